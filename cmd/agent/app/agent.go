@@ -2,174 +2,262 @@ package app
 
 import (
 	"bytes"
-	"compress/gzip"
+	gz "compress/gzip"
 	"context"
+	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"go-metrics/internal/collectors"
 	"go-metrics/internal/domain"
 	"go-metrics/internal/errors"
+	"go-metrics/internal/proto"
+	"go-metrics/internal/repositories"
 	"go-metrics/pkg/log"
-	"math/rand/v2"
 	"net/http"
-	"runtime"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
-
-	"github.com/shirou/gopsutil/cpu"
-	"github.com/shirou/gopsutil/mem"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
 )
 
+type scheduledCollector struct {
+	spec      collectors.Spec
+	collector collectors.Collector
+}
+
 type MetricAgent struct {
-	config      *Config
-	client      *resty.Client
-	metricsChan chan []domain.Metric
-	workerPool  chan struct{}
-	workerCount int
-	mu          sync.Mutex
+	config          *Config
+	client          *resty.Client
+	metricsChan     chan []domain.Metric
+	workerPool      chan struct{}
+	workerCount     int
+	collectors      []scheduledCollector
+	metrics         []domain.Metric
+	grpcConn        *grpc.ClientConn
+	grpcClient      proto.MetricsClient
+	spool           *repositories.MetricSpoolFileRepository
+	reportedDropped int64
+	mu              sync.Mutex
+	replayMu        sync.Mutex
 }
 
-func NewMetricAgent(config *Config) *MetricAgent {
-	return &MetricAgent{
+func NewMetricAgent(config *Config) (*MetricAgent, error) {
+	agent := &MetricAgent{
 		config:      config,
 		client:      resty.New(),
 		metricsChan: make(chan []domain.Metric, config.RateLimit),
 		workerPool:  make(chan struct{}, config.RateLimit),
 		workerCount: config.RateLimit,
 	}
+	specs, err := collectors.ParseSpecs(config.Collectors, time.Duration(config.PollInterval)*time.Second)
+	if err != nil {
+		log.Error("Invalid collectors configuration", "error", err)
+		return nil, err
+	}
+	options := &collectors.Options{GCPauseBuckets: config.GCPauseBuckets}
+	for _, spec := range specs {
+		collector, err := collectors.DefaultRegistry.New(spec.Name, options)
+		if err != nil {
+			log.Error("Failed to create collector", "collector", spec.Name, "error", err)
+			return nil, err
+		}
+		agent.collectors = append(agent.collectors, scheduledCollector{spec: spec, collector: collector})
+	}
+	if config.GetTransport() == TransportGRPC {
+		conn, err := grpc.NewClient(
+			config.GetGRPCAddress(),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithDefaultCallOptions(grpc.UseCompressor(gzip.Name)),
+		)
+		if err != nil {
+			log.Error("Failed to create gRPC client", "error", err)
+			return nil, err
+		}
+		agent.grpcConn = conn
+		agent.grpcClient = proto.NewMetricsClient(conn)
+	}
+	if dir := config.GetSpoolDir(); dir != "" {
+		spool, err := repositories.NewMetricSpoolFileRepository(
+			dir, config.SpoolMaxBatches, config.SpoolMaxBytes, config.GetSpoolMaxAge(),
+		)
+		if err != nil {
+			log.Error("Failed to open spool", "error", err)
+			return nil, err
+		}
+		agent.spool = spool
+		log.Info("Spool opened", "dir", dir, "queued", spool.Len())
+	}
+	return agent, nil
 }
 
 func (ma *MetricAgent) Start(ctx context.Context) error {
-	tickerPoll := time.NewTicker(time.Duration(ma.config.PollInterval) * time.Second)
 	tickerReport := time.NewTicker(time.Duration(ma.config.ReportInterval) * time.Second)
-	defer tickerPoll.Stop()
 	defer tickerReport.Stop()
-	var metrics []domain.Metric
 	for i := 0; i < ma.workerCount; i++ {
 		go ma.worker(ctx)
 	}
+	for _, scheduled := range ma.collectors {
+		go ma.poll(ctx, scheduled.spec, scheduled.collector)
+	}
 	for {
 		select {
-		case <-tickerPoll.C:
-			ma.mu.Lock()
-			metrics = ma.collectMetrics(metrics)
-			ma.mu.Unlock()
-			log.Info("Metrics collected", "metrics_count", len(metrics))
 		case <-tickerReport.C:
 			ma.mu.Lock()
-			ma.metricsChan <- metrics
-			metrics = nil
+			metrics := ma.collectSpoolMetrics(ma.metrics)
+			ma.metrics = nil
 			ma.mu.Unlock()
+			ma.report(ctx, metrics)
 		case <-ctx.Done():
 			log.Info("Shutting down metric agent")
+			if ma.grpcConn != nil {
+				ma.grpcConn.Close()
+			}
+			ma.mu.Lock()
+			metrics := ma.metrics
+			ma.metrics = nil
+			ma.mu.Unlock()
+			if ma.spool != nil {
+				ma.spoolPending(metrics)
+			}
 			return nil
 		}
 	}
 }
 
+func (ma *MetricAgent) report(ctx context.Context, metrics []domain.Metric) {
+	if ma.spool == nil {
+		ma.metricsChan <- metrics
+		return
+	}
+	select {
+	case ma.metricsChan <- metrics:
+	default:
+		log.Info("Workers are busy, spooling metrics", "metrics_count", len(metrics))
+		if err := ma.spool.Push(ctx, metrics); err != nil {
+			log.Error("Failed to spool metrics", "error", err)
+		}
+	}
+}
+
 func (ma *MetricAgent) worker(ctx context.Context) {
 	for metrics := range ma.metricsChan {
 		ma.workerPool <- struct{}{}
-		err := ma.sendMetrics(ctx, metrics)
-		if err != nil {
-			log.Error("Failed to send metrics", "error", err)
-		}
+		ma.deliver(ctx, metrics)
 		<-ma.workerPool
 	}
 }
 
-func (ma *MetricAgent) collectMetrics(metrics []domain.Metric) []domain.Metric {
-	metrics = ma.collectCounterMetrics(metrics)
-	metrics = ma.collectGaugeMetrics(metrics)
-	metrics = ma.collectAdditionalGaugeMetrics(metrics)
-	return metrics
-}
-
-func (ma *MetricAgent) collectGaugeMetrics(metrics []domain.Metric) []domain.Metric {
-	var memStats runtime.MemStats
-	runtime.ReadMemStats(&memStats)
-	float64ptr := func(value float64) *float64 { return &value }
-	metrics = append(metrics, []domain.Metric{
-		{MetricID: domain.MetricID{ID: "Alloc", Type: domain.Gauge}, Value: float64ptr(float64(memStats.Alloc))},
-		{MetricID: domain.MetricID{ID: "BuckHashSys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.BuckHashSys))},
-		{MetricID: domain.MetricID{ID: "Frees", Type: domain.Gauge}, Value: float64ptr(float64(memStats.Frees))},
-		{MetricID: domain.MetricID{ID: "GCCPUFraction", Type: domain.Gauge}, Value: &memStats.GCCPUFraction},
-		{MetricID: domain.MetricID{ID: "GCSys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.GCSys))},
-		{MetricID: domain.MetricID{ID: "HeapAlloc", Type: domain.Gauge}, Value: float64ptr(float64(memStats.HeapAlloc))},
-		{MetricID: domain.MetricID{ID: "HeapIdle", Type: domain.Gauge}, Value: float64ptr(float64(memStats.HeapIdle))},
-		{MetricID: domain.MetricID{ID: "HeapInuse", Type: domain.Gauge}, Value: float64ptr(float64(memStats.HeapInuse))},
-		{MetricID: domain.MetricID{ID: "HeapObjects", Type: domain.Gauge}, Value: float64ptr(float64(memStats.HeapObjects))},
-		{MetricID: domain.MetricID{ID: "HeapReleased", Type: domain.Gauge}, Value: float64ptr(float64(memStats.HeapReleased))},
-		{MetricID: domain.MetricID{ID: "HeapSys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.HeapSys))},
-		{MetricID: domain.MetricID{ID: "LastGC", Type: domain.Gauge}, Value: float64ptr(float64(memStats.LastGC))},
-		{MetricID: domain.MetricID{ID: "Lookups", Type: domain.Gauge}, Value: float64ptr(float64(memStats.Lookups))},
-		{MetricID: domain.MetricID{ID: "MCacheInuse", Type: domain.Gauge}, Value: float64ptr(float64(memStats.MCacheInuse))},
-		{MetricID: domain.MetricID{ID: "MCacheSys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.MCacheSys))},
-		{MetricID: domain.MetricID{ID: "MSpanInuse", Type: domain.Gauge}, Value: float64ptr(float64(memStats.MSpanInuse))},
-		{MetricID: domain.MetricID{ID: "MSpanSys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.MSpanSys))},
-		{MetricID: domain.MetricID{ID: "Mallocs", Type: domain.Gauge}, Value: float64ptr(float64(memStats.Mallocs))},
-		{MetricID: domain.MetricID{ID: "NextGC", Type: domain.Gauge}, Value: float64ptr(float64(memStats.NextGC))},
-		{MetricID: domain.MetricID{ID: "NumForcedGC", Type: domain.Gauge}, Value: float64ptr(float64(memStats.NumForcedGC))},
-		{MetricID: domain.MetricID{ID: "NumGC", Type: domain.Gauge}, Value: float64ptr(float64(memStats.NumGC))},
-		{MetricID: domain.MetricID{ID: "OtherSys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.OtherSys))},
-		{MetricID: domain.MetricID{ID: "PauseTotalNs", Type: domain.Gauge}, Value: float64ptr(float64(memStats.PauseTotalNs))},
-		{MetricID: domain.MetricID{ID: "StackInuse", Type: domain.Gauge}, Value: float64ptr(float64(memStats.StackInuse))},
-		{MetricID: domain.MetricID{ID: "StackSys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.StackSys))},
-		{MetricID: domain.MetricID{ID: "Sys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.Sys))},
-		{MetricID: domain.MetricID{ID: "TotalAlloc", Type: domain.Gauge}, Value: float64ptr(float64(memStats.TotalAlloc))},
-		{MetricID: domain.MetricID{ID: "RandomValue", Type: domain.Gauge}, Value: float64ptr(rand.Float64())},
-	}...)
-	return metrics
+func (ma *MetricAgent) spoolPending(metrics []domain.Metric) {
+	for {
+		select {
+		case batch := <-ma.metricsChan:
+			if err := ma.spool.Push(context.Background(), batch); err != nil {
+				log.Error("Failed to spool metrics on shutdown", "error", err)
+			}
+		default:
+			if len(metrics) == 0 {
+				return
+			}
+			if err := ma.spool.Push(context.Background(), metrics); err != nil {
+				log.Error("Failed to spool metrics on shutdown", "error", err)
+			}
+			return
+		}
+	}
 }
 
-func (ma *MetricAgent) collectAdditionalGaugeMetrics(metrics []domain.Metric) []domain.Metric {
-	vmStat, err := mem.VirtualMemory()
-	if err != nil {
-		log.Error("Failed to get virtual memory stats", "error", err)
-		return metrics
+func (ma *MetricAgent) deliver(ctx context.Context, metrics []domain.Metric) {
+	if ma.spool == nil {
+		if err := ma.sendMetrics(ctx, metrics); err != nil {
+			log.Error("Failed to send metrics", "error", err)
+		}
+		return
 	}
-	float64ptr := func(value float64) *float64 { return &value }
-	metrics = append(metrics, []domain.Metric{
-		{MetricID: domain.MetricID{ID: "TotalMemory", Type: domain.Gauge}, Value: float64ptr(float64(vmStat.Total))},
-		{MetricID: domain.MetricID{ID: "FreeMemory", Type: domain.Gauge}, Value: float64ptr(float64(vmStat.Free))},
-	}...)
-	cpuPercent, err := cpu.Percent(time.Second, false)
-	if err != nil {
-		log.Error("Failed to get CPU utilization", "error", err)
-		return metrics
+	if ma.replay(ctx) {
+		err := ma.sendMetrics(ctx, metrics)
+		if err == nil {
+			return
+		}
+		log.Error("Failed to send metrics, spooling them", "error", err)
 	}
-	metrics = append(metrics, domain.Metric{
-		MetricID: domain.MetricID{ID: "CPUutilization1", Type: domain.Gauge},
-		Value:    float64ptr(cpuPercent[0]),
-	})
-	coreCPUPercent, err := cpu.Percent(time.Second, true)
-	if err != nil {
-		log.Error("Failed to get per-core CPU utilization", "error", err)
-		return metrics
+	if err := ma.spool.Push(ctx, metrics); err != nil {
+		log.Error("Failed to spool metrics", "error", err)
 	}
-	for i, util := range coreCPUPercent {
-		metrics = append(metrics, domain.Metric{
-			MetricID: domain.MetricID{ID: fmt.Sprintf("CPUutilization%d", i+1), Type: domain.Gauge},
-			Value:    float64ptr(util),
-		})
+}
+
+func (ma *MetricAgent) replay(ctx context.Context) bool {
+	ma.replayMu.Lock()
+	defer ma.replayMu.Unlock()
+	for ctx.Err() == nil {
+		batch, err := ma.spool.Front(ctx)
+		if err != nil {
+			log.Error("Failed to read spooled metrics", "error", err)
+			return false
+		}
+		if batch == nil {
+			return true
+		}
+		if err := ma.sendMetrics(ctx, batch.Metrics); err != nil {
+			log.Error("Failed to send metrics, keeping them spooled", "error", err, "queued", ma.spool.Len())
+			return false
+		}
+		if err := ma.spool.Remove(ctx, batch.ID); err != nil {
+			log.Error("Failed to remove spooled metrics", "error", err)
+			return false
+		}
 	}
-	return metrics
+	return false
 }
 
-func (ma *MetricAgent) collectCounterMetrics(metrics []domain.Metric) []domain.Metric {
-	int64ptr := func(value int64) *int64 { return &value }
-	metrics = append(metrics, domain.Metric{
-		MetricID: domain.MetricID{ID: "PollCount", Type: domain.Counter},
-		Delta:    int64ptr(1),
-	})
-	return metrics
+func (ma *MetricAgent) poll(ctx context.Context, spec collectors.Spec, collector collectors.Collector) {
+	ticker := time.NewTicker(spec.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			metrics, err := collector.Collect(ctx)
+			if err != nil {
+				log.Error("Failed to collect metrics", "collector", spec.Name, "error", err)
+				continue
+			}
+			ma.mu.Lock()
+			ma.metrics = append(ma.metrics, metrics...)
+			ma.mu.Unlock()
+			log.Info("Metrics collected", "collector", spec.Name, "metrics_count", len(metrics))
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (ma *MetricAgent) collectSpoolMetrics(metrics []domain.Metric) []domain.Metric {
+	if ma.spool == nil {
+		return metrics
+	}
+	queued := float64(ma.spool.Len())
+	dropped := ma.spool.Dropped()
+	delta := dropped - ma.reportedDropped
+	ma.reportedDropped = dropped
+	return append(metrics, []domain.Metric{
+		{MetricID: domain.MetricID{ID: "SpoolQueuedBatches", Type: domain.Gauge}, Value: &queued},
+		{MetricID: domain.MetricID{ID: "SpoolDroppedBatches", Type: domain.Counter}, Delta: &delta},
+	}...)
 }
 
 func (ma *MetricAgent) sendMetrics(ctx context.Context, metrics []domain.Metric) error {
+	if ma.grpcClient != nil {
+		return ma.sendMetricsGRPC(ctx, metrics)
+	}
+	return ma.sendMetricsHTTP(ctx, metrics)
+}
+
+func (ma *MetricAgent) sendMetricsHTTP(ctx context.Context, metrics []domain.Metric) error {
 	url := ma.getURL(ma.config.Address)
 	body, err := json.Marshal(metrics)
 	if err != nil {
@@ -182,7 +270,7 @@ func (ma *MetricAgent) sendMetrics(ctx context.Context, metrics []domain.Metric)
 	key := ma.config.Key
 	var hash string
 	if key != "" {
-		hash = ma.computeHMAC(key)
+		hash = ma.computeHMAC(body, key)
 	}
 	attempts := 0
 	retryIntervals := []time.Duration{1 * time.Second, 3 * time.Second, 5 * time.Second}
@@ -214,16 +302,31 @@ func (ma *MetricAgent) sendMetrics(ctx context.Context, metrics []domain.Metric)
 			}
 			return fmt.Errorf("failed to send metrics, status code: %d", resp.StatusCode())
 		}
+		if key != "" {
+			if err := ma.verifyHMAC(resp.Body(), key, resp.Header().Get("HashSHA256")); err != nil {
+				return err
+			}
+		}
 		log.Info("Metrics sent successfully", "metrics_count", len(metrics))
 		return nil
 	}
 	return fmt.Errorf("failed to send metrics after multiple attempts")
 }
 
-func (ma *MetricAgent) computeHMAC(key string) string {
-	hash := sha256.New()
-	hash.Write([]byte(key))
-	return hex.EncodeToString(hash.Sum(nil))
+func (ma *MetricAgent) computeHMAC(data []byte, key string) string {
+	h := hmac.New(sha256.New, []byte(key))
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (ma *MetricAgent) verifyHMAC(data []byte, key string, hash string) error {
+	if hash == "" {
+		return fmt.Errorf("response is not signed")
+	}
+	if !hmac.Equal([]byte(hash), []byte(ma.computeHMAC(data, key))) {
+		return fmt.Errorf("response signature mismatch")
+	}
+	return nil
 }
 
 func (ma *MetricAgent) getURL(address string) string {
@@ -235,7 +338,7 @@ func (ma *MetricAgent) getURL(address string) string {
 
 func (ma *MetricAgent) compress(data []byte) ([]byte, error) {
 	var buf bytes.Buffer
-	gzipWriter := gzip.NewWriter(&buf)
+	gzipWriter := gz.NewWriter(&buf)
 	_, err := gzipWriter.Write(data)
 	if err != nil {
 		return nil, fmt.Errorf("failed to write to gzip: %w", err)
@@ -1,6 +1,7 @@
 package app
 
 import (
+	"go-metrics/internal/converters"
 	"go-metrics/pkg/context"
 	"go-metrics/pkg/log"
 
@@ -9,34 +10,74 @@ import (
 )
 
 const (
-	DefaultAddress        = "localhost:8080"
-	DefaultReportInterval = 10
-	DefaultPollInterval   = 2
-	DefaultRateLimit      = 1
+	DefaultAddress         = "localhost:8080"
+	DefaultReportInterval  = 10
+	DefaultPollInterval    = 2
+	DefaultRateLimit       = 1
+	DefaultGCPauseBuckets  = "10000,50000,100000,250000,500000,1000000,5000000,10000000"
+	DefaultSpoolDir        = "data/spool"
+	DefaultSpoolMaxBatches = 1000
+	DefaultSpoolMaxBytes   = 64 << 20
+	DefaultSpoolMaxAge     = 86400
+	DefaultCollectors      = "runtime,memory,cpu"
+	DefaultTransport       = TransportHTTP
+	DefaultGRPCAddress     = "localhost:3200"
 
-	FlagAddress        = "address"
-	FlagReportInterval = "report-interval"
-	FlagPollInterval   = "poll-interval"
-	FlagKey            = "key"
-	FlagRateLimit      = "rate-limit"
+	FlagAddress         = "address"
+	FlagReportInterval  = "report-interval"
+	FlagPollInterval    = "poll-interval"
+	FlagKey             = "key"
+	FlagRateLimit       = "rate-limit"
+	FlagGCPauseBuckets  = "gc-pause-buckets"
+	FlagSpoolDir        = "spool-dir"
+	FlagSpoolMaxBatches = "spool-max-batches"
+	FlagSpoolMaxBytes   = "spool-max-bytes"
+	FlagSpoolMaxAge     = "spool-max-age"
+	FlagCollectors      = "collectors"
+	FlagTransport       = "transport"
+	FlagGRPCAddress     = "grpc-address"
 
-	ShortFlagAddress        = "a"
-	ShortFlagReportInterval = "r"
-	ShortFlagPollInterval   = "p"
-	ShortFlagKey            = "k"
-	ShortFlagRateLimit      = "l"
+	ShortFlagAddress         = "a"
+	ShortFlagReportInterval  = "r"
+	ShortFlagPollInterval    = "p"
+	ShortFlagKey             = "k"
+	ShortFlagRateLimit       = "l"
+	ShortFlagGCPauseBuckets  = "b"
+	ShortFlagSpoolDir        = "s"
+	ShortFlagSpoolMaxBatches = "n"
+	ShortFlagSpoolMaxBytes   = "m"
+	ShortFlagSpoolMaxAge     = "g"
+	ShortFlagCollectors      = "c"
+	ShortFlagTransport       = "t"
+	ShortFlagGRPCAddress     = "G"
 
-	EnvAddress        = "ADDRESS"
-	EnvReportInterval = "REPORT_INTERVAL"
-	EnvPollInterval   = "POLL_INTERVAL"
-	EnvKey            = "KEY"
-	EnvRateLimit      = "RATE_LIMIT"
+	EnvAddress         = "ADDRESS"
+	EnvReportInterval  = "REPORT_INTERVAL"
+	EnvPollInterval    = "POLL_INTERVAL"
+	EnvKey             = "KEY"
+	EnvRateLimit       = "RATE_LIMIT"
+	EnvGCPauseBuckets  = "GC_PAUSE_BUCKETS"
+	EnvSpoolDir        = "SPOOL_DIR"
+	EnvSpoolMaxBatches = "SPOOL_MAX_BATCHES"
+	EnvSpoolMaxBytes   = "SPOOL_MAX_BYTES"
+	EnvSpoolMaxAge     = "SPOOL_MAX_AGE"
+	EnvCollectors      = "COLLECTORS"
+	EnvTransport       = "TRANSPORT"
+	EnvGRPCAddress     = "GRPC_ADDRESS"
 
-	DescriptionAddress        = "Address of the HTTP server endpoint"
-	DescriptionReportInterval = "Interval in seconds for sending metrics to the server"
-	DescriptionPollInterval   = "Interval in seconds for polling metrics from the runtime package"
-	DescriptionKey            = "Secret key for data signing"
-	DescriptionRateLimit      = "Limit the number of concurrent outgoing requests"
+	DescriptionAddress         = "Address of the HTTP server endpoint"
+	DescriptionReportInterval  = "Interval in seconds for sending metrics to the server"
+	DescriptionPollInterval    = "Interval in seconds for polling metrics from the runtime package"
+	DescriptionKey             = "Secret key for data signing"
+	DescriptionRateLimit       = "Limit the number of concurrent outgoing requests"
+	DescriptionGCPauseBuckets  = "Comma-separated histogram bucket bounds in nanoseconds for GC pauses"
+	DescriptionSpoolDir        = "Directory for batches that could not be delivered (empty disables the spool)"
+	DescriptionSpoolMaxBatches = "Maximum number of spooled batches (0 means unlimited)"
+	DescriptionSpoolMaxBytes   = "Maximum total size in bytes of spooled batches (0 means unlimited)"
+	DescriptionSpoolMaxAge     = "Maximum age in seconds of a spooled batch (0 means unlimited)"
+	DescriptionCollectors      = "Comma-separated collectors to enable as name[:seconds], the poll interval is used when seconds are omitted"
+	DescriptionTransport       = "Transport used to send metrics: 'http' or 'grpc'"
+	DescriptionGRPCAddress     = "Address of the gRPC server endpoint"
 )
 
 func NewCommand() *cobra.Command {
@@ -47,14 +88,34 @@ func NewCommand() *cobra.Command {
 		Short: "Metrics Agent for collecting and sending metrics",
 		RunE: func(cmd *cobra.Command, args []string) error {
 			log.Init(log.LevelInfo)
+			gcPauseBuckets, err := converters.ConvertToSortedFloat64Slice(viper.GetString(EnvGCPauseBuckets))
+			if err != nil {
+				log.Error("Invalid GC pause buckets", "error", err)
+				return err
+			}
 			config := &Config{
-				Address:        viper.GetString(EnvAddress),
-				ReportInterval: viper.GetInt(EnvReportInterval),
-				PollInterval:   viper.GetInt(EnvPollInterval),
-				Key:            viper.GetString(EnvKey),
-				RateLimit:      viper.GetInt(EnvRateLimit),
+				Address:         viper.GetString(EnvAddress),
+				ReportInterval:  viper.GetInt(EnvReportInterval),
+				PollInterval:    viper.GetInt(EnvPollInterval),
+				Key:             viper.GetString(EnvKey),
+				RateLimit:       viper.GetInt(EnvRateLimit),
+				GCPauseBuckets:  gcPauseBuckets,
+				SpoolDir:        viper.GetString(EnvSpoolDir),
+				SpoolMaxBatches: viper.GetInt(EnvSpoolMaxBatches),
+				SpoolMaxBytes:   viper.GetInt64(EnvSpoolMaxBytes),
+				SpoolMaxAge:     viper.GetInt(EnvSpoolMaxAge),
+				Collectors:      viper.GetString(EnvCollectors),
+				Transport:       viper.GetString(EnvTransport),
+				GRPCAddress:     viper.GetString(EnvGRPCAddress),
+			}
+			if config.Transport != TransportHTTP && config.Transport != TransportGRPC {
+				log.Error("Invalid transport", "transport", config.Transport)
+				return ErrInvalidTransport
+			}
+			agent, err := NewMetricAgent(config)
+			if err != nil {
+				return err
 			}
-			agent := NewMetricAgent(config)
 			ctx, cancel := context.NewContext()
 			defer cancel()
 			return agent.Start(ctx)
@@ -66,12 +127,28 @@ func NewCommand() *cobra.Command {
 	cmd.PersistentFlags().IntP(FlagPollInterval, ShortFlagPollInterval, DefaultPollInterval, DescriptionPollInterval)
 	cmd.PersistentFlags().StringP(FlagKey, ShortFlagKey, "", DescriptionKey)
 	cmd.PersistentFlags().IntP(FlagRateLimit, ShortFlagRateLimit, DefaultRateLimit, DescriptionRateLimit)
+	cmd.PersistentFlags().StringP(FlagGCPauseBuckets, ShortFlagGCPauseBuckets, DefaultGCPauseBuckets, DescriptionGCPauseBuckets)
+	cmd.PersistentFlags().StringP(FlagSpoolDir, ShortFlagSpoolDir, DefaultSpoolDir, DescriptionSpoolDir)
+	cmd.PersistentFlags().IntP(FlagSpoolMaxBatches, ShortFlagSpoolMaxBatches, DefaultSpoolMaxBatches, DescriptionSpoolMaxBatches)
+	cmd.PersistentFlags().Int64P(FlagSpoolMaxBytes, ShortFlagSpoolMaxBytes, DefaultSpoolMaxBytes, DescriptionSpoolMaxBytes)
+	cmd.PersistentFlags().IntP(FlagSpoolMaxAge, ShortFlagSpoolMaxAge, DefaultSpoolMaxAge, DescriptionSpoolMaxAge)
+	cmd.PersistentFlags().StringP(FlagCollectors, ShortFlagCollectors, DefaultCollectors, DescriptionCollectors)
+	cmd.PersistentFlags().StringP(FlagTransport, ShortFlagTransport, DefaultTransport, DescriptionTransport)
+	cmd.PersistentFlags().StringP(FlagGRPCAddress, ShortFlagGRPCAddress, DefaultGRPCAddress, DescriptionGRPCAddress)
 
 	viper.BindPFlag(EnvAddress, cmd.PersistentFlags().Lookup(FlagAddress))
 	viper.BindPFlag(EnvReportInterval, cmd.PersistentFlags().Lookup(FlagReportInterval))
 	viper.BindPFlag(EnvPollInterval, cmd.PersistentFlags().Lookup(FlagPollInterval))
 	viper.BindPFlag(EnvKey, cmd.PersistentFlags().Lookup(FlagKey))
 	viper.BindPFlag(EnvRateLimit, cmd.PersistentFlags().Lookup(FlagRateLimit))
+	viper.BindPFlag(EnvGCPauseBuckets, cmd.PersistentFlags().Lookup(FlagGCPauseBuckets))
+	viper.BindPFlag(EnvSpoolDir, cmd.PersistentFlags().Lookup(FlagSpoolDir))
+	viper.BindPFlag(EnvSpoolMaxBatches, cmd.PersistentFlags().Lookup(FlagSpoolMaxBatches))
+	viper.BindPFlag(EnvSpoolMaxBytes, cmd.PersistentFlags().Lookup(FlagSpoolMaxBytes))
+	viper.BindPFlag(EnvSpoolMaxAge, cmd.PersistentFlags().Lookup(FlagSpoolMaxAge))
+	viper.BindPFlag(EnvCollectors, cmd.PersistentFlags().Lookup(FlagCollectors))
+	viper.BindPFlag(EnvTransport, cmd.PersistentFlags().Lookup(FlagTransport))
+	viper.BindPFlag(EnvGRPCAddress, cmd.PersistentFlags().Lookup(FlagGRPCAddress))
 
 	return cmd
 }
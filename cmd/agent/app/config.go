@@ -1,13 +1,49 @@
 package app
 
+import (
+	"errors"
+	"time"
+)
+
+const (
+	TransportHTTP = "http"
+	TransportGRPC = "grpc"
+)
+
+var ErrInvalidTransport = errors.New("invalid transport: must be 'http' or 'grpc'")
+
 type Config struct {
-	Address        string
-	PollInterval   int
-	ReportInterval int
-	Key            string
-	RateLimit      int
+	Address         string
+	PollInterval    int
+	ReportInterval  int
+	Key             string
+	RateLimit       int
+	GCPauseBuckets  []float64
+	SpoolDir        string
+	SpoolMaxBatches int
+	SpoolMaxBytes   int64
+	SpoolMaxAge     int
+	Collectors      string
+	Transport       string
+	GRPCAddress     string
 }
 
 func (c *Config) GetAddress() string {
 	return c.Address
 }
+
+func (c *Config) GetSpoolDir() string {
+	return c.SpoolDir
+}
+
+func (c *Config) GetSpoolMaxAge() time.Duration {
+	return time.Duration(c.SpoolMaxAge) * time.Second
+}
+
+func (c *Config) GetTransport() string {
+	return c.Transport
+}
+
+func (c *Config) GetGRPCAddress() string {
+	return c.GRPCAddress
+}
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"go-metrics/internal/converters"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/middlewares"
+	"go-metrics/internal/proto"
+	"go-metrics/pkg/log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func (ma *MetricAgent) sendMetricsGRPC(ctx context.Context, metrics []domain.Metric) error {
+	req := &proto.UpdateBatchRequest{Metrics: make([]*proto.Metric, 0, len(metrics))}
+	for i := range metrics {
+		req.Metrics = append(req.Metrics, converters.ConvertMetricToProto(&metrics[i]))
+	}
+	key := ma.config.Key
+	callCtx := ctx
+	if key != "" {
+		data, err := middlewares.MarshalProtoForHMAC(req)
+		if err != nil {
+			return fmt.Errorf("failed to marshal metrics: %w", err)
+		}
+		callCtx = metadata.AppendToOutgoingContext(ctx, middlewares.MetadataHashSHA256, ma.computeHMAC(data, key))
+	}
+	attempts := 0
+	retryIntervals := []time.Duration{1 * time.Second, 3 * time.Second, 5 * time.Second}
+	for {
+		var header metadata.MD
+		resp, err := ma.grpcClient.UpdateBatch(callCtx, req, grpc.Header(&header))
+		if err != nil {
+			if status.Code(err) == codes.Unavailable && attempts < len(retryIntervals) {
+				log.Info("Temporary error, retrying", "attempt", attempts+1, "error", err)
+				time.Sleep(retryIntervals[attempts])
+				attempts++
+				continue
+			}
+			return fmt.Errorf("failed to send metrics: %w", err)
+		}
+		if key != "" {
+			data, err := middlewares.MarshalProtoForHMAC(resp)
+			if err != nil {
+				return fmt.Errorf("failed to marshal response: %w", err)
+			}
+			var hash string
+			if values := header.Get(middlewares.MetadataHashSHA256); len(values) > 0 {
+				hash = values[0]
+			}
+			if err := ma.verifyHMAC(data, key, hash); err != nil {
+				return err
+			}
+		}
+		log.Info("Metrics sent successfully", "metrics_count", len(metrics), "transport", TransportGRPC)
+		return nil
+	}
+}
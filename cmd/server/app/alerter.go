@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+	"go-metrics/pkg/log"
+	"time"
+)
+
+type Alerter struct {
+	config    *Config
+	container *Container
+}
+
+func NewAlerter(config *Config, container *Container) *Alerter {
+	return &Alerter{
+		config:    config,
+		container: container,
+	}
+}
+
+func (a *Alerter) Start(ctx context.Context) {
+	if a.config.GetAlertRules() == "" || a.config.GetAlertInterval() <= 0 {
+		log.Info("Alerting rules are not configured, alerter is disabled")
+		return
+	}
+	ticker := time.NewTicker(a.config.GetAlertInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			if err := a.container.AlertEvaluateService.Evaluate(ctx, now); err != nil {
+				log.Error("Failed to evaluate alerting rules", "error", err)
+			}
+		case <-ctx.Done():
+			log.Info("Alerter is stopping")
+			return
+		}
+	}
+}
@@ -9,38 +9,103 @@ import (
 )
 
 const (
-	DefaultAddress         = "localhost:8080"
-	DefaultStoreInterval   = 300
-	DefaultFileStoragePath = "data/metrics.json"
-	DefaultRestore         = true
+	DefaultAddress             = "localhost:8080"
+	DefaultStoreInterval       = 300
+	DefaultFileStoragePath     = "data/metrics.json"
+	DefaultRestore             = true
+	DefaultHistoryRetention    = 3600
+	DefaultAlertInterval       = 15
+	DefaultGRPCAddress         = ""
+	DefaultStatsDFlushInterval = 10
+	DefaultGraphiteMaxConns    = 100
+	DefaultGraphiteMaxLine     = 4096
+	DefaultStreamBufferSize    = 256
+	DefaultStreamKeepAlive     = 15
+	DefaultDerivedInterval     = 10
 
-	FlagAddress         = "address"
-	FlagStoreInterval   = "store-interval"
-	FlagFileStoragePath = "file-storage-path"
-	FlagRestore         = "restore"
-	FlagDatabaseDSN     = "database-dsn"
-	FlagKey             = "key"
+	FlagAddress             = "address"
+	FlagStoreInterval       = "store-interval"
+	FlagFileStoragePath     = "file-storage-path"
+	FlagRestore             = "restore"
+	FlagDatabaseDSN         = "database-dsn"
+	FlagKey                 = "key"
+	FlagHistoryRetention    = "history-retention"
+	FlagAlertRules          = "alert-rules"
+	FlagAlertWebhook        = "alert-webhook"
+	FlagAlertInterval       = "alert-interval"
+	FlagGRPCAddress         = "grpc-address"
+	FlagStatsDAddress       = "statsd-address"
+	FlagStatsDFlushInterval = "statsd-flush-interval"
+	FlagGraphiteAddress     = "graphite-address"
+	FlagGraphiteMaxConns    = "graphite-max-connections"
+	FlagGraphiteMaxLine     = "graphite-max-line-bytes"
+	FlagStreamBufferSize    = "stream-buffer-size"
+	FlagStreamKeepAlive     = "stream-keepalive-interval"
+	FlagDerivedMetrics      = "derived-metrics"
+	FlagDerivedInterval     = "derived-interval"
 
-	ShortFlagAddress         = "a"
-	ShortFlagStoreInterval   = "i"
-	ShortFlagFileStoragePath = "f"
-	ShortFlagRestore         = "r"
-	ShortFlagDatabaseDSN     = "d"
-	ShortFlagKey             = "k"
+	ShortFlagAddress             = "a"
+	ShortFlagStoreInterval       = "i"
+	ShortFlagFileStoragePath     = "f"
+	ShortFlagRestore             = "r"
+	ShortFlagDatabaseDSN         = "d"
+	ShortFlagKey                 = "k"
+	ShortFlagHistoryRetention    = "H"
+	ShortFlagAlertRules          = "R"
+	ShortFlagAlertWebhook        = "W"
+	ShortFlagAlertInterval       = "E"
+	ShortFlagGRPCAddress         = "g"
+	ShortFlagStatsDAddress       = "s"
+	ShortFlagStatsDFlushInterval = "S"
+	ShortFlagGraphiteAddress     = "G"
+	ShortFlagGraphiteMaxConns    = "N"
+	ShortFlagGraphiteMaxLine     = "L"
+	ShortFlagStreamBufferSize    = "B"
+	ShortFlagStreamKeepAlive     = "K"
+	ShortFlagDerivedMetrics      = "D"
+	ShortFlagDerivedInterval     = "I"
 
-	EnvAddress         = "ADDRESS"
-	EnvStoreInterval   = "STORE_INTERVAL"
-	EnvFileStoragePath = "FILE_STORAGE_PATH"
-	EnvRestore         = "RESTORE"
-	EnvDatabaseDSN     = "DATABASE_DSN"
-	EnvKey             = "KEY"
+	EnvAddress             = "ADDRESS"
+	EnvStoreInterval       = "STORE_INTERVAL"
+	EnvFileStoragePath     = "FILE_STORAGE_PATH"
+	EnvRestore             = "RESTORE"
+	EnvDatabaseDSN         = "DATABASE_DSN"
+	EnvKey                 = "KEY"
+	EnvHistoryRetention    = "HISTORY_RETENTION"
+	EnvAlertRules          = "ALERT_RULES"
+	EnvAlertWebhook        = "ALERT_WEBHOOK"
+	EnvAlertInterval       = "ALERT_INTERVAL"
+	EnvGRPCAddress         = "GRPC_ADDRESS"
+	EnvStatsDAddress       = "STATSD_ADDRESS"
+	EnvStatsDFlushInterval = "STATSD_FLUSH_INTERVAL"
+	EnvGraphiteAddress     = "GRAPHITE_ADDRESS"
+	EnvGraphiteMaxConns    = "GRAPHITE_MAX_CONNECTIONS"
+	EnvGraphiteMaxLine     = "GRAPHITE_MAX_LINE_BYTES"
+	EnvStreamBufferSize    = "STREAM_BUFFER_SIZE"
+	EnvStreamKeepAlive     = "STREAM_KEEPALIVE_INTERVAL"
+	EnvDerivedMetrics      = "DERIVED_METRICS"
+	EnvDerivedInterval     = "DERIVED_INTERVAL"
 
-	DescriptionAddress         = "Address of the HTTP server endpoint"
-	DescriptionStoreInterval   = "Interval in seconds to store metrics to disk"
-	DescriptionFileStoragePath = "Path to the file to store metrics"
-	DescriptionRestore         = "Whether to load previously saved values on server startup"
-	DescriptionDatabaseDSN     = "Database DSN"
-	DescriptionKey             = "Secret key for data signing"
+	DescriptionAddress             = "Address of the HTTP server endpoint"
+	DescriptionStoreInterval       = "Interval in seconds to store metrics to disk, 0 persists every update synchronously"
+	DescriptionFileStoragePath     = "Path to the file to store metrics"
+	DescriptionRestore             = "Whether to load previously saved values on server startup"
+	DescriptionDatabaseDSN         = "Database DSN"
+	DescriptionKey                 = "Secret key for data signing"
+	DescriptionHistoryRetention    = "Retention window in seconds for metric history samples (0 keeps everything)"
+	DescriptionAlertRules          = "Path to the JSON file with alerting rules"
+	DescriptionAlertWebhook        = "Webhook URL that receives alert state changes"
+	DescriptionAlertInterval       = "Interval in seconds to evaluate alerting rules"
+	DescriptionGRPCAddress         = "Address of the gRPC server endpoint (empty disables gRPC)"
+	DescriptionStatsDAddress       = "UDP address of the StatsD listener (empty disables StatsD)"
+	DescriptionStatsDFlushInterval = "Interval in seconds to flush aggregated StatsD metrics"
+	DescriptionGraphiteAddress     = "TCP address of the Graphite plaintext listener (empty disables Graphite)"
+	DescriptionGraphiteMaxConns    = "Maximum number of concurrent Graphite connections"
+	DescriptionGraphiteMaxLine     = "Maximum size in bytes of a single Graphite line"
+	DescriptionStreamBufferSize    = "Number of pending updates buffered per stream client before the oldest are dropped"
+	DescriptionStreamKeepAlive     = "Interval in seconds between keepalive comments on metric streams"
+	DescriptionDerivedMetrics      = "Path to the JSON file with derived metric definitions"
+	DescriptionDerivedInterval     = "Interval in seconds to evaluate derived metrics"
 )
 
 func NewCommand() *cobra.Command {
@@ -52,19 +117,36 @@ func NewCommand() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			log.Init(log.LevelInfo)
 			config := &Config{
-				Address:         viper.GetString(EnvAddress),
-				DatabaseDSN:     viper.GetString(EnvDatabaseDSN),
-				StoreInterval:   viper.GetInt(EnvStoreInterval),
-				FileStoragePath: viper.GetString(EnvFileStoragePath),
-				Restore:         viper.GetBool(EnvRestore),
-				Key:             viper.GetString(EnvKey),
+				Address:             viper.GetString(EnvAddress),
+				DatabaseDSN:         viper.GetString(EnvDatabaseDSN),
+				StoreInterval:       viper.GetInt(EnvStoreInterval),
+				FileStoragePath:     viper.GetString(EnvFileStoragePath),
+				Restore:             viper.GetBool(EnvRestore),
+				Key:                 viper.GetString(EnvKey),
+				HistoryRetention:    viper.GetInt(EnvHistoryRetention),
+				AlertRules:          viper.GetString(EnvAlertRules),
+				AlertWebhook:        viper.GetString(EnvAlertWebhook),
+				AlertInterval:       viper.GetInt(EnvAlertInterval),
+				GRPCAddress:         viper.GetString(EnvGRPCAddress),
+				StatsDAddress:       viper.GetString(EnvStatsDAddress),
+				StatsDFlushInterval: viper.GetInt(EnvStatsDFlushInterval),
+				GraphiteAddress:     viper.GetString(EnvGraphiteAddress),
+				GraphiteMaxConns:    viper.GetInt(EnvGraphiteMaxConns),
+				GraphiteMaxLine:     viper.GetInt(EnvGraphiteMaxLine),
+				StreamBufferSize:    viper.GetInt(EnvStreamBufferSize),
+				StreamKeepAlive:     viper.GetInt(EnvStreamKeepAlive),
+				DerivedMetrics:      viper.GetString(EnvDerivedMetrics),
+				DerivedInterval:     viper.GetInt(EnvDerivedInterval),
 			}
 			container, err := NewContainer(config)
 			if err != nil {
 				return err
 			}
 			worker := NewWorker(config, container)
-			server := NewServer(config, container, worker)
+			alerter := NewAlerter(config, container)
+			deriver := NewDeriver(config, container)
+			pruner := NewHistoryPruner(config, container)
+			server := NewServer(config, container, worker, alerter, deriver, pruner)
 			ctx, cancel := c.NewContext()
 			defer cancel()
 			return server.Start(ctx)
@@ -77,6 +159,20 @@ func NewCommand() *cobra.Command {
 	cmd.PersistentFlags().BoolP(FlagRestore, ShortFlagRestore, DefaultRestore, DescriptionRestore)
 	cmd.PersistentFlags().StringP(FlagDatabaseDSN, ShortFlagDatabaseDSN, "", DescriptionDatabaseDSN)
 	cmd.PersistentFlags().StringP(FlagKey, ShortFlagKey, "", DescriptionKey)
+	cmd.PersistentFlags().IntP(FlagHistoryRetention, ShortFlagHistoryRetention, DefaultHistoryRetention, DescriptionHistoryRetention)
+	cmd.PersistentFlags().StringP(FlagAlertRules, ShortFlagAlertRules, "", DescriptionAlertRules)
+	cmd.PersistentFlags().StringP(FlagAlertWebhook, ShortFlagAlertWebhook, "", DescriptionAlertWebhook)
+	cmd.PersistentFlags().IntP(FlagAlertInterval, ShortFlagAlertInterval, DefaultAlertInterval, DescriptionAlertInterval)
+	cmd.PersistentFlags().StringP(FlagGRPCAddress, ShortFlagGRPCAddress, DefaultGRPCAddress, DescriptionGRPCAddress)
+	cmd.PersistentFlags().StringP(FlagStatsDAddress, ShortFlagStatsDAddress, "", DescriptionStatsDAddress)
+	cmd.PersistentFlags().IntP(FlagStatsDFlushInterval, ShortFlagStatsDFlushInterval, DefaultStatsDFlushInterval, DescriptionStatsDFlushInterval)
+	cmd.PersistentFlags().StringP(FlagGraphiteAddress, ShortFlagGraphiteAddress, "", DescriptionGraphiteAddress)
+	cmd.PersistentFlags().IntP(FlagGraphiteMaxConns, ShortFlagGraphiteMaxConns, DefaultGraphiteMaxConns, DescriptionGraphiteMaxConns)
+	cmd.PersistentFlags().IntP(FlagGraphiteMaxLine, ShortFlagGraphiteMaxLine, DefaultGraphiteMaxLine, DescriptionGraphiteMaxLine)
+	cmd.PersistentFlags().IntP(FlagStreamBufferSize, ShortFlagStreamBufferSize, DefaultStreamBufferSize, DescriptionStreamBufferSize)
+	cmd.PersistentFlags().IntP(FlagStreamKeepAlive, ShortFlagStreamKeepAlive, DefaultStreamKeepAlive, DescriptionStreamKeepAlive)
+	cmd.PersistentFlags().StringP(FlagDerivedMetrics, ShortFlagDerivedMetrics, "", DescriptionDerivedMetrics)
+	cmd.PersistentFlags().IntP(FlagDerivedInterval, ShortFlagDerivedInterval, DefaultDerivedInterval, DescriptionDerivedInterval)
 
 	viper.BindPFlag(EnvAddress, cmd.PersistentFlags().Lookup(FlagAddress))
 	viper.BindPFlag(EnvStoreInterval, cmd.PersistentFlags().Lookup(FlagStoreInterval))
@@ -84,6 +180,22 @@ func NewCommand() *cobra.Command {
 	viper.BindPFlag(EnvRestore, cmd.PersistentFlags().Lookup(FlagRestore))
 	viper.BindPFlag(EnvDatabaseDSN, cmd.PersistentFlags().Lookup(FlagDatabaseDSN))
 	viper.BindPFlag(EnvKey, cmd.PersistentFlags().Lookup(FlagKey))
+	viper.BindPFlag(EnvHistoryRetention, cmd.PersistentFlags().Lookup(FlagHistoryRetention))
+	viper.BindPFlag(EnvAlertRules, cmd.PersistentFlags().Lookup(FlagAlertRules))
+	viper.BindPFlag(EnvAlertWebhook, cmd.PersistentFlags().Lookup(FlagAlertWebhook))
+	viper.BindPFlag(EnvAlertInterval, cmd.PersistentFlags().Lookup(FlagAlertInterval))
+	viper.BindPFlag(EnvGRPCAddress, cmd.PersistentFlags().Lookup(FlagGRPCAddress))
+	viper.BindPFlag(EnvStatsDAddress, cmd.PersistentFlags().Lookup(FlagStatsDAddress))
+	viper.BindPFlag(EnvStatsDFlushInterval, cmd.PersistentFlags().Lookup(FlagStatsDFlushInterval))
+	viper.BindPFlag(EnvGraphiteAddress, cmd.PersistentFlags().Lookup(FlagGraphiteAddress))
+	viper.BindPFlag(EnvGraphiteMaxConns, cmd.PersistentFlags().Lookup(FlagGraphiteMaxConns))
+	viper.BindPFlag(EnvGraphiteMaxLine, cmd.PersistentFlags().Lookup(FlagGraphiteMaxLine))
+	viper.BindPFlag(EnvStreamBufferSize, cmd.PersistentFlags().Lookup(FlagStreamBufferSize))
+	viper.BindPFlag(EnvStreamKeepAlive, cmd.PersistentFlags().Lookup(FlagStreamKeepAlive))
+	viper.BindPFlag(EnvDerivedMetrics, cmd.PersistentFlags().Lookup(FlagDerivedMetrics))
+	viper.BindPFlag(EnvDerivedInterval, cmd.PersistentFlags().Lookup(FlagDerivedInterval))
+
+	cmd.AddCommand(NewMigrateCommand())
 
 	return cmd
 }
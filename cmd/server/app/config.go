@@ -1,12 +1,32 @@
 package app
 
+import (
+	"path/filepath"
+	"strings"
+	"time"
+)
+
 type Config struct {
-	Address         string
-	DatabaseDSN     string
-	StoreInterval   int
-	FileStoragePath string
-	Restore         bool
-	Key             string
+	Address             string
+	DatabaseDSN         string
+	StoreInterval       int
+	FileStoragePath     string
+	Restore             bool
+	Key                 string
+	HistoryRetention    int
+	AlertRules          string
+	AlertWebhook        string
+	AlertInterval       int
+	GRPCAddress         string
+	StatsDAddress       string
+	StatsDFlushInterval int
+	GraphiteAddress     string
+	GraphiteMaxConns    int
+	GraphiteMaxLine     int
+	StreamBufferSize    int
+	StreamKeepAlive     int
+	DerivedMetrics      string
+	DerivedInterval     int
 }
 
 func (c *Config) GetAddress() string {
@@ -17,6 +37,14 @@ func (c *Config) GetFileStoragePath() string {
 	return c.FileStoragePath
 }
 
+func (c *Config) GetStoreInterval() time.Duration {
+	return time.Duration(c.StoreInterval) * time.Second
+}
+
+func (c *Config) IsSyncStore() bool {
+	return c.StoreInterval <= 0
+}
+
 func (c *Config) GetDatabaseDSN() string {
 	return c.DatabaseDSN
 }
@@ -24,3 +52,64 @@ func (c *Config) GetDatabaseDSN() string {
 func (c *Config) GetKey() string {
 	return c.Key
 }
+
+func (c *Config) GetHistoryRetention() time.Duration {
+	return time.Duration(c.HistoryRetention) * time.Second
+}
+
+func (c *Config) GetHistoryFilePath() string {
+	ext := filepath.Ext(c.FileStoragePath)
+	return strings.TrimSuffix(c.FileStoragePath, ext) + "_history" + ext
+}
+
+func (c *Config) GetAlertRules() string {
+	return c.AlertRules
+}
+
+func (c *Config) GetAlertWebhook() string {
+	return c.AlertWebhook
+}
+
+func (c *Config) GetAlertInterval() time.Duration {
+	return time.Duration(c.AlertInterval) * time.Second
+}
+
+func (c *Config) GetGRPCAddress() string {
+	return c.GRPCAddress
+}
+
+func (c *Config) GetStatsDAddress() string {
+	return c.StatsDAddress
+}
+
+func (c *Config) GetStatsDFlushInterval() time.Duration {
+	return time.Duration(c.StatsDFlushInterval) * time.Second
+}
+
+func (c *Config) GetGraphiteAddress() string {
+	return c.GraphiteAddress
+}
+
+func (c *Config) GetGraphiteMaxConns() int {
+	return c.GraphiteMaxConns
+}
+
+func (c *Config) GetGraphiteMaxLine() int {
+	return c.GraphiteMaxLine
+}
+
+func (c *Config) GetStreamBufferSize() int {
+	return c.StreamBufferSize
+}
+
+func (c *Config) GetStreamKeepAlive() time.Duration {
+	return time.Duration(c.StreamKeepAlive) * time.Second
+}
+
+func (c *Config) GetDerivedMetrics() string {
+	return c.DerivedMetrics
+}
+
+func (c *Config) GetDerivedInterval() time.Duration {
+	return time.Duration(c.DerivedInterval) * time.Second
+}
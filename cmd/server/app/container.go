@@ -1,9 +1,11 @@
 package app
 
 import (
-	"bufio"
 	"database/sql"
 	"go-metrics/internal/domain"
+	"go-metrics/internal/grpcservers"
+	"go-metrics/internal/listeners"
+	"go-metrics/internal/notifiers"
 	"go-metrics/internal/repositories"
 	"go-metrics/internal/services"
 	"go-metrics/internal/unitofworks"
@@ -15,28 +17,64 @@ import (
 	_ "github.com/jackc/pgx/v5/stdlib"
 )
 
+const fileStorageMaxWALBytes = 4 << 20
+
 type Container struct {
-	DB                       *sql.DB
-	File                     *os.File
-	Memory                   map[domain.MetricID]*domain.Metric
-	MetricSaveDBRepo         *repositories.MetricDBSaveRepository
-	MetricFindDBRepo         *repositories.MetricDBFindRepository
-	MetricSaveFileRepo       *repositories.MetricFileSaveRepository
-	MetricFindFileRepo       *repositories.MetricFileFindRepository
-	MetricSaveMemoryRepo     *repositories.MetricMemorySaveRepository
-	MetricFindMemoryRepo     *repositories.MetricMemoryFindRepository
-	DBUOW                    *unitofworks.DBUnitOfWork
-	FileUOW                  *unitofworks.FileUnitOfWork
-	MemoryUOW                *unitofworks.MemoryUnitOfWork
-	MetricUpdateService      *services.MetricUpdateService
-	MetricGetByIDService     *services.MetricGetByIDService
-	MetricListService        *services.MetricListService
-	MetricUpdatePathUsecase  *usecases.MetricUpdatePathUsecase
-	MetricGetByIDPathUsecase *usecases.MetricGetByIDPathUsecase
-	MetricListHTMLUsecase    *usecases.MetricListHTMLUsecase
-	MetricUpdateBodyUsecase  *usecases.MetricUpdateBodyUsecase
-	MetricGetByIDBodyUsecase *usecases.MetricGetByIDBodyUsecase
-	MetricUpdatesBodyUsecase *usecases.MetricUpdatesBodyUsecase
+	DB                          *sql.DB
+	FileStorage                 *repositories.MetricFileStorage
+	Memory                      map[domain.MetricID]*domain.Metric
+	MemoryStorage               *repositories.MetricMemoryStorage
+	MetricSaveDBRepo            *repositories.MetricDBSaveRepository
+	MetricFindDBRepo            *repositories.MetricDBFindRepository
+	MetricDeleteDBRepo          *repositories.MetricDBDeleteRepository
+	MetricSaveFileRepo          *repositories.MetricFileSaveRepository
+	MetricFindFileRepo          *repositories.MetricFileFindRepository
+	MetricDeleteFileRepo        *repositories.MetricFileDeleteRepository
+	MetricSaveMemoryRepo        *repositories.MetricMemorySaveRepository
+	MetricFindMemoryRepo        *repositories.MetricMemoryFindRepository
+	MetricDeleteMemoryRepo      *repositories.MetricMemoryDeleteRepository
+	MetricSaveMemoryFileRepo    *repositories.MetricMemoryFileSaveRepository
+	MetricDeleteMemoryFileRepo  *repositories.MetricMemoryFileDeleteRepository
+	MetricHistoryDBRepo         *repositories.MetricHistoryDBRepository
+	MetricHistoryFileRepo       *repositories.MetricHistoryFileRepository
+	MetricHistoryMemoryRepo     *repositories.MetricHistoryMemoryRepository
+	DBUOW                       *unitofworks.DBUnitOfWork
+	MemoryUOW                   *unitofworks.MemoryUnitOfWork
+	MetricStreamService         *services.MetricStreamService
+	MetricUpdateService         *services.MetricUpdateService
+	MetricGetByIDService        *services.MetricGetByIDService
+	MetricListService           *services.MetricListService
+	MetricHistoryService        *services.MetricHistoryService
+	MetricQueryService          *services.MetricQueryService
+	MetricDeleteService         *services.MetricDeleteService
+	MetricResetService          *services.MetricResetService
+	MetricUpdatePathUsecase     *usecases.MetricUpdatePathUsecase
+	MetricGetByIDPathUsecase    *usecases.MetricGetByIDPathUsecase
+	MetricListHTMLUsecase       *usecases.MetricListHTMLUsecase
+	MetricUpdateBodyUsecase     *usecases.MetricUpdateBodyUsecase
+	MetricGetByIDBodyUsecase    *usecases.MetricGetByIDBodyUsecase
+	MetricUpdatesBodyUsecase    *usecases.MetricUpdatesBodyUsecase
+	MetricListPrometheusUsecase *usecases.MetricListPrometheusUsecase
+	MetricHistoryPathUsecase    *usecases.MetricHistoryPathUsecase
+	MetricQueryUsecase          *usecases.MetricQueryUsecase
+	MetricDeletePathUsecase     *usecases.MetricDeletePathUsecase
+	MetricDeletesBodyUsecase    *usecases.MetricDeletesBodyUsecase
+	MetricResetPathUsecase      *usecases.MetricResetPathUsecase
+	MetricWriteInfluxUsecase    *usecases.MetricWriteInfluxUsecase
+	MetricWriteOTLPUsecase      *usecases.MetricWriteOTLPUsecase
+	MetricWriteRemoteUsecase    *usecases.MetricWriteRemoteUsecase
+	MetricStreamUsecase         *usecases.MetricStreamUsecase
+	AlertRuleFileRepo           *repositories.AlertRuleFileRepository
+	AlertMemoryRepo             *repositories.AlertMemoryRepository
+	AlertWebhookNotifier        *notifiers.AlertWebhookNotifier
+	AlertEvaluateService        *services.AlertEvaluateService
+	AlertListService            *services.AlertListService
+	AlertListUsecase            *usecases.AlertListUsecase
+	DerivedMetricRuleFileRepo   *repositories.DerivedMetricRuleFileRepository
+	DerivedMetricService        *services.DerivedMetricEvaluateService
+	MetricGRPCServer            *grpcservers.MetricServer
+	StatsDListener              *listeners.StatsDListener
+	GraphiteListener            *listeners.GraphiteListener
 }
 
 func NewContainer(config *Config) (*Container, error) {
@@ -59,6 +97,8 @@ func NewContainer(config *Config) (*Container, error) {
 		container.DB = db
 		container.MetricSaveDBRepo = repositories.NewMetricDBSaveRepository(db)
 		container.MetricFindDBRepo = repositories.NewMetricDBFindRepository(db)
+		container.MetricDeleteDBRepo = repositories.NewMetricDBDeleteRepository(db)
+		container.MetricHistoryDBRepo = repositories.NewMetricHistoryDBRepository(db, config.GetHistoryRetention())
 		container.DBUOW = unitofworks.NewDBUnitOfWork(db)
 	}
 	if filePath := config.GetFileStoragePath(); filePath != "" {
@@ -67,28 +107,45 @@ func NewContainer(config *Config) (*Container, error) {
 			log.Error("Failed to create directories", "error", err)
 			return nil, err
 		}
-		file, err := os.OpenFile(config.GetFileStoragePath(), os.O_APPEND|os.O_CREATE|os.O_RDWR, 0666)
+		storage, err := repositories.NewMetricFileStorage(
+			config.GetFileStoragePath(), fileStorageMaxWALBytes, config.IsSyncStore(), config.Restore,
+		)
 		if err != nil {
-			log.Error("Failed to open file", "error", err)
+			log.Error("Failed to open file storage", "error", err)
 			return nil, err
 		}
-		scanner := bufio.NewScanner(file)
-		container.File = file
-		container.MetricSaveFileRepo = repositories.NewMetricFileSaveRepository(file)
-		container.MetricFindFileRepo = repositories.NewMetricFileFindRepository(file, scanner)
-		container.FileUOW = unitofworks.NewFileUnitOfWork()
+		container.FileStorage = storage
+		container.MetricSaveFileRepo = repositories.NewMetricFileSaveRepository(storage)
+		container.MetricFindFileRepo = repositories.NewMetricFileFindRepository(storage)
+		container.MetricDeleteFileRepo = repositories.NewMetricFileDeleteRepository(storage)
+		container.MetricHistoryFileRepo = repositories.NewMetricHistoryFileRepository(config.GetHistoryFilePath(), config.GetHistoryRetention())
 	}
-	if container.DB == nil && container.File == nil {
-		container.Memory = make(map[domain.MetricID]*domain.Metric)
-		container.MetricSaveMemoryRepo = repositories.NewMetricMemorySaveRepository(container.Memory)
-		container.MetricFindMemoryRepo = repositories.NewMetricMemoryFindRepository(container.Memory)
+	if container.DB == nil {
+		container.MemoryStorage = repositories.NewMetricMemoryStorage(container.Memory)
+		container.MetricSaveMemoryRepo = repositories.NewMetricMemorySaveRepository(container.MemoryStorage)
+		container.MetricFindMemoryRepo = repositories.NewMetricMemoryFindRepository(container.MemoryStorage)
+		container.MetricDeleteMemoryRepo = repositories.NewMetricMemoryDeleteRepository(container.MemoryStorage)
+		container.MetricHistoryMemoryRepo = repositories.NewMetricHistoryMemoryRepository(config.GetHistoryRetention())
 		container.MemoryUOW = unitofworks.NewMemoryUnitOfWork()
+		if container.FileStorage != nil && config.IsSyncStore() {
+			container.MetricSaveMemoryFileRepo = repositories.NewMetricMemoryFileSaveRepository(
+				container.MetricSaveMemoryRepo,
+				container.MetricSaveFileRepo,
+			)
+			container.MetricDeleteMemoryFileRepo = repositories.NewMetricMemoryFileDeleteRepository(
+				container.MetricDeleteMemoryRepo,
+				container.MetricDeleteFileRepo,
+			)
+		}
 	}
+	container.MetricStreamService = services.NewMetricStreamService(config.GetStreamBufferSize())
 	if container.MetricSaveDBRepo != nil {
 		container.MetricUpdateService = services.NewMetricUpdateService(
 			container.MetricSaveDBRepo,
 			container.MetricFindDBRepo,
+			container.MetricHistoryDBRepo,
 			container.DBUOW,
+			container.MetricStreamService,
 		)
 		container.MetricGetByIDService = services.NewMetricGetByIDService(
 			container.MetricFindDBRepo,
@@ -96,23 +153,49 @@ func NewContainer(config *Config) (*Container, error) {
 		container.MetricListService = services.NewMetricListService(
 			container.MetricFindDBRepo,
 		)
-	} else if container.MetricSaveFileRepo != nil {
-		container.MetricUpdateService = services.NewMetricUpdateService(
-			container.MetricSaveFileRepo,
-			container.MetricFindFileRepo,
-			container.FileUOW,
+		container.MetricHistoryService = services.NewMetricHistoryService(
+			container.MetricHistoryDBRepo,
 		)
-		container.MetricGetByIDService = services.NewMetricGetByIDService(
-			container.MetricFindFileRepo,
+		container.MetricQueryService = services.NewMetricQueryService(
+			container.MetricFindDBRepo,
 		)
-		container.MetricListService = services.NewMetricListService(
-			container.MetricFindFileRepo,
+		container.MetricDeleteService = services.NewMetricDeleteService(
+			container.MetricFindDBRepo,
+			container.MetricDeleteDBRepo,
+			container.MetricHistoryDBRepo,
+			container.DBUOW,
+			container.MetricStreamService,
+		)
+		container.MetricResetService = services.NewMetricResetService(
+			container.MetricSaveDBRepo,
+			container.MetricFindDBRepo,
+			container.MetricHistoryDBRepo,
+			container.DBUOW,
+			container.MetricStreamService,
 		)
 	} else {
+		var saveRepo services.MetricUpdateSaveRepository = container.MetricSaveMemoryRepo
+		if container.MetricSaveMemoryFileRepo != nil {
+			saveRepo = container.MetricSaveMemoryFileRepo
+		}
+		var deleteRepo services.MetricDeleteRepository = container.MetricDeleteMemoryRepo
+		if container.MetricDeleteMemoryFileRepo != nil {
+			deleteRepo = container.MetricDeleteMemoryFileRepo
+		}
+		var historyRepo interface {
+			services.MetricUpdateHistoryRepository
+			services.MetricHistoryFindRepository
+			services.MetricDeleteHistoryRepository
+		} = container.MetricHistoryMemoryRepo
+		if container.MetricHistoryFileRepo != nil {
+			historyRepo = container.MetricHistoryFileRepo
+		}
 		container.MetricUpdateService = services.NewMetricUpdateService(
-			container.MetricSaveMemoryRepo,
+			saveRepo,
 			container.MetricFindMemoryRepo,
+			historyRepo,
 			container.MemoryUOW,
+			container.MetricStreamService,
 		)
 		container.MetricGetByIDService = services.NewMetricGetByIDService(
 			container.MetricFindMemoryRepo,
@@ -120,12 +203,80 @@ func NewContainer(config *Config) (*Container, error) {
 		container.MetricListService = services.NewMetricListService(
 			container.MetricFindMemoryRepo,
 		)
+		container.MetricHistoryService = services.NewMetricHistoryService(
+			historyRepo,
+		)
+		container.MetricQueryService = services.NewMetricQueryService(
+			container.MetricFindMemoryRepo,
+		)
+		container.MetricDeleteService = services.NewMetricDeleteService(
+			container.MetricFindMemoryRepo,
+			deleteRepo,
+			historyRepo,
+			container.MemoryUOW,
+			container.MetricStreamService,
+		)
+		container.MetricResetService = services.NewMetricResetService(
+			saveRepo,
+			container.MetricFindMemoryRepo,
+			historyRepo,
+			container.MemoryUOW,
+			container.MetricStreamService,
+		)
 	}
 	container.MetricUpdatePathUsecase = usecases.NewMetricUpdatePathUsecase(container.MetricUpdateService)
 	container.MetricUpdateBodyUsecase = usecases.NewMetricUpdateBodyUsecase(container.MetricUpdateService)
 	container.MetricGetByIDPathUsecase = usecases.NewMetricGetByIDPathUsecase(container.MetricGetByIDService)
 	container.MetricGetByIDBodyUsecase = usecases.NewMetricGetByIDBodyUsecase(container.MetricGetByIDService)
-	container.MetricListHTMLUsecase = usecases.NewMetricListHTMLUsecase(container.MetricListService)
+	container.MetricListHTMLUsecase = usecases.NewMetricListHTMLUsecase(container.MetricListService, container.MetricHistoryService)
 	container.MetricUpdatesBodyUsecase = usecases.NewMetricUpdatesBodyUsecase(container.MetricUpdateService)
+	container.MetricListPrometheusUsecase = usecases.NewMetricListPrometheusUsecase(container.MetricListService)
+	container.MetricHistoryPathUsecase = usecases.NewMetricHistoryPathUsecase(container.MetricHistoryService)
+	container.MetricQueryUsecase = usecases.NewMetricQueryUsecase(container.MetricQueryService)
+	container.MetricDeletePathUsecase = usecases.NewMetricDeletePathUsecase(container.MetricDeleteService)
+	container.MetricDeletesBodyUsecase = usecases.NewMetricDeletesBodyUsecase(container.MetricDeleteService)
+	container.MetricResetPathUsecase = usecases.NewMetricResetPathUsecase(container.MetricResetService)
+	container.MetricWriteInfluxUsecase = usecases.NewMetricWriteInfluxUsecase(container.MetricUpdateService)
+	container.MetricWriteOTLPUsecase = usecases.NewMetricWriteOTLPUsecase(container.MetricUpdateService)
+	container.MetricWriteRemoteUsecase = usecases.NewMetricWriteRemoteUsecase(container.MetricUpdateService)
+	container.MetricStreamUsecase = usecases.NewMetricStreamUsecase(container.MetricStreamService)
+	container.AlertRuleFileRepo = repositories.NewAlertRuleFileRepository(config.GetAlertRules())
+	container.AlertMemoryRepo = repositories.NewAlertMemoryRepository()
+	container.AlertWebhookNotifier = notifiers.NewAlertWebhookNotifier(config.GetAlertWebhook())
+	container.AlertEvaluateService = services.NewAlertEvaluateService(
+		container.AlertRuleFileRepo,
+		container.MetricListService,
+		container.AlertMemoryRepo,
+		container.AlertMemoryRepo,
+		container.AlertWebhookNotifier,
+	)
+	container.AlertListService = services.NewAlertListService(container.AlertMemoryRepo)
+	container.AlertListUsecase = usecases.NewAlertListUsecase(container.AlertListService)
+	container.DerivedMetricRuleFileRepo = repositories.NewDerivedMetricRuleFileRepository(config.GetDerivedMetrics())
+	container.DerivedMetricService = services.NewDerivedMetricEvaluateService(
+		container.DerivedMetricRuleFileRepo,
+		container.MetricListService,
+		container.MetricHistoryService,
+		container.MetricUpdateService,
+	)
+	container.MetricGRPCServer = grpcservers.NewMetricServer(
+		container.MetricUpdateService,
+		container.MetricGetByIDService,
+		container.MetricListService,
+	)
+	if config.GetStatsDAddress() != "" && config.GetStatsDFlushInterval() > 0 {
+		container.StatsDListener = listeners.NewStatsDListener(
+			container.MetricUpdateService,
+			container.MetricGetByIDService,
+			config.GetStatsDFlushInterval(),
+		)
+	}
+	if config.GetGraphiteAddress() != "" && config.GetGraphiteMaxConns() > 0 && config.GetGraphiteMaxLine() > 0 {
+		container.GraphiteListener = listeners.NewGraphiteListener(
+			container.MetricUpdateService,
+			config.GetGraphiteMaxConns(),
+			config.GetGraphiteMaxLine(),
+		)
+	}
 	return container, nil
 }
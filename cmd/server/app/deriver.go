@@ -0,0 +1,52 @@
+package app
+
+import (
+	"context"
+	"go-metrics/pkg/log"
+	"time"
+)
+
+type Deriver struct {
+	config    *Config
+	container *Container
+}
+
+func NewDeriver(config *Config, container *Container) *Deriver {
+	return &Deriver{
+		config:    config,
+		container: container,
+	}
+}
+
+func (d *Deriver) Load(ctx context.Context) error {
+	if d.config.GetDerivedMetrics() == "" {
+		return nil
+	}
+	count, err := d.container.DerivedMetricService.Load(ctx)
+	if err != nil {
+		log.Error("Failed to load derived metrics", "error", err)
+		return err
+	}
+	log.Info("Derived metrics loaded", "count", count)
+	return nil
+}
+
+func (d *Deriver) Start(ctx context.Context) {
+	if d.config.GetDerivedMetrics() == "" || d.config.GetDerivedInterval() <= 0 {
+		log.Info("Derived metrics are not configured, deriver is disabled")
+		return
+	}
+	ticker := time.NewTicker(d.config.GetDerivedInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			if err := d.container.DerivedMetricService.Evaluate(ctx, now); err != nil {
+				log.Error("Failed to evaluate derived metrics", "error", err)
+			}
+		case <-ctx.Done():
+			log.Info("Deriver is stopping")
+			return
+		}
+	}
+}
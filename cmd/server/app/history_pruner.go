@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"go-metrics/pkg/log"
+	"time"
+)
+
+const MaxHistoryPruneInterval = time.Minute
+
+type HistoryPruneRepository interface {
+	Prune(ctx context.Context, now time.Time) (int, error)
+}
+
+type HistoryPruner struct {
+	config    *Config
+	container *Container
+}
+
+func NewHistoryPruner(config *Config, container *Container) *HistoryPruner {
+	return &HistoryPruner{
+		config:    config,
+		container: container,
+	}
+}
+
+func (p *HistoryPruner) Start(ctx context.Context) {
+	retention := p.config.GetHistoryRetention()
+	repo := p.repository()
+	if repo == nil || retention <= 0 {
+		return
+	}
+	ticker := time.NewTicker(min(retention, MaxHistoryPruneInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			removed, err := repo.Prune(ctx, now)
+			if err != nil {
+				log.Error("Failed to prune metric history", "error", err)
+				continue
+			}
+			if removed > 0 {
+				log.Info("Stale metric history pruned", "count", removed)
+			}
+		case <-ctx.Done():
+			log.Info("History pruner is stopping")
+			return
+		}
+	}
+}
+
+func (p *HistoryPruner) repository() HistoryPruneRepository {
+	switch {
+	case p.container.MetricHistoryDBRepo != nil:
+		return p.container.MetricHistoryDBRepo
+	case p.container.MetricHistoryFileRepo != nil:
+		return p.container.MetricHistoryFileRepo
+	case p.container.MetricHistoryMemoryRepo != nil:
+		return p.container.MetricHistoryMemoryRepo
+	}
+	return nil
+}
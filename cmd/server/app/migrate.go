@@ -0,0 +1,87 @@
+package app
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-metrics/internal/migrations"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	c "go-metrics/pkg/context"
+	"go-metrics/pkg/log"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+const (
+	MigrateUp     = "up"
+	MigrateDown   = "down"
+	MigrateStatus = "status"
+)
+
+var ErrMissingDatabaseDSN = errors.New("database DSN is required for migrations")
+
+func NewMigrateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:       "migrate [up | down [steps] | status]",
+		Short:     "Apply or revert database schema migrations",
+		Args:      cobra.RangeArgs(0, 2),
+		ValidArgs: []string{MigrateUp, MigrateDown, MigrateStatus},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			log.Init(log.LevelInfo)
+			dsn := viper.GetString(EnvDatabaseDSN)
+			if dsn == "" {
+				return ErrMissingDatabaseDSN
+			}
+			direction := MigrateUp
+			if len(args) > 0 {
+				direction = args[0]
+			}
+			steps := 1
+			if len(args) > 1 {
+				n, err := strconv.Atoi(args[1])
+				if err != nil || n <= 0 {
+					return fmt.Errorf("invalid number of steps: %s", args[1])
+				}
+				steps = n
+			}
+			db, err := sql.Open("pgx", dsn)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+			migrator, err := migrations.NewMigrator(db)
+			if err != nil {
+				return err
+			}
+			ctx, cancel := c.NewContext()
+			defer cancel()
+			switch direction {
+			case MigrateUp:
+				applied, err := migrator.Up(ctx)
+				for _, migration := range applied {
+					log.Info("Migration applied", "version", migration.Version, "name", migration.Name)
+				}
+				return err
+			case MigrateDown:
+				reverted, err := migrator.Down(ctx, steps)
+				for _, migration := range reverted {
+					log.Info("Migration reverted", "version", migration.Version, "name", migration.Name)
+				}
+				return err
+			case MigrateStatus:
+				version, err := migrator.Version(ctx)
+				if err != nil {
+					return err
+				}
+				log.Info("Schema version", "current", version, "latest", migrator.Latest())
+				return nil
+			default:
+				return fmt.Errorf("unknown migrate command: %s", direction)
+			}
+		},
+	}
+}
@@ -4,20 +4,34 @@ import (
 	"context"
 	"database/sql"
 	"go-metrics/internal/handlers"
+	"go-metrics/internal/middlewares"
+	"go-metrics/internal/migrations"
+	"go-metrics/internal/proto"
 	"go-metrics/internal/routers"
+	"go-metrics/internal/web"
 	"go-metrics/pkg/log"
+	"net"
 	"net/http"
 	"time"
+
+	"google.golang.org/grpc"
+	_ "google.golang.org/grpc/encoding/gzip"
 )
 
 type Server struct {
 	config    *Config
 	container *Container
 	server    *http.Server
+	grpc      *grpc.Server
 	worker    *Worker
+	alerter   *Alerter
+	deriver   *Deriver
+	pruner    *HistoryPruner
 }
 
-func NewServer(config *Config, container *Container, worker *Worker) *Server {
+func NewServer(
+	config *Config, container *Container, worker *Worker, alerter *Alerter, deriver *Deriver, pruner *HistoryPruner,
+) *Server {
 	log.Init(log.LevelInfo)
 	defer log.Sync()
 
@@ -29,6 +43,18 @@ func NewServer(config *Config, container *Container, worker *Worker) *Server {
 	metricUpdateBodyHandler := handlers.MetricUpdateBodyHandler(container.MetricUpdateBodyUsecase)
 	metricGetByIDBodyHandler := handlers.MetricGetByIDBodyHandler(container.MetricGetByIDBodyUsecase)
 	metricUpdatesHandler := handlers.MetricUpdatesBodyHandler(container.MetricUpdatesBodyUsecase)
+	metricListPrometheusHandler := handlers.MetricListPrometheusHandler(container.MetricListPrometheusUsecase)
+	metricHistoryHandler := handlers.MetricHistoryPathHandler(container.MetricHistoryPathUsecase)
+	alertListHandler := handlers.AlertListHandler(container.AlertListUsecase)
+	metricQueryHandler := handlers.MetricQueryHandler(container.MetricQueryUsecase)
+	metricDeleteHandler := handlers.MetricDeletePathHandler(container.MetricDeletePathUsecase)
+	metricDeletesHandler := handlers.MetricDeletesBodyHandler(container.MetricDeletesBodyUsecase)
+	metricResetHandler := handlers.MetricResetPathHandler(container.MetricResetPathUsecase)
+	metricWriteInfluxHandler := handlers.MetricWriteInfluxHandler(container.MetricWriteInfluxUsecase)
+	metricWriteOTLPHandler := handlers.MetricWriteOTLPHandler(container.MetricWriteOTLPUsecase)
+	metricWriteRemoteHandler := handlers.MetricWriteRemoteHandler(container.MetricWriteRemoteUsecase)
+	metricStreamHandler := handlers.MetricStreamHandler(container.MetricStreamUsecase, config.GetStreamKeepAlive())
+	staticHandler := handlers.StaticHandler(web.Static())
 
 	metricRouter := routers.NewMetricRouter(
 		config,
@@ -38,6 +64,18 @@ func NewServer(config *Config, container *Container, worker *Worker) *Server {
 		metricGetByIDHandler,
 		metricGetByIDBodyHandler,
 		metricListHTMLHandler,
+		metricListPrometheusHandler,
+		metricHistoryHandler,
+		alertListHandler,
+		metricQueryHandler,
+		metricDeleteHandler,
+		metricDeletesHandler,
+		metricResetHandler,
+		metricWriteInfluxHandler,
+		metricWriteOTLPHandler,
+		metricWriteRemoteHandler,
+		metricStreamHandler,
+		staticHandler,
 	)
 	metricRouter.Get("/ping", PingDBHandler(container.DB))
 
@@ -45,27 +83,34 @@ func NewServer(config *Config, container *Container, worker *Worker) *Server {
 		Addr:    config.GetAddress(),
 		Handler: metricRouter,
 	}
+	server.RegisterOnShutdown(container.MetricStreamService.Close)
+
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(middlewares.HMACUnaryInterceptor(config)))
+	proto.RegisterMetricsServer(grpcServer, container.MetricGRPCServer)
 
-	log.Info("Server initialized", "address", config.GetAddress())
+	log.Info("Server initialized", "address", config.GetAddress(), "grpc_address", config.GetGRPCAddress())
 
 	return &Server{
 		config:    config,
 		container: container,
 		server:    server,
+		grpc:      grpcServer,
 		worker:    worker,
+		alerter:   alerter,
+		deriver:   deriver,
+		pruner:    pruner,
 	}
 }
 
 func (s *Server) Start(ctx context.Context) error {
 	log.Info("Starting server")
-	defer func() {
-		if err := s.container.File.Sync(); err != nil {
-			log.Error("Failed to sync file", "error", err)
-		}
-		if err := s.container.File.Close(); err != nil {
-			log.Error("Failed to close file", "error", err)
-		}
-	}()
+	if s.container.FileStorage != nil {
+		defer func() {
+			if err := s.container.FileStorage.Close(); err != nil {
+				log.Error("Failed to close file storage", "error", err)
+			}
+		}()
+	}
 
 	if s.config.GetDatabaseDSN() != "" {
 		log.Info("Opening database connection", "dsn", s.config.GetDatabaseDSN())
@@ -76,11 +121,16 @@ func (s *Server) Start(ctx context.Context) error {
 				log.Info("Database connection closed successfully")
 			}
 		}()
-		if err := CreateMetricTable(s.container.DB); err != nil {
-			log.Error("Failed to create metrics table", "error", err)
+		if err := CheckSchemaVersion(ctx, s.container.DB); err != nil {
+			return err
 		}
 	}
 
+	s.worker.Restore(ctx)
+	if err := s.deriver.Load(ctx); err != nil {
+		return err
+	}
+
 	go func() {
 		log.Info("Starting HTTP server", "address", s.server.Addr)
 		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -88,19 +138,79 @@ func (s *Server) Start(ctx context.Context) error {
 		}
 	}()
 
+	if s.config.GetGRPCAddress() != "" {
+		listener, err := net.Listen("tcp", s.config.GetGRPCAddress())
+		if err != nil {
+			log.Error("Failed to listen for gRPC", "error", err)
+			return err
+		}
+		go func() {
+			log.Info("Starting gRPC server", "address", s.config.GetGRPCAddress())
+			if err := s.grpc.Serve(listener); err != nil {
+				log.Error("gRPC server error", "error", err)
+			}
+		}()
+	}
+
+	if s.container.StatsDListener != nil {
+		conn, err := net.ListenPacket("udp", s.config.GetStatsDAddress())
+		if err != nil {
+			log.Error("Failed to listen for StatsD", "error", err)
+			return err
+		}
+		go func() {
+			log.Info("Starting StatsD listener", "address", s.config.GetStatsDAddress())
+			s.container.StatsDListener.Serve(ctx, conn)
+		}()
+	}
+
+	if s.container.GraphiteListener != nil {
+		listener, err := net.Listen("tcp", s.config.GetGraphiteAddress())
+		if err != nil {
+			log.Error("Failed to listen for Graphite", "error", err)
+			return err
+		}
+		go func() {
+			log.Info("Starting Graphite listener", "address", s.config.GetGraphiteAddress())
+			s.container.GraphiteListener.Serve(ctx, listener)
+		}()
+	}
+
 	go func() {
 		log.Info("Starting worker")
 		s.worker.Start(ctx)
 	}()
 
+	go func() {
+		log.Info("Starting alerter")
+		s.alerter.Start(ctx)
+	}()
+
+	go func() {
+		log.Info("Starting deriver")
+		s.deriver.Start(ctx)
+	}()
+
+	go func() {
+		log.Info("Starting history pruner")
+		s.pruner.Start(ctx)
+	}()
+
 	<-ctx.Done()
 
 	log.Info("Shutting down server")
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	s.worker.Stop(shutdownCtx)
+	if s.container.StatsDListener != nil {
+		s.container.StatsDListener.Stop(shutdownCtx)
+	}
+	if s.container.GraphiteListener != nil {
+		s.container.GraphiteListener.Stop(shutdownCtx)
+	}
 	s.server.Shutdown(shutdownCtx)
+	s.grpc.GracefulStop()
+	s.worker.Stop(shutdownCtx)
 
 	log.Info("Server shutdown complete")
 	return nil
@@ -125,20 +235,15 @@ func PingDBHandler(db *sql.DB) http.HandlerFunc {
 	}
 }
 
-func CreateMetricTable(db *sql.DB) error {
-	log.Info("Creating metrics table if not exists")
-	_, err := db.Exec(`
-	CREATE TABLE IF NOT EXISTS metrics (
-		id VARCHAR(255) NOT NULL,
-		type VARCHAR(255) NOT NULL,
-		delta BIGINT,
-		value DOUBLE PRECISION,
-		PRIMARY KEY (id, type)
-	);`)
+func CheckSchemaVersion(ctx context.Context, db *sql.DB) error {
+	migrator, err := migrations.NewMigrator(db)
 	if err != nil {
-		log.Error("Failed to create metrics table", "error", err)
 		return err
 	}
-	log.Info("Metrics table ready")
+	if err := migrator.Check(ctx); err != nil {
+		log.Error("Database schema is not up to date", "error", err)
+		return err
+	}
+	log.Info("Database schema is up to date", "version", migrator.Latest())
 	return nil
 }
@@ -19,78 +19,95 @@ func NewWorker(config *Config, container *Container) *Worker {
 	}
 }
 
-func (w *Worker) Start(ctx context.Context) {
+func (w *Worker) Restore(ctx context.Context) {
+	if !w.config.Restore || w.container.FileStorage == nil {
+		return
+	}
 	log.Info("Server is starting, attempting to restore data...")
-	w.restore(ctx)
-	ticker := time.NewTicker(time.Duration(w.config.StoreInterval) * time.Second)
+	count, err := w.restore(ctx)
+	if err != nil {
+		log.Error("Error restoring data from file", "error", err)
+		return
+	}
+	log.Info("Data successfully restored from file", "count", count)
+}
+
+func (w *Worker) Start(ctx context.Context) {
+	if w.container.FileStorage == nil {
+		return
+	}
+	if w.config.IsSyncStore() {
+		log.Info("Store interval is zero, every update is persisted synchronously")
+		return
+	}
+	ticker := time.NewTicker(w.config.GetStoreInterval())
 	defer ticker.Stop()
-	for range ticker.C {
-		log.Info("Periodically saving data...")
-		w.save(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			log.Info("Periodically saving data...")
+			w.snapshot(ctx)
+		case <-ctx.Done():
+			log.Info("Worker is stopping")
+			return
+		}
 	}
 }
 
 func (w *Worker) Stop(ctx context.Context) {
+	if w.container.FileStorage == nil {
+		return
+	}
 	log.Info("Server is stopping, attempting to save data...")
-	w.save(ctx)
+	w.snapshot(ctx)
 }
 
-func (w *Worker) restore(ctx context.Context) {
-	if w.config.Restore {
-		var metrics []*domain.Metric
-		var err error
-		metricsMap, err := w.container.MetricFindFileRepo.Find(ctx, []*domain.MetricID{})
-		if err != nil {
-			log.Error("Error restoring data from file", "error", err)
-		} else {
-			for _, metric := range metricsMap {
-				metrics = append(metrics, metric)
-			}
-			if w.config.GetDatabaseDSN() != "" {
-				err = w.container.MetricSaveDBRepo.Save(ctx, metrics)
-				if err != nil {
-					log.Error("Error saving restored data to db", "error", err)
-				} else {
-					log.Info("Data successfully restored and saved to db")
-				}
-			} else {
-				err = w.container.MetricSaveFileRepo.Save(ctx, metrics)
-				if err != nil {
-					log.Error("Error saving restored data to file", "error", err)
-				} else {
-					log.Info("Data successfully restored and saved to file")
-				}
-			}
-		}
+func (w *Worker) snapshot(ctx context.Context) {
+	count, err := w.save(ctx)
+	if err != nil {
+		log.Error("Failed to save metrics to file", "error", err)
+		return
+	}
+	log.Info("Metrics successfully saved to file", "count", count)
+}
+
+func (w *Worker) restore(ctx context.Context) (int, error) {
+	metricsMap, err := w.container.MetricFindFileRepo.Find(ctx, []*domain.MetricID{})
+	if err != nil {
+		return 0, err
+	}
+	metrics := make([]*domain.Metric, 0, len(metricsMap))
+	for _, metric := range metricsMap {
+		metrics = append(metrics, metric)
+	}
+	if w.container.MetricSaveDBRepo != nil {
+		err = w.container.MetricSaveDBRepo.Save(ctx, metrics)
+	} else {
+		err = w.container.MetricSaveMemoryRepo.Save(ctx, metrics)
+	}
+	if err != nil {
+		return 0, err
 	}
+	return len(metrics), nil
 }
 
-func (w *Worker) save(ctx context.Context) {
+func (w *Worker) save(ctx context.Context) (int, error) {
 	var metricsMap map[domain.MetricID]*domain.Metric
 	var err error
-	if w.config.GetDatabaseDSN() != "" {
+	if w.container.MetricFindDBRepo != nil {
 		metricsMap, err = w.container.MetricFindDBRepo.Find(ctx, []*domain.MetricID{})
-		if err != nil {
-			log.Error("Error saving restored data to db", "error", err)
-		} else {
-			log.Info("Data successfully restored and saved to db")
-		}
 	} else {
-		metricsMap, err = w.container.MetricFindFileRepo.Find(ctx, []*domain.MetricID{})
-		if err != nil {
-			log.Error("Error saving restored data to file", "error", err)
-		} else {
-			log.Info("Data successfully restored and saved to file")
-		}
+		metricsMap, err = w.container.MetricFindMemoryRepo.Find(ctx, []*domain.MetricID{})
+	}
+	if err != nil {
+		return 0, err
 	}
-	var metrics []*domain.Metric
+	metrics := make([]*domain.Metric, 0, len(metricsMap))
 	for _, metric := range metricsMap {
 		metrics = append(metrics, metric)
 	}
-	err = w.container.MetricSaveFileRepo.Save(ctx, metrics)
-	if err != nil {
-		log.Error("Failed to save metrics to file", "error", err)
-	} else {
-		log.Info("Metrics successfully saved to file")
+	if err := w.container.FileStorage.Snapshot(metrics); err != nil {
+		return 0, err
 	}
+	return len(metrics), nil
 }
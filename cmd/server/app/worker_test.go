@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWorker(t *testing.T, path string, storeInterval int, restore bool) *Worker {
+	config := &Config{FileStoragePath: path, StoreInterval: storeInterval, Restore: restore}
+	container, err := NewContainer(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { container.FileStorage.Close() })
+	return NewWorker(config, container)
+}
+
+func updateTestCounter(t *testing.T, w *Worker, delta int64) {
+	_, err := w.container.MetricUpdateService.Update(context.Background(), []*domain.Metric{
+		{MetricID: domain.MetricID{ID: "PollCount", Type: domain.Counter}, Delta: &delta},
+	})
+	require.NoError(t, err)
+}
+
+func TestWorker_SnapshotAndRestore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	id := domain.MetricID{ID: "PollCount", Type: domain.Counter}
+
+	first := newTestWorker(t, path, 300, true)
+	updateTestCounter(t, first, 5)
+	updateTestCounter(t, first, 2)
+	assert.Empty(t, first.container.FileStorage.Find(nil))
+	first.Stop(ctx)
+	require.NoError(t, first.container.FileStorage.Close())
+
+	second := newTestWorker(t, path, 300, true)
+	assert.Empty(t, second.container.Memory)
+	second.Restore(ctx)
+	require.Contains(t, second.container.Memory, id)
+	assert.Equal(t, int64(7), *second.container.Memory[id].Delta)
+
+	updateTestCounter(t, second, 1)
+	assert.Equal(t, int64(8), *second.container.Memory[id].Delta)
+}
+
+func TestWorker_RestoreDisabled(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "metrics.json")
+
+	first := newTestWorker(t, path, 300, true)
+	updateTestCounter(t, first, 5)
+	first.Stop(ctx)
+	require.NoError(t, first.container.FileStorage.Close())
+
+	second := newTestWorker(t, path, 300, false)
+	second.Restore(ctx)
+	assert.Empty(t, second.container.Memory)
+}
+
+func TestWorker_SyncStoreWritesThrough(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	id := domain.MetricID{ID: "PollCount", Type: domain.Counter}
+
+	first := newTestWorker(t, path, 0, true)
+	updateTestCounter(t, first, 3)
+	require.NoError(t, first.container.FileStorage.Close())
+
+	second := newTestWorker(t, path, 0, true)
+	second.Restore(ctx)
+	require.Contains(t, second.container.Memory, id)
+	assert.Equal(t, int64(3), *second.container.Memory[id].Delta)
+}
@@ -0,0 +1,82 @@
+package collectors
+
+import (
+	"context"
+	"errors"
+	"go-metrics/internal/domain"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrCollectorAlreadyRegistered = errors.New("collector is already registered")
+	ErrUnknownCollector           = errors.New("unknown collector")
+)
+
+type Collector interface {
+	Collect(ctx context.Context) ([]domain.Metric, error)
+}
+
+type Options struct {
+	GCPauseBuckets []float64
+	DiskPath       string
+}
+
+type Factory func(options *Options) Collector
+
+type Registry struct {
+	factories map[string]Factory
+	mu        sync.RWMutex
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: make(map[string]Factory),
+	}
+}
+
+func (r *Registry) Register(name string, factory Factory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.factories[name]; exists {
+		return ErrCollectorAlreadyRegistered
+	}
+	r.factories[name] = factory
+	return nil
+}
+
+func (r *Registry) New(name string, options *Options) (Collector, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	factory, exists := r.factories[name]
+	if !exists {
+		return nil, ErrUnknownCollector
+	}
+	return factory(options), nil
+}
+
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var DefaultRegistry = NewRegistry()
+
+func Register(name string, factory Factory) error {
+	return DefaultRegistry.Register(name, factory)
+}
+
+func init() {
+	DefaultRegistry.Register(RuntimeCollectorName, NewRuntimeCollector)
+	DefaultRegistry.Register(MemoryCollectorName, NewMemoryCollector)
+	DefaultRegistry.Register(CPUCollectorName, NewCPUCollector)
+	DefaultRegistry.Register(DiskCollectorName, NewDiskCollector)
+	DefaultRegistry.Register(NetworkCollectorName, NewNetworkCollector)
+	DefaultRegistry.Register(LoadCollectorName, NewLoadCollector)
+}
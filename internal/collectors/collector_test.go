@@ -0,0 +1,73 @@
+package collectors
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticCollector struct {
+	metrics []domain.Metric
+}
+
+func (c *staticCollector) Collect(ctx context.Context) ([]domain.Metric, error) {
+	return c.metrics, nil
+}
+
+func TestRegistry_RegisterAndNew(t *testing.T) {
+	registry := NewRegistry()
+	value := 1.0
+	factory := func(options *Options) Collector {
+		return &staticCollector{metrics: []domain.Metric{{MetricID: domain.MetricID{ID: "Custom", Type: domain.Gauge}, Value: &value}}}
+	}
+	require.NoError(t, registry.Register("custom", factory))
+	assert.Equal(t, ErrCollectorAlreadyRegistered, registry.Register("custom", factory))
+	assert.Equal(t, []string{"custom"}, registry.Names())
+
+	collector, err := registry.New("custom", &Options{})
+	require.NoError(t, err)
+	metrics, err := collector.Collect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "Custom", metrics[0].ID)
+
+	collector, err = registry.New("missing", &Options{})
+	assert.Nil(t, collector)
+	assert.Equal(t, ErrUnknownCollector, err)
+}
+
+func TestDefaultRegistry_BuiltinCollectors(t *testing.T) {
+	assert.Equal(t, []string{"cpu", "disk", "load", "memory", "network", "runtime"}, DefaultRegistry.Names())
+}
+
+func TestRuntimeCollector_Collect(t *testing.T) {
+	collector := NewRuntimeCollector(&Options{GCPauseBuckets: []float64{1000, 1000000}})
+	metrics, err := collector.Collect(context.Background())
+	require.NoError(t, err)
+	byID := make(map[string]domain.Metric)
+	for _, metric := range metrics {
+		byID[metric.ID] = metric
+	}
+	assert.Contains(t, byID, "HeapAlloc")
+	assert.Contains(t, byID, "PauseTotalNs")
+	assert.Equal(t, int64(1), *byID["PollCount"].Delta)
+	histogram := byID["GCPauseNs"].Histogram
+	require.NotNil(t, histogram)
+	assert.Equal(t, []float64{1000, 1000000}, histogram.Buckets)
+}
+
+func TestRuntimeCollector_ReportsOnlyNewGCPauses(t *testing.T) {
+	collector := NewRuntimeCollector(nil).(*RuntimeCollector)
+	_, err := collector.Collect(context.Background())
+	require.NoError(t, err)
+	before := collector.lastNumGC
+	runtime.GC()
+	metrics, err := collector.Collect(context.Background())
+	require.NoError(t, err)
+	histogram := metrics[len(metrics)-1].Histogram
+	assert.GreaterOrEqual(t, histogram.Count, int64(1))
+	assert.Equal(t, int64(collector.lastNumGC-before), histogram.Count)
+}
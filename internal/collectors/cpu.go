@@ -0,0 +1,33 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"go-metrics/internal/domain"
+
+	"github.com/shirou/gopsutil/cpu"
+)
+
+const CPUCollectorName = "cpu"
+
+type CPUCollector struct{}
+
+func NewCPUCollector(options *Options) Collector {
+	return &CPUCollector{}
+}
+
+func (c *CPUCollector) Collect(ctx context.Context) ([]domain.Metric, error) {
+	coreCPUPercent, err := cpu.PercentWithContext(ctx, 0, true)
+	if err != nil {
+		return nil, err
+	}
+	metrics := make([]domain.Metric, 0, len(coreCPUPercent))
+	for i, util := range coreCPUPercent {
+		value := util
+		metrics = append(metrics, domain.Metric{
+			MetricID: domain.MetricID{ID: fmt.Sprintf("CPUutilization%d", i+1), Type: domain.Gauge},
+			Value:    &value,
+		})
+	}
+	return metrics, nil
+}
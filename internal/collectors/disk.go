@@ -0,0 +1,36 @@
+package collectors
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+
+	"github.com/shirou/gopsutil/disk"
+)
+
+const DiskCollectorName = "disk"
+
+type DiskCollector struct {
+	path string
+}
+
+func NewDiskCollector(options *Options) Collector {
+	path := "/"
+	if options != nil && options.DiskPath != "" {
+		path = options.DiskPath
+	}
+	return &DiskCollector{path: path}
+}
+
+func (c *DiskCollector) Collect(ctx context.Context) ([]domain.Metric, error) {
+	usage, err := disk.UsageWithContext(ctx, c.path)
+	if err != nil {
+		return nil, err
+	}
+	float64ptr := func(value float64) *float64 { return &value }
+	labels := domain.NewLabels(map[string]string{"path": c.path})
+	return []domain.Metric{
+		{MetricID: domain.MetricID{ID: "DiskTotal", Type: domain.Gauge, Labels: labels}, Value: float64ptr(float64(usage.Total))},
+		{MetricID: domain.MetricID{ID: "DiskFree", Type: domain.Gauge, Labels: labels}, Value: float64ptr(float64(usage.Free))},
+		{MetricID: domain.MetricID{ID: "DiskUsedPercent", Type: domain.Gauge, Labels: labels}, Value: float64ptr(usage.UsedPercent)},
+	}, nil
+}
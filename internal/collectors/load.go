@@ -0,0 +1,28 @@
+package collectors
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+
+	"github.com/shirou/gopsutil/load"
+)
+
+const LoadCollectorName = "load"
+
+type LoadCollector struct{}
+
+func NewLoadCollector(options *Options) Collector {
+	return &LoadCollector{}
+}
+
+func (c *LoadCollector) Collect(ctx context.Context) ([]domain.Metric, error) {
+	avg, err := load.AvgWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []domain.Metric{
+		{MetricID: domain.MetricID{ID: "Load1", Type: domain.Gauge}, Value: &avg.Load1},
+		{MetricID: domain.MetricID{ID: "Load5", Type: domain.Gauge}, Value: &avg.Load5},
+		{MetricID: domain.MetricID{ID: "Load15", Type: domain.Gauge}, Value: &avg.Load15},
+	}, nil
+}
@@ -0,0 +1,28 @@
+package collectors
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+
+	"github.com/shirou/gopsutil/mem"
+)
+
+const MemoryCollectorName = "memory"
+
+type MemoryCollector struct{}
+
+func NewMemoryCollector(options *Options) Collector {
+	return &MemoryCollector{}
+}
+
+func (c *MemoryCollector) Collect(ctx context.Context) ([]domain.Metric, error) {
+	vmStat, err := mem.VirtualMemoryWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	float64ptr := func(value float64) *float64 { return &value }
+	return []domain.Metric{
+		{MetricID: domain.MetricID{ID: "TotalMemory", Type: domain.Gauge}, Value: float64ptr(float64(vmStat.Total))},
+		{MetricID: domain.MetricID{ID: "FreeMemory", Type: domain.Gauge}, Value: float64ptr(float64(vmStat.Free))},
+	}, nil
+}
@@ -0,0 +1,48 @@
+package collectors
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+
+	"github.com/shirou/gopsutil/net"
+)
+
+const NetworkCollectorName = "network"
+
+type NetworkCollector struct {
+	last *net.IOCountersStat
+}
+
+func NewNetworkCollector(options *Options) Collector {
+	return &NetworkCollector{}
+}
+
+func (c *NetworkCollector) Collect(ctx context.Context) ([]domain.Metric, error) {
+	counters, err := net.IOCountersWithContext(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(counters) == 0 {
+		return nil, nil
+	}
+	current := counters[0]
+	last := c.last
+	c.last = &current
+	if last == nil {
+		return nil, nil
+	}
+	return []domain.Metric{
+		{MetricID: domain.MetricID{ID: "NetworkBytesSent", Type: domain.Counter}, Delta: counterDelta(current.BytesSent, last.BytesSent)},
+		{MetricID: domain.MetricID{ID: "NetworkBytesRecv", Type: domain.Counter}, Delta: counterDelta(current.BytesRecv, last.BytesRecv)},
+		{MetricID: domain.MetricID{ID: "NetworkPacketsSent", Type: domain.Counter}, Delta: counterDelta(current.PacketsSent, last.PacketsSent)},
+		{MetricID: domain.MetricID{ID: "NetworkPacketsRecv", Type: domain.Counter}, Delta: counterDelta(current.PacketsRecv, last.PacketsRecv)},
+	}, nil
+}
+
+func counterDelta(current uint64, last uint64) *int64 {
+	delta := int64(current)
+	if current >= last {
+		delta = int64(current - last)
+	}
+	return &delta
+}
@@ -0,0 +1,79 @@
+package collectors
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"math/rand/v2"
+	"runtime"
+)
+
+const RuntimeCollectorName = "runtime"
+
+type RuntimeCollector struct {
+	buckets   []float64
+	lastNumGC uint32
+}
+
+func NewRuntimeCollector(options *Options) Collector {
+	buckets := domain.DefaultHistogramBuckets
+	if options != nil && len(options.GCPauseBuckets) > 0 {
+		buckets = options.GCPauseBuckets
+	}
+	return &RuntimeCollector{buckets: buckets}
+}
+
+func (c *RuntimeCollector) Collect(ctx context.Context) ([]domain.Metric, error) {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	float64ptr := func(value float64) *float64 { return &value }
+	int64ptr := func(value int64) *int64 { return &value }
+	metrics := []domain.Metric{
+		{MetricID: domain.MetricID{ID: "Alloc", Type: domain.Gauge}, Value: float64ptr(float64(memStats.Alloc))},
+		{MetricID: domain.MetricID{ID: "BuckHashSys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.BuckHashSys))},
+		{MetricID: domain.MetricID{ID: "Frees", Type: domain.Gauge}, Value: float64ptr(float64(memStats.Frees))},
+		{MetricID: domain.MetricID{ID: "GCCPUFraction", Type: domain.Gauge}, Value: &memStats.GCCPUFraction},
+		{MetricID: domain.MetricID{ID: "GCSys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.GCSys))},
+		{MetricID: domain.MetricID{ID: "HeapAlloc", Type: domain.Gauge}, Value: float64ptr(float64(memStats.HeapAlloc))},
+		{MetricID: domain.MetricID{ID: "HeapIdle", Type: domain.Gauge}, Value: float64ptr(float64(memStats.HeapIdle))},
+		{MetricID: domain.MetricID{ID: "HeapInuse", Type: domain.Gauge}, Value: float64ptr(float64(memStats.HeapInuse))},
+		{MetricID: domain.MetricID{ID: "HeapObjects", Type: domain.Gauge}, Value: float64ptr(float64(memStats.HeapObjects))},
+		{MetricID: domain.MetricID{ID: "HeapReleased", Type: domain.Gauge}, Value: float64ptr(float64(memStats.HeapReleased))},
+		{MetricID: domain.MetricID{ID: "HeapSys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.HeapSys))},
+		{MetricID: domain.MetricID{ID: "LastGC", Type: domain.Gauge}, Value: float64ptr(float64(memStats.LastGC))},
+		{MetricID: domain.MetricID{ID: "Lookups", Type: domain.Gauge}, Value: float64ptr(float64(memStats.Lookups))},
+		{MetricID: domain.MetricID{ID: "MCacheInuse", Type: domain.Gauge}, Value: float64ptr(float64(memStats.MCacheInuse))},
+		{MetricID: domain.MetricID{ID: "MCacheSys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.MCacheSys))},
+		{MetricID: domain.MetricID{ID: "MSpanInuse", Type: domain.Gauge}, Value: float64ptr(float64(memStats.MSpanInuse))},
+		{MetricID: domain.MetricID{ID: "MSpanSys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.MSpanSys))},
+		{MetricID: domain.MetricID{ID: "Mallocs", Type: domain.Gauge}, Value: float64ptr(float64(memStats.Mallocs))},
+		{MetricID: domain.MetricID{ID: "NextGC", Type: domain.Gauge}, Value: float64ptr(float64(memStats.NextGC))},
+		{MetricID: domain.MetricID{ID: "NumForcedGC", Type: domain.Gauge}, Value: float64ptr(float64(memStats.NumForcedGC))},
+		{MetricID: domain.MetricID{ID: "NumGC", Type: domain.Gauge}, Value: float64ptr(float64(memStats.NumGC))},
+		{MetricID: domain.MetricID{ID: "OtherSys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.OtherSys))},
+		{MetricID: domain.MetricID{ID: "PauseTotalNs", Type: domain.Gauge}, Value: float64ptr(float64(memStats.PauseTotalNs))},
+		{MetricID: domain.MetricID{ID: "StackInuse", Type: domain.Gauge}, Value: float64ptr(float64(memStats.StackInuse))},
+		{MetricID: domain.MetricID{ID: "StackSys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.StackSys))},
+		{MetricID: domain.MetricID{ID: "Sys", Type: domain.Gauge}, Value: float64ptr(float64(memStats.Sys))},
+		{MetricID: domain.MetricID{ID: "TotalAlloc", Type: domain.Gauge}, Value: float64ptr(float64(memStats.TotalAlloc))},
+		{MetricID: domain.MetricID{ID: "RandomValue", Type: domain.Gauge}, Value: float64ptr(rand.Float64())},
+		{MetricID: domain.MetricID{ID: "PollCount", Type: domain.Counter}, Delta: int64ptr(1)},
+	}
+	return append(metrics, c.collectGCPauses(&memStats)), nil
+}
+
+func (c *RuntimeCollector) collectGCPauses(memStats *runtime.MemStats) domain.Metric {
+	histogram := domain.NewHistogram(c.buckets)
+	size := uint32(len(memStats.PauseNs))
+	first := c.lastNumGC + 1
+	if memStats.NumGC > size && first <= memStats.NumGC-size {
+		first = memStats.NumGC - size + 1
+	}
+	for n := first; n <= memStats.NumGC; n++ {
+		histogram.Observe(float64(memStats.PauseNs[(n+size-1)%size]))
+	}
+	c.lastNumGC = memStats.NumGC
+	return domain.Metric{
+		MetricID:  domain.MetricID{ID: "GCPauseNs", Type: domain.Histogram},
+		Histogram: histogram,
+	}
+}
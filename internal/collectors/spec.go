@@ -0,0 +1,41 @@
+package collectors
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCollectorSpec = errors.New("invalid collector spec: expected name[:seconds] separated by commas")
+
+type Spec struct {
+	Name     string
+	Interval time.Duration
+}
+
+func ParseSpecs(value string, defaultInterval time.Duration) ([]Spec, error) {
+	var specs []Spec
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		spec := Spec{Name: part, Interval: defaultInterval}
+		if name, interval, found := strings.Cut(part, ":"); found {
+			seconds, err := strconv.Atoi(strings.TrimSpace(interval))
+			if err != nil || seconds <= 0 {
+				return nil, ErrInvalidCollectorSpec
+			}
+			spec.Name = strings.TrimSpace(name)
+			spec.Interval = time.Duration(seconds) * time.Second
+		}
+		if spec.Name == "" || seen[spec.Name] {
+			return nil, ErrInvalidCollectorSpec
+		}
+		seen[spec.Name] = true
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
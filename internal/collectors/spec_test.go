@@ -0,0 +1,47 @@
+package collectors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSpecs(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []Spec
+		err      error
+	}{
+		{
+			name:  "default intervals",
+			value: "runtime,memory",
+			expected: []Spec{
+				{Name: "runtime", Interval: 2 * time.Second},
+				{Name: "memory", Interval: 2 * time.Second},
+			},
+		},
+		{
+			name:  "custom intervals",
+			value: "runtime:1, disk:60 ,load",
+			expected: []Spec{
+				{Name: "runtime", Interval: time.Second},
+				{Name: "disk", Interval: time.Minute},
+				{Name: "load", Interval: 2 * time.Second},
+			},
+		},
+		{name: "empty", value: "", expected: nil},
+		{name: "invalid interval", value: "cpu:fast", err: ErrInvalidCollectorSpec},
+		{name: "zero interval", value: "cpu:0", err: ErrInvalidCollectorSpec},
+		{name: "duplicate", value: "cpu,cpu:5", err: ErrInvalidCollectorSpec},
+		{name: "missing name", value: ":5", err: ErrInvalidCollectorSpec},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			specs, err := ParseSpecs(tt.value, 2*time.Second)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.expected, specs)
+		})
+	}
+}
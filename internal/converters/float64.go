@@ -2,11 +2,15 @@ package converters
 
 import (
 	"errors"
+	"sort"
 	"strconv"
+	"strings"
 )
 
 var ErrInvalidFloat64 = errors.New("invalid float64 value")
 
+var ErrInvalidFloat64Slice = errors.New("invalid float64 list value")
+
 func ConvertToFloat64(value string) (*float64, error) {
 	result, err := strconv.ParseFloat(value, 64)
 	if err != nil {
@@ -18,3 +22,22 @@ func ConvertToFloat64(value string) (*float64, error) {
 func FormatFloat64(value float64) string {
 	return strconv.FormatFloat(value, 'f', -1, 64)
 }
+
+func ConvertToSortedFloat64Slice(value string) ([]float64, error) {
+	parts := strings.Split(value, ",")
+	result := make([]float64, 0, len(parts))
+	for _, part := range parts {
+		number, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return nil, ErrInvalidFloat64Slice
+		}
+		result = append(result, number)
+	}
+	sort.Float64s(result)
+	for i := 1; i < len(result); i++ {
+		if result[i] == result[i-1] {
+			return nil, ErrInvalidFloat64Slice
+		}
+	}
+	return result, nil
+}
@@ -91,3 +91,51 @@ func TestFormatFloat64(t *testing.T) {
 func ptrFloat64(f float64) *float64 {
 	return &f
 }
+
+func TestConvertToSortedFloat64Slice(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []float64
+		err      error
+	}{
+		{
+			name:     "sorted list",
+			value:    "0.1,1,10",
+			expected: []float64{0.1, 1, 10},
+			err:      nil,
+		},
+		{
+			name:     "unsorted list with spaces",
+			value:    "10, 0.1 ,1",
+			expected: []float64{0.1, 1, 10},
+			err:      nil,
+		},
+		{
+			name:     "invalid element",
+			value:    "1,abc",
+			expected: nil,
+			err:      ErrInvalidFloat64Slice,
+		},
+		{
+			name:     "duplicate element",
+			value:    "1,1",
+			expected: nil,
+			err:      ErrInvalidFloat64Slice,
+		},
+		{
+			name:     "empty value",
+			value:    "",
+			expected: nil,
+			err:      ErrInvalidFloat64Slice,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ConvertToSortedFloat64Slice(tt.value)
+			assert.Equal(t, tt.expected, result)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package converters
+
+import (
+	"go-metrics/internal/domain"
+	"go-metrics/internal/proto"
+)
+
+func ConvertMetricToProto(metric *domain.Metric) *proto.Metric {
+	result := &proto.Metric{
+		Id:     metric.ID,
+		Type:   string(metric.Type),
+		Labels: metric.Labels.Map(),
+		Delta:  metric.Delta,
+		Value:  metric.Value,
+	}
+	if metric.Histogram != nil {
+		result.Histogram = &proto.Histogram{
+			Buckets: metric.Histogram.Buckets,
+			Counts:  metric.Histogram.Counts,
+			Sum:     metric.Histogram.Sum,
+			Count:   metric.Histogram.Count,
+		}
+	}
+	if metric.Summary != nil {
+		result.Summary = &proto.Summary{
+			Quantiles:    metric.Summary.Quantiles,
+			Values:       metric.Summary.Values,
+			Observations: metric.Summary.Observations,
+			Sum:          metric.Summary.Sum,
+			Count:        metric.Summary.Count,
+		}
+	}
+	return result
+}
+
+func ConvertProtoToHistogram(histogram *proto.Histogram) *domain.HistogramValue {
+	if histogram == nil {
+		return nil
+	}
+	return &domain.HistogramValue{
+		Buckets: histogram.GetBuckets(),
+		Counts:  histogram.GetCounts(),
+		Sum:     histogram.GetSum(),
+		Count:   histogram.GetCount(),
+	}
+}
+
+func ConvertProtoToSummary(summary *proto.Summary) *domain.SummaryValue {
+	if summary == nil {
+		return nil
+	}
+	return &domain.SummaryValue{
+		Quantiles:    summary.GetQuantiles(),
+		Values:       summary.GetValues(),
+		Observations: summary.GetObservations(),
+		Sum:          summary.GetSum(),
+		Count:        summary.GetCount(),
+	}
+}
@@ -0,0 +1,37 @@
+package converters
+
+import (
+	"go-metrics/internal/domain"
+	"go-metrics/internal/proto"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertMetricToProto(t *testing.T) {
+	delta := int64(5)
+	value := 1.5
+	counter := &domain.Metric{
+		MetricID: domain.MetricID{ID: "PollCount", Type: domain.Counter, Labels: domain.NewLabels(map[string]string{"host": "a"})},
+		Delta:    &delta,
+	}
+	assert.Equal(t, &proto.Metric{Id: "PollCount", Type: "counter", Labels: map[string]string{"host": "a"}, Delta: &delta}, ConvertMetricToProto(counter))
+
+	gauge := &domain.Metric{MetricID: domain.MetricID{ID: "Alloc", Type: domain.Gauge}, Value: &value}
+	assert.Equal(t, &proto.Metric{Id: "Alloc", Type: "gauge", Value: &value}, ConvertMetricToProto(gauge))
+
+	histogram := domain.NewHistogram([]float64{1})
+	histogram.Observe(2)
+	converted := ConvertMetricToProto(&domain.Metric{MetricID: domain.MetricID{ID: "GCPauseNs", Type: domain.Histogram}, Histogram: histogram})
+	assert.Equal(t, histogram, ConvertProtoToHistogram(converted.Histogram))
+
+	summary := domain.NewSummary([]float64{0.5})
+	summary.Observe(3)
+	converted = ConvertMetricToProto(&domain.Metric{MetricID: domain.MetricID{ID: "Latency", Type: domain.Summary}, Summary: summary})
+	assert.Equal(t, summary, ConvertProtoToSummary(converted.Summary))
+}
+
+func TestConvertProtoToValues_Nil(t *testing.T) {
+	assert.Nil(t, ConvertProtoToHistogram(nil))
+	assert.Nil(t, ConvertProtoToSummary(nil))
+}
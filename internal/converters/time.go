@@ -0,0 +1,21 @@
+package converters
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+var ErrInvalidTime = errors.New("invalid time value")
+
+func ConvertToTime(value string) (*time.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		result := time.Unix(seconds, 0)
+		return &result, nil
+	}
+	result, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, ErrInvalidTime
+	}
+	return &result, nil
+}
@@ -0,0 +1,51 @@
+package converters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertToTime(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected *time.Time
+		err      error
+	}{
+		{
+			name:     "unix seconds",
+			value:    "1700000000",
+			expected: ptrTime(time.Unix(1700000000, 0)),
+			err:      nil,
+		},
+		{
+			name:     "RFC3339",
+			value:    "2024-01-02T03:04:05Z",
+			expected: ptrTime(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+			err:      nil,
+		},
+		{
+			name:     "invalid",
+			value:    "yesterday",
+			expected: nil,
+			err:      ErrInvalidTime,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ConvertToTime(tt.value)
+			assert.Equal(t, tt.err, err)
+			if tt.expected == nil {
+				assert.Nil(t, result)
+			} else {
+				assert.True(t, tt.expected.Equal(*result))
+			}
+		})
+	}
+}
+
+func ptrTime(v time.Time) *time.Time {
+	return &v
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+type AlertState string
+
+const (
+	AlertInactive AlertState = "inactive"
+	AlertPending  AlertState = "pending"
+	AlertFiring   AlertState = "firing"
+	AlertResolved AlertState = "resolved"
+)
+
+const AlertFunctionRate = "rate"
+
+var ErrInvalidAlertRule = errors.New("invalid alert rule expression")
+
+var alertRuleExpr = regexp.MustCompile(
+	`^\s*(?:(rate)\(\s*([^\s()<>=!]+)\s*\)|([^\s()<>=!]+))\s*(>=|<=|==|!=|>|<)\s*(\S+?)(?:\s+for\s+(\S+))?\s*$`,
+)
+
+type AlertRule struct {
+	Name      string        `json:"name"`
+	Expr      string        `json:"expr"`
+	Function  string        `json:"-"`
+	Metric    string        `json:"-"`
+	Operator  string        `json:"-"`
+	Threshold float64       `json:"-"`
+	For       time.Duration `json:"-"`
+}
+
+func NewAlertRule(name string, expr string) (*AlertRule, error) {
+	match := alertRuleExpr.FindStringSubmatch(expr)
+	if match == nil {
+		return nil, ErrInvalidAlertRule
+	}
+	rule := &AlertRule{
+		Name:     name,
+		Expr:     expr,
+		Function: match[1],
+		Metric:   match[2] + match[3],
+		Operator: match[4],
+	}
+	threshold, err := strconv.ParseFloat(match[5], 64)
+	if err != nil {
+		return nil, ErrInvalidAlertRule
+	}
+	rule.Threshold = threshold
+	if match[6] != "" {
+		duration, err := time.ParseDuration(match[6])
+		if err != nil || duration < 0 {
+			return nil, ErrInvalidAlertRule
+		}
+		rule.For = duration
+	}
+	if rule.Name == "" {
+		rule.Name = expr
+	}
+	return rule, nil
+}
+
+func (r *AlertRule) Matches(value float64) bool {
+	switch r.Operator {
+	case ">":
+		return value > r.Threshold
+	case ">=":
+		return value >= r.Threshold
+	case "<":
+		return value < r.Threshold
+	case "<=":
+		return value <= r.Threshold
+	case "==":
+		return value == r.Threshold
+	case "!=":
+		return value != r.Threshold
+	default:
+		return false
+	}
+}
+
+type AlertID struct {
+	Name   string `json:"name"`
+	Labels Labels `json:"labels,omitempty"`
+}
+
+type Alert struct {
+	AlertID
+	Expr       string     `json:"expr"`
+	State      AlertState `json:"state"`
+	Value      *float64   `json:"value,omitempty"`
+	ActiveAt   *time.Time `json:"active_at,omitempty"`
+	FiredAt    *time.Time `json:"fired_at,omitempty"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+	Unsent     bool       `json:"-"`
+}
+
+func (a *Alert) Transition(rule *AlertRule, active bool, now time.Time) bool {
+	previous := a.State
+	switch {
+	case active && (a.State == AlertPending || a.State == AlertFiring):
+	case active:
+		a.State = AlertPending
+		a.ActiveAt = &now
+		a.FiredAt = nil
+		a.ResolvedAt = nil
+	case a.State == AlertFiring:
+		a.State = AlertResolved
+		a.ResolvedAt = &now
+	case a.State == AlertPending:
+		a.State = AlertInactive
+		a.ActiveAt = nil
+	}
+	if a.State == AlertPending && !now.Before(a.ActiveAt.Add(rule.For)) {
+		a.State = AlertFiring
+		a.FiredAt = &now
+	}
+	if a.State != previous {
+		a.Unsent = true
+		return true
+	}
+	return false
+}
+
+func (a *Alert) SameState(other *Alert) bool {
+	return a.State == other.State &&
+		sameAlertTime(a.ActiveAt, other.ActiveAt) &&
+		sameAlertTime(a.FiredAt, other.FiredAt) &&
+		sameAlertTime(a.ResolvedAt, other.ResolvedAt)
+}
+
+func sameAlertTime(a *time.Time, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func (a *Alert) Clone() *Alert {
+	if a == nil {
+		return nil
+	}
+	clone := *a
+	if a.Value != nil {
+		value := *a.Value
+		clone.Value = &value
+	}
+	return &clone
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAlertRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected *AlertRule
+		err      error
+	}{
+		{
+			name: "threshold with duration",
+			expr: "HeapAlloc > 5e8 for 2m",
+			expected: &AlertRule{
+				Name: "heap", Expr: "HeapAlloc > 5e8 for 2m",
+				Metric: "HeapAlloc", Operator: ">", Threshold: 5e8, For: 2 * time.Minute,
+			},
+		},
+		{
+			name: "rate function",
+			expr: "rate(PollCount) == 0 for 1m",
+			expected: &AlertRule{
+				Name: "heap", Expr: "rate(PollCount) == 0 for 1m",
+				Function: AlertFunctionRate, Metric: "PollCount", Operator: "==", Threshold: 0, For: time.Minute,
+			},
+		},
+		{
+			name: "without duration and spaces",
+			expr: "CPUutilization1>=90",
+			expected: &AlertRule{
+				Name: "heap", Expr: "CPUutilization1>=90",
+				Metric: "CPUutilization1", Operator: ">=", Threshold: 90,
+			},
+		},
+		{name: "missing operator", expr: "HeapAlloc 5e8", err: ErrInvalidAlertRule},
+		{name: "invalid threshold", expr: "HeapAlloc > big", err: ErrInvalidAlertRule},
+		{name: "invalid duration", expr: "HeapAlloc > 1 for ever", err: ErrInvalidAlertRule},
+		{name: "unknown function", expr: "avg(HeapAlloc) > 1", err: ErrInvalidAlertRule},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := NewAlertRule("heap", tt.expr)
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.expected, rule)
+		})
+	}
+}
+
+func TestNewAlertRule_DefaultName(t *testing.T) {
+	rule, err := NewAlertRule("", "HeapAlloc > 1")
+	require.NoError(t, err)
+	assert.Equal(t, "HeapAlloc > 1", rule.Name)
+}
+
+func TestAlertRule_Matches(t *testing.T) {
+	operators := map[string][3]bool{
+		">":  {false, false, true},
+		">=": {false, true, true},
+		"<":  {true, false, false},
+		"<=": {true, true, false},
+		"==": {false, true, false},
+		"!=": {true, false, true},
+	}
+	for operator, expected := range operators {
+		rule := &AlertRule{Operator: operator, Threshold: 1}
+		assert.Equal(t, expected[0], rule.Matches(0), operator)
+		assert.Equal(t, expected[1], rule.Matches(1), operator)
+		assert.Equal(t, expected[2], rule.Matches(2), operator)
+	}
+}
+
+func TestAlert_Transition(t *testing.T) {
+	rule := &AlertRule{For: time.Minute}
+	start := time.Unix(1000, 0)
+	alert := &Alert{State: AlertInactive}
+
+	assert.False(t, alert.Transition(rule, false, start))
+	assert.Equal(t, AlertInactive, alert.State)
+
+	assert.True(t, alert.Transition(rule, true, start))
+	assert.Equal(t, AlertPending, alert.State)
+	assert.Equal(t, start, *alert.ActiveAt)
+
+	assert.False(t, alert.Transition(rule, true, start.Add(30*time.Second)))
+	assert.Equal(t, AlertPending, alert.State)
+
+	assert.True(t, alert.Transition(rule, true, start.Add(time.Minute)))
+	assert.Equal(t, AlertFiring, alert.State)
+	assert.Equal(t, start.Add(time.Minute), *alert.FiredAt)
+
+	assert.True(t, alert.Transition(rule, false, start.Add(2*time.Minute)))
+	assert.Equal(t, AlertResolved, alert.State)
+	assert.Equal(t, start.Add(2*time.Minute), *alert.ResolvedAt)
+
+	assert.False(t, alert.Transition(rule, false, start.Add(3*time.Minute)))
+	assert.Equal(t, AlertResolved, alert.State)
+
+	assert.True(t, alert.Transition(rule, true, start.Add(4*time.Minute)))
+	assert.Equal(t, AlertPending, alert.State)
+	assert.Nil(t, alert.FiredAt)
+	assert.Nil(t, alert.ResolvedAt)
+
+	assert.True(t, alert.Transition(rule, false, start.Add(5*time.Minute)))
+	assert.Equal(t, AlertInactive, alert.State)
+	assert.Nil(t, alert.ActiveAt)
+}
+
+func TestAlert_TransitionWithoutDurationFiresImmediately(t *testing.T) {
+	alert := &Alert{State: AlertInactive}
+	assert.True(t, alert.Transition(&AlertRule{}, true, time.Unix(1000, 0)))
+	assert.Equal(t, AlertFiring, alert.State)
+}
+
+func TestAlert_TransitionMarksUnsent(t *testing.T) {
+	alert := &Alert{State: AlertInactive}
+	previous := alert.Clone()
+	assert.True(t, alert.Transition(&AlertRule{}, true, time.Unix(1000, 0)))
+	assert.True(t, alert.Unsent)
+	assert.False(t, alert.SameState(previous))
+	assert.True(t, alert.SameState(alert.Clone()))
+}
@@ -0,0 +1,280 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const DerivedFunctionRate = "rate"
+
+type DerivedEnv interface {
+	Value(id string) (float64, bool)
+	Rate(id string, window time.Duration) (float64, bool)
+}
+
+type derivedExpr interface {
+	eval(env DerivedEnv) (float64, bool)
+	refs(visit func(id string))
+}
+
+type derivedNumber float64
+
+func (n derivedNumber) eval(DerivedEnv) (float64, bool) {
+	return float64(n), true
+}
+
+func (n derivedNumber) refs(func(string)) {}
+
+type derivedRef string
+
+func (r derivedRef) eval(env DerivedEnv) (float64, bool) {
+	return env.Value(string(r))
+}
+
+func (r derivedRef) refs(visit func(string)) {
+	visit(string(r))
+}
+
+type derivedRate struct {
+	id     string
+	window time.Duration
+}
+
+func (r *derivedRate) eval(env DerivedEnv) (float64, bool) {
+	return env.Rate(r.id, r.window)
+}
+
+func (r *derivedRate) refs(visit func(string)) {
+	visit(r.id)
+}
+
+type derivedNeg struct {
+	x derivedExpr
+}
+
+func (n *derivedNeg) eval(env DerivedEnv) (float64, bool) {
+	x, ok := n.x.eval(env)
+	return -x, ok
+}
+
+func (n *derivedNeg) refs(visit func(string)) {
+	n.x.refs(visit)
+}
+
+type derivedBinary struct {
+	op   byte
+	x, y derivedExpr
+}
+
+func (b *derivedBinary) eval(env DerivedEnv) (float64, bool) {
+	x, ok := b.x.eval(env)
+	if !ok {
+		return 0, false
+	}
+	y, ok := b.y.eval(env)
+	if !ok {
+		return 0, false
+	}
+	switch b.op {
+	case '+':
+		return x + y, true
+	case '-':
+		return x - y, true
+	case '*':
+		return x * y, true
+	case '/':
+		if y == 0 {
+			return 0, false
+		}
+		return x / y, true
+	default:
+		return 0, false
+	}
+}
+
+func (b *derivedBinary) refs(visit func(string)) {
+	b.x.refs(visit)
+	b.y.refs(visit)
+}
+
+type derivedParser struct {
+	src string
+	pos int
+}
+
+func parseDerivedExpr(src string) (derivedExpr, error) {
+	p := &derivedParser{src: src}
+	expr, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if p.skipSpaces(); p.pos < len(p.src) {
+		return nil, p.errorf("unexpected %q", p.src[p.pos:])
+	}
+	return expr, nil
+}
+
+func (p *derivedParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at offset %d", ErrInvalidDerivedMetric, fmt.Sprintf(format, args...), p.pos)
+}
+
+func (p *derivedParser) skipSpaces() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+}
+
+func (p *derivedParser) accept(c byte) bool {
+	if p.skipSpaces(); p.pos < len(p.src) && p.src[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *derivedParser) expect(c byte) error {
+	if !p.accept(c) {
+		return p.errorf("expected %q", c)
+	}
+	return nil
+}
+
+func (p *derivedParser) parseSum() (derivedExpr, error) {
+	x, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op byte
+		switch {
+		case p.accept('+'):
+			op = '+'
+		case p.accept('-'):
+			op = '-'
+		default:
+			return x, nil
+		}
+		y, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		x = &derivedBinary{op: op, x: x, y: y}
+	}
+}
+
+func (p *derivedParser) parseProduct() (derivedExpr, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op byte
+		switch {
+		case p.accept('*'):
+			op = '*'
+		case p.accept('/'):
+			op = '/'
+		default:
+			return x, nil
+		}
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		x = &derivedBinary{op: op, x: x, y: y}
+	}
+}
+
+func (p *derivedParser) parseUnary() (derivedExpr, error) {
+	if p.accept('-') {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &derivedNeg{x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *derivedParser) parsePrimary() (derivedExpr, error) {
+	if p.accept('(') {
+		x, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		return x, p.expect(')')
+	}
+	if p.pos >= len(p.src) {
+		return nil, p.errorf("unexpected end of expression")
+	}
+	c := p.src[p.pos]
+	switch {
+	case c >= '0' && c <= '9' || c == '.':
+		return p.parseNumber()
+	case isDerivedIdentStart(c):
+		id := p.parseIdent()
+		if !p.accept('(') {
+			return derivedRef(id), nil
+		}
+		if id != DerivedFunctionRate {
+			return nil, p.errorf("unknown function %q", id)
+		}
+		return p.parseRate()
+	default:
+		return nil, p.errorf("unexpected %q", c)
+	}
+}
+
+func (p *derivedParser) parseNumber() (derivedExpr, error) {
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		exponentSign := (c == '+' || c == '-') && (p.src[p.pos-1] == 'e' || p.src[p.pos-1] == 'E')
+		if !(c >= '0' && c <= '9' || c == '.' || c == 'e' || c == 'E' || exponentSign) {
+			break
+		}
+		p.pos++
+	}
+	value, err := strconv.ParseFloat(p.src[start:p.pos], 64)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("invalid number")
+	}
+	return derivedNumber(value), nil
+}
+
+func (p *derivedParser) parseIdent() string {
+	start := p.pos
+	for p.pos < len(p.src) && (isDerivedIdentStart(p.src[p.pos]) || p.src[p.pos] >= '0' && p.src[p.pos] <= '9') {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *derivedParser) parseRate() (derivedExpr, error) {
+	if p.skipSpaces(); p.pos >= len(p.src) || !isDerivedIdentStart(p.src[p.pos]) {
+		return nil, p.errorf("rate expects a metric name")
+	}
+	rate := &derivedRate{id: p.parseIdent()}
+	if err := p.expect('['); err != nil {
+		return nil, err
+	}
+	end := strings.IndexByte(p.src[p.pos:], ']')
+	if end < 0 {
+		return nil, p.errorf("expected ']'")
+	}
+	window, err := time.ParseDuration(strings.TrimSpace(p.src[p.pos : p.pos+end]))
+	if err != nil || window <= 0 {
+		return nil, p.errorf("invalid rate window")
+	}
+	rate.window = window
+	p.pos += end + 1
+	return rate, p.expect(')')
+}
+
+func isDerivedIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrInvalidDerivedMetric       = errors.New("invalid derived metric")
+	ErrDerivedMetricCycle         = errors.New("derived metric dependency cycle")
+	ErrUnknownDerivedMetricSource = errors.New("derived metric references unknown metric")
+	ErrDerivedMetricConflict      = errors.New("derived metric name conflicts with a stored metric")
+)
+
+var derivedMetricName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type DerivedMetricRule struct {
+	Name string `json:"name"`
+	Expr string `json:"expr"`
+	expr derivedExpr
+}
+
+func NewDerivedMetricRule(name string, expr string) (*DerivedMetricRule, error) {
+	if name == "" {
+		if left, right, ok := strings.Cut(expr, "="); ok {
+			name, expr = strings.TrimSpace(left), right
+		}
+	}
+	if !derivedMetricName.MatchString(name) {
+		return nil, fmt.Errorf("%w: invalid name %q", ErrInvalidDerivedMetric, name)
+	}
+	parsed, err := parseDerivedExpr(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &DerivedMetricRule{Name: name, Expr: strings.TrimSpace(expr), expr: parsed}, nil
+}
+
+func (r *DerivedMetricRule) Eval(env DerivedEnv) (float64, bool) {
+	return r.expr.eval(env)
+}
+
+func (r *DerivedMetricRule) References() []string {
+	var refs []string
+	seen := make(map[string]bool)
+	r.expr.refs(func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			refs = append(refs, id)
+		}
+	})
+	return refs
+}
+
+func SortDerivedMetricRules(rules []*DerivedMetricRule) ([]*DerivedMetricRule, error) {
+	byName := make(map[string]*DerivedMetricRule, len(rules))
+	for _, rule := range rules {
+		if _, exists := byName[rule.Name]; exists {
+			return nil, fmt.Errorf("%w: duplicate name %q", ErrInvalidDerivedMetric, rule.Name)
+		}
+		byName[rule.Name] = rule
+	}
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(rules))
+	sorted := make([]*DerivedMetricRule, 0, len(rules))
+	var path []string
+	var visit func(rule *DerivedMetricRule) error
+	visit = func(rule *DerivedMetricRule) error {
+		switch state[rule.Name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: %s -> %s", ErrDerivedMetricCycle, strings.Join(path, " -> "), rule.Name)
+		}
+		state[rule.Name] = visiting
+		path = append(path, rule.Name)
+		for _, ref := range rule.References() {
+			if dependency, ok := byName[ref]; ok {
+				if err := visit(dependency); err != nil {
+					return err
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		state[rule.Name] = visited
+		sorted = append(sorted, rule)
+		return nil
+	}
+	for _, rule := range rules {
+		if err := visit(rule); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+func DerivedRate(samples []*MetricSample, counter bool) (float64, bool) {
+	if len(samples) < 2 {
+		return 0, false
+	}
+	var increase float64
+	previous, ok := derivedSampleValue(&samples[0].Metric)
+	if !ok {
+		return 0, false
+	}
+	first := previous
+	for _, sample := range samples[1:] {
+		value, ok := derivedSampleValue(&sample.Metric)
+		if !ok {
+			return 0, false
+		}
+		if counter && value < previous {
+			increase += value
+		} else {
+			increase += value - previous
+		}
+		previous = value
+	}
+	if !counter {
+		increase = previous - first
+	}
+	elapsed := samples[len(samples)-1].Timestamp.Sub(samples[0].Timestamp)
+	if elapsed <= 0 {
+		return 0, false
+	}
+	return increase / elapsed.Seconds(), true
+}
+
+func derivedSampleValue(metric *Metric) (float64, bool) {
+	switch {
+	case metric.Type == Gauge && metric.Value != nil:
+		return *metric.Value, true
+	case metric.Type == Counter && metric.Delta != nil:
+		return float64(*metric.Delta), true
+	default:
+		return 0, false
+	}
+}
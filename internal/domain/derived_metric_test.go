@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type derivedTestEnv struct {
+	values map[string]float64
+	rates  map[string]time.Duration
+}
+
+func (env *derivedTestEnv) Value(id string) (float64, bool) {
+	value, ok := env.values[id]
+	return value, ok
+}
+
+func (env *derivedTestEnv) Rate(id string, window time.Duration) (float64, bool) {
+	if env.rates[id] != window {
+		return 0, false
+	}
+	return env.values[id] / window.Seconds(), true
+}
+
+func TestNewDerivedMetricRule(t *testing.T) {
+	env := &derivedTestEnv{
+		values: map[string]float64{"HeapAlloc": 25, "HeapSys": 100, "TotalAlloc": 600},
+		rates:  map[string]time.Duration{"TotalAlloc": time.Minute},
+	}
+	tests := []struct {
+		name     string
+		ruleName string
+		expr     string
+		expected float64
+		refs     []string
+	}{
+		{name: "division", ruleName: "HeapUtilization", expr: "HeapAlloc / HeapSys", expected: 0.25, refs: []string{"HeapAlloc", "HeapSys"}},
+		{name: "inline name", expr: "HeapFree = HeapSys - HeapAlloc", expected: 75, refs: []string{"HeapSys", "HeapAlloc"}},
+		{name: "rate", ruleName: "AllocRate", expr: "rate(TotalAlloc[1m])", expected: 10, refs: []string{"TotalAlloc"}},
+		{name: "precedence", ruleName: "x", expr: "1 + 2 * 3 - 4 / 2", expected: 5},
+		{name: "parentheses and unary minus", ruleName: "x", expr: "-(1 + 2) * -2", expected: 6},
+		{name: "scientific numbers", ruleName: "x", expr: "HeapAlloc * 1e-1 + .5", expected: 3, refs: []string{"HeapAlloc"}},
+		{name: "repeated reference", ruleName: "x", expr: "HeapAlloc * HeapAlloc", expected: 625, refs: []string{"HeapAlloc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := NewDerivedMetricRule(tt.ruleName, tt.expr)
+			require.NoError(t, err)
+			value, ok := rule.Eval(env)
+			require.True(t, ok)
+			assert.InDelta(t, tt.expected, value, 1e-9)
+			assert.Equal(t, tt.refs, rule.References())
+		})
+	}
+}
+
+func TestNewDerivedMetricRule_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		ruleName string
+		expr     string
+	}{
+		{name: "missing name", expr: "HeapAlloc / HeapSys"},
+		{name: "invalid name", ruleName: "heap.util", expr: "1"},
+		{name: "empty", ruleName: "x", expr: ""},
+		{name: "dangling operator", ruleName: "x", expr: "HeapAlloc /"},
+		{name: "unbalanced parentheses", ruleName: "x", expr: "(HeapAlloc"},
+		{name: "trailing input", ruleName: "x", expr: "HeapAlloc HeapSys"},
+		{name: "unknown function", ruleName: "x", expr: "sum(HeapAlloc)"},
+		{name: "rate without window", ruleName: "x", expr: "rate(TotalAlloc)"},
+		{name: "rate with bad window", ruleName: "x", expr: "rate(TotalAlloc[soon])"},
+		{name: "rate of expression", ruleName: "x", expr: "rate(1[1m])"},
+		{name: "bad number", ruleName: "x", expr: "1.2.3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewDerivedMetricRule(tt.ruleName, tt.expr)
+			assert.ErrorIs(t, err, ErrInvalidDerivedMetric)
+		})
+	}
+}
+
+func TestDerivedMetricRule_EvalUnavailable(t *testing.T) {
+	env := &derivedTestEnv{values: map[string]float64{"a": 1, "zero": 0}}
+	for _, expr := range []string{"a / zero", "a + missing", "rate(a[1m])"} {
+		rule, err := NewDerivedMetricRule("x", expr)
+		require.NoError(t, err)
+		_, ok := rule.Eval(env)
+		assert.False(t, ok, expr)
+	}
+}
+
+func TestSortDerivedMetricRules(t *testing.T) {
+	rule := func(expr string) *DerivedMetricRule {
+		r, err := NewDerivedMetricRule("", expr)
+		require.NoError(t, err)
+		return r
+	}
+	sorted, err := SortDerivedMetricRules([]*DerivedMetricRule{
+		rule("c = b * 2"),
+		rule("b = a + HeapAlloc"),
+		rule("a = HeapSys"),
+	})
+	require.NoError(t, err)
+	names := make([]string, 0, len(sorted))
+	for _, r := range sorted {
+		names = append(names, r.Name)
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, names)
+
+	_, err = SortDerivedMetricRules([]*DerivedMetricRule{rule("a = c + 1"), rule("b = a"), rule("c = b")})
+	require.ErrorIs(t, err, ErrDerivedMetricCycle)
+	assert.Contains(t, err.Error(), "a -> c -> b -> a")
+
+	_, err = SortDerivedMetricRules([]*DerivedMetricRule{rule("a = a + 1")})
+	assert.ErrorIs(t, err, ErrDerivedMetricCycle)
+
+	_, err = SortDerivedMetricRules([]*DerivedMetricRule{rule("a = 1"), rule("a = 2")})
+	assert.ErrorIs(t, err, ErrInvalidDerivedMetric)
+}
+
+func TestDerivedRate(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	counter := func(delta int64, offset time.Duration) *MetricSample {
+		return &MetricSample{Metric: Metric{MetricID: MetricID{ID: "c", Type: Counter}, Delta: &delta}, Timestamp: start.Add(offset)}
+	}
+	gauge := func(value float64, offset time.Duration) *MetricSample {
+		return &MetricSample{Metric: Metric{MetricID: MetricID{ID: "g", Type: Gauge}, Value: &value}, Timestamp: start.Add(offset)}
+	}
+
+	rate, ok := DerivedRate([]*MetricSample{counter(100, 0), counter(160, 30*time.Second), counter(20, time.Minute)}, true)
+	require.True(t, ok)
+	assert.InDelta(t, 80.0/60, rate, 1e-9)
+
+	rate, ok = DerivedRate([]*MetricSample{gauge(10, 0), gauge(40, 10*time.Second), gauge(4, 20*time.Second)}, false)
+	require.True(t, ok)
+	assert.InDelta(t, -0.3, rate, 1e-9)
+
+	_, ok = DerivedRate([]*MetricSample{gauge(1, 0)}, false)
+	assert.False(t, ok)
+	_, ok = DerivedRate([]*MetricSample{gauge(1, 0), gauge(2, 0)}, false)
+	assert.False(t, ok)
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+)
+
+var DefaultHistogramBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+var ErrHistogramBucketsMismatch = errors.New("histogram buckets do not match the stored metric")
+
+type HistogramValue struct {
+	Buckets []float64 `json:"buckets"`
+	Counts  []int64   `json:"counts"`
+	Sum     float64   `json:"sum"`
+	Count   int64     `json:"count"`
+}
+
+func NewHistogram(buckets []float64) *HistogramValue {
+	return &HistogramValue{
+		Buckets: append([]float64(nil), buckets...),
+		Counts:  make([]int64, len(buckets)+1),
+	}
+}
+
+func (h *HistogramValue) Observe(value float64) {
+	i := sort.SearchFloat64s(h.Buckets, value)
+	h.Counts[i]++
+	h.Sum += value
+	h.Count++
+}
+
+func (h *HistogramValue) Merge(other *HistogramValue) error {
+	if len(h.Buckets) != len(other.Buckets) || len(h.Counts) != len(other.Counts) {
+		return ErrHistogramBucketsMismatch
+	}
+	for i := range h.Buckets {
+		if h.Buckets[i] != other.Buckets[i] {
+			return ErrHistogramBucketsMismatch
+		}
+	}
+	for i := range h.Counts {
+		h.Counts[i] += other.Counts[i]
+	}
+	h.Sum += other.Sum
+	h.Count += other.Count
+	return nil
+}
+
+func (h *HistogramValue) Cumulative() []int64 {
+	result := make([]int64, len(h.Counts))
+	var total int64
+	for i, count := range h.Counts {
+		total += count
+		result[i] = total
+	}
+	return result
+}
+
+func (h *HistogramValue) Clone() *HistogramValue {
+	if h == nil {
+		return nil
+	}
+	return &HistogramValue{
+		Buckets: append([]float64(nil), h.Buckets...),
+		Counts:  append([]int64(nil), h.Counts...),
+		Sum:     h.Sum,
+		Count:   h.Count,
+	}
+}
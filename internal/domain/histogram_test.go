@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogramValue_Observe(t *testing.T) {
+	h := NewHistogram([]float64{1, 5, 10})
+	for _, v := range []float64{0.5, 1, 3, 7, 100} {
+		h.Observe(v)
+	}
+	assert.Equal(t, []int64{2, 1, 1, 1}, h.Counts)
+	assert.Equal(t, []int64{2, 3, 4, 5}, h.Cumulative())
+	assert.Equal(t, int64(5), h.Count)
+	assert.Equal(t, 111.5, h.Sum)
+}
+
+func TestHistogramValue_Merge(t *testing.T) {
+	a := NewHistogram([]float64{1, 5})
+	a.Observe(0.5)
+	b := NewHistogram([]float64{1, 5})
+	b.Observe(3)
+	b.Observe(9)
+	require.NoError(t, a.Merge(b))
+	assert.Equal(t, []int64{1, 1, 1}, a.Counts)
+	assert.Equal(t, int64(3), a.Count)
+	assert.Equal(t, 12.5, a.Sum)
+
+	assert.Equal(t, ErrHistogramBucketsMismatch, a.Merge(NewHistogram([]float64{1, 10})))
+	assert.Equal(t, ErrHistogramBucketsMismatch, a.Merge(NewHistogram([]float64{1})))
+}
+
+func TestHistogramValue_Clone(t *testing.T) {
+	h := NewHistogram([]float64{1})
+	h.Observe(2)
+	c := h.Clone()
+	c.Observe(0)
+	assert.Equal(t, []int64{0, 1}, h.Counts)
+	assert.Equal(t, []int64{1, 1}, c.Counts)
+	assert.Nil(t, (*HistogramValue)(nil).Clone())
+}
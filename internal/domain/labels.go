@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+)
+
+type Labels string
+
+var ErrInvalidLabels = errors.New("invalid labels encoding")
+
+func NewLabels(m map[string]string) Labels {
+	if len(m) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for i, key := range keys {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(key)
+		sb.WriteString(`="`)
+		sb.WriteString(escapeLabelValue(m[key]))
+		sb.WriteByte('"')
+	}
+	return Labels(sb.String())
+}
+
+func (l Labels) Map() map[string]string {
+	m, _ := l.parse()
+	return m
+}
+
+func (l Labels) String() string {
+	if l == "" {
+		return ""
+	}
+	return "{" + string(l) + "}"
+}
+
+func (l Labels) MarshalJSON() ([]byte, error) {
+	m, err := l.parse()
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		m = map[string]string{}
+	}
+	return json.Marshal(m)
+}
+
+func (l *Labels) UnmarshalJSON(data []byte) error {
+	var m map[string]string
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*l = NewLabels(m)
+	return nil
+}
+
+func (l Labels) parse() (map[string]string, error) {
+	if l == "" {
+		return nil, nil
+	}
+	m := make(map[string]string)
+	s := string(l)
+	for len(s) > 0 {
+		eq := strings.Index(s, `="`)
+		if eq <= 0 {
+			return nil, ErrInvalidLabels
+		}
+		key := s[:eq]
+		s = s[eq+2:]
+		var value strings.Builder
+		closed := false
+		for i := 0; i < len(s); i++ {
+			c := s[i]
+			if c == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				continue
+			}
+			if c == '"' {
+				s = s[i+1:]
+				closed = true
+				break
+			}
+			value.WriteByte(c)
+		}
+		if !closed {
+			return nil, ErrInvalidLabels
+		}
+		m[key] = value.String()
+		if len(s) > 0 {
+			if s[0] != ',' {
+				return nil, ErrInvalidLabels
+			}
+			s = s[1:]
+		}
+	}
+	return m, nil
+}
+
+func escapeLabelValue(value string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return r.Replace(value)
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLabels(t *testing.T) {
+	assert.Equal(t, Labels(""), NewLabels(nil))
+	assert.Equal(t, Labels(`dc="eu",host="h1"`), NewLabels(map[string]string{"host": "h1", "dc": "eu"}))
+	assert.Equal(t, NewLabels(map[string]string{"a": "1", "b": "2"}), NewLabels(map[string]string{"b": "2", "a": "1"}))
+}
+
+func TestLabels_MapRoundTrip(t *testing.T) {
+	m := map[string]string{"path": `C:\tmp`, "quote": `say "hi"`, "multi": "a\nb", "comma": "x,y=z"}
+	assert.Equal(t, m, NewLabels(m).Map())
+	assert.Nil(t, Labels("").Map())
+}
+
+func TestLabels_String(t *testing.T) {
+	assert.Equal(t, "", Labels("").String())
+	assert.Equal(t, `{host="h1"}`, NewLabels(map[string]string{"host": "h1"}).String())
+}
+
+func TestLabels_JSON(t *testing.T) {
+	metric := Metric{MetricID: MetricID{ID: "CPU", Type: Gauge, Labels: NewLabels(map[string]string{"host": "h1"})}}
+	data, err := json.Marshal(metric)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"CPU","type":"gauge","labels":{"host":"h1"}}`, string(data))
+	var decoded Metric
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, metric.MetricID, decoded.MetricID)
+
+	data, err = json.Marshal(Metric{MetricID: MetricID{ID: "CPU", Type: Gauge}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"CPU","type":"gauge"}`, string(data))
+}
@@ -1,19 +1,46 @@
 package domain
 
+import "time"
+
 type MetricType string
 
 const (
-	Gauge   MetricType = "gauge"
-	Counter MetricType = "counter"
+	Gauge     MetricType = "gauge"
+	Counter   MetricType = "counter"
+	Histogram MetricType = "histogram"
+	Summary   MetricType = "summary"
 )
 
 type MetricID struct {
-	ID   string     `json:"id"`
-	Type MetricType `json:"type"`
+	ID     string     `json:"id"`
+	Type   MetricType `json:"type"`
+	Labels Labels     `json:"labels,omitempty"`
 }
 
 type Metric struct {
 	MetricID
-	Delta *int64   `json:"delta,omitempty"`
-	Value *float64 `json:"value,omitempty"`
+	Delta     *int64          `json:"delta,omitempty"`
+	Value     *float64        `json:"value,omitempty"`
+	Histogram *HistogramValue `json:"histogram,omitempty"`
+	Summary   *SummaryValue   `json:"summary,omitempty"`
+	UpdatedAt time.Time       `json:"updated_at,omitzero"`
+}
+
+func (m *Metric) Clone() *Metric {
+	clone := *m
+	if m.Delta != nil {
+		delta := *m.Delta
+		clone.Delta = &delta
+	}
+	if m.Value != nil {
+		value := *m.Value
+		clone.Value = &value
+	}
+	if m.Histogram != nil {
+		clone.Histogram = m.Histogram.Clone()
+	}
+	if m.Summary != nil {
+		clone.Summary = m.Summary.Clone()
+	}
+	return &clone
 }
@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type LabelMatchOperator string
+
+const (
+	LabelMatchEqual     LabelMatchOperator = "="
+	LabelMatchNotEqual  LabelMatchOperator = "!="
+	LabelMatchRegexp    LabelMatchOperator = "=~"
+	LabelMatchNotRegexp LabelMatchOperator = "!~"
+)
+
+type MetricSortField string
+
+const (
+	MetricSortByID   MetricSortField = "id"
+	MetricSortByType MetricSortField = "type"
+)
+
+var ErrInvalidLabelMatcher = errors.New("invalid label matcher")
+
+var labelMatcherExpr = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)(=~|!~|!=|=)(.*)$`)
+
+type LabelMatcher struct {
+	Name     string
+	Operator LabelMatchOperator
+	Value    string
+	re       *regexp.Regexp
+	posix    string
+}
+
+func NewLabelMatcher(name string, operator LabelMatchOperator, value string) (*LabelMatcher, error) {
+	matcher := &LabelMatcher{Name: name, Operator: operator, Value: value}
+	switch operator {
+	case LabelMatchEqual, LabelMatchNotEqual:
+	case LabelMatchRegexp, LabelMatchNotRegexp:
+		re, err := CompileAnchoredRegexp(value)
+		if err != nil {
+			return nil, ErrInvalidLabelMatcher
+		}
+		matcher.re = re
+		matcher.posix, _ = POSIXRegexp(re.String())
+	default:
+		return nil, ErrInvalidLabelMatcher
+	}
+	return matcher, nil
+}
+
+func ParseLabelMatcher(s string) (*LabelMatcher, error) {
+	match := labelMatcherExpr.FindStringSubmatch(s)
+	if match == nil {
+		return nil, ErrInvalidLabelMatcher
+	}
+	return NewLabelMatcher(match[1], LabelMatchOperator(match[2]), strings.Trim(match[3], `"`))
+}
+
+func (m *LabelMatcher) Matches(labels Labels) bool {
+	value := labels.Map()[m.Name]
+	switch m.Operator {
+	case LabelMatchEqual:
+		return value == m.Value
+	case LabelMatchNotEqual:
+		return value != m.Value
+	case LabelMatchRegexp:
+		return m.re.MatchString(value)
+	case LabelMatchNotRegexp:
+		return !m.re.MatchString(value)
+	default:
+		return false
+	}
+}
+
+func (m *LabelMatcher) EscapedValue() string {
+	return escapeLabelValue(m.Value)
+}
+
+func (m *LabelMatcher) POSIXValue() string {
+	return m.posix
+}
+
+type MetricQuery struct {
+	Type   MetricType
+	Prefix string
+	Regexp *regexp.Regexp
+	Labels []*LabelMatcher
+	SortBy MetricSortField
+	Desc   bool
+	After  *MetricID
+	Limit  int
+}
+
+type MetricPage struct {
+	Metrics []*Metric
+	Next    *MetricID
+}
+
+func (q *MetricQuery) Matches(metric *Metric) bool {
+	if q.Type != "" && metric.Type != q.Type {
+		return false
+	}
+	if !strings.HasPrefix(metric.ID, q.Prefix) {
+		return false
+	}
+	if q.Regexp != nil && !q.Regexp.MatchString(metric.ID) {
+		return false
+	}
+	for _, matcher := range q.Labels {
+		if !matcher.Matches(metric.Labels) {
+			return false
+		}
+	}
+	return true
+}
+
+func (q *MetricQuery) SortKey(id MetricID) [3]string {
+	if q.SortBy == MetricSortByType {
+		return [3]string{string(id.Type), id.ID, string(id.Labels)}
+	}
+	return [3]string{id.ID, string(id.Type), string(id.Labels)}
+}
+
+func (q *MetricQuery) Less(a, b MetricID) bool {
+	ka, kb := q.SortKey(a), q.SortKey(b)
+	for i := range ka {
+		if ka[i] != kb[i] {
+			return (ka[i] < kb[i]) != q.Desc
+		}
+	}
+	return false
+}
+
+func (q *MetricQuery) Page(metrics []*Metric) *MetricPage {
+	matched := make([]*Metric, 0, len(metrics))
+	for _, metric := range metrics {
+		if !q.Matches(metric) {
+			continue
+		}
+		if q.After != nil && !q.Less(*q.After, metric.MetricID) {
+			continue
+		}
+		matched = append(matched, metric)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		return q.Less(matched[i].MetricID, matched[j].MetricID)
+	})
+	return NewMetricPage(matched, q.Limit)
+}
+
+func NewMetricPage(metrics []*Metric, limit int) *MetricPage {
+	page := &MetricPage{Metrics: metrics}
+	if limit > 0 && len(metrics) > limit {
+		page.Metrics = metrics[:limit]
+		next := metrics[limit-1].MetricID
+		page.Next = &next
+	}
+	return page
+}
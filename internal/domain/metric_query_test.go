@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLabelMatcher(t *testing.T) {
+	tests := []struct {
+		input    string
+		name     string
+		operator LabelMatchOperator
+		value    string
+		err      error
+	}{
+		{input: `host=h1`, name: "host", operator: LabelMatchEqual, value: "h1"},
+		{input: `host="h1"`, name: "host", operator: LabelMatchEqual, value: "h1"},
+		{input: `host!=h1`, name: "host", operator: LabelMatchNotEqual, value: "h1"},
+		{input: `host=~h[0-9]`, name: "host", operator: LabelMatchRegexp, value: "h[0-9]"},
+		{input: `host!~h.*`, name: "host", operator: LabelMatchNotRegexp, value: "h.*"},
+		{input: `host=`, name: "host", operator: LabelMatchEqual, value: ""},
+		{input: `1host=h1`, err: ErrInvalidLabelMatcher},
+		{input: `host`, err: ErrInvalidLabelMatcher},
+		{input: `host=~(`, err: ErrInvalidLabelMatcher},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			matcher, err := ParseLabelMatcher(tt.input)
+			assert.Equal(t, tt.err, err)
+			if tt.err != nil {
+				return
+			}
+			assert.Equal(t, tt.name, matcher.Name)
+			assert.Equal(t, tt.operator, matcher.Operator)
+			assert.Equal(t, tt.value, matcher.Value)
+		})
+	}
+}
+
+func TestLabelMatcher_Matches(t *testing.T) {
+	h1 := NewLabels(map[string]string{"host": "h1"})
+	h12 := NewLabels(map[string]string{"host": "h12"})
+	equal, _ := NewLabelMatcher("host", LabelMatchEqual, "h1")
+	notEqual, _ := NewLabelMatcher("host", LabelMatchNotEqual, "h1")
+	re, _ := NewLabelMatcher("host", LabelMatchRegexp, "h[0-9]")
+	notRe, _ := NewLabelMatcher("host", LabelMatchNotRegexp, "h[0-9]")
+	missing, _ := NewLabelMatcher("dc", LabelMatchEqual, "")
+
+	assert.True(t, equal.Matches(h1))
+	assert.False(t, equal.Matches(h12))
+	assert.True(t, notEqual.Matches(h12))
+	assert.True(t, re.Matches(h1))
+	assert.False(t, re.Matches(h12))
+	assert.True(t, notRe.Matches(h12))
+	assert.True(t, missing.Matches(h1))
+}
+
+func queryMetric(id string, metricType MetricType, labels map[string]string) *Metric {
+	return &Metric{MetricID: MetricID{ID: id, Type: metricType, Labels: NewLabels(labels)}}
+}
+
+func TestMetricQuery_Page(t *testing.T) {
+	metrics := []*Metric{
+		queryMetric("HeapAlloc", Gauge, nil),
+		queryMetric("CPU", Gauge, map[string]string{"host": "h2"}),
+		queryMetric("CPU", Gauge, map[string]string{"host": "h1"}),
+		queryMetric("PollCount", Counter, nil),
+		queryMetric("HeapInuse", Gauge, nil),
+	}
+
+	t.Run("filters", func(t *testing.T) {
+		hostH1, err := NewLabelMatcher("host", LabelMatchEqual, "h1")
+		require.NoError(t, err)
+		page := (&MetricQuery{Type: Gauge, Labels: []*LabelMatcher{hostH1}}).Page(metrics)
+		assert.Equal(t, []*Metric{metrics[2]}, page.Metrics)
+		assert.Nil(t, page.Next)
+
+		page = (&MetricQuery{Prefix: "Heap"}).Page(metrics)
+		assert.Equal(t, []*Metric{metrics[0], metrics[4]}, page.Metrics)
+
+		page = (&MetricQuery{Regexp: regexp.MustCompile(AnchorRegexp("Poll.*|CP"))}).Page(metrics)
+		assert.Equal(t, []*Metric{metrics[3]}, page.Metrics)
+	})
+
+	t.Run("sort by type descending", func(t *testing.T) {
+		page := (&MetricQuery{SortBy: MetricSortByType, Desc: true}).Page(metrics)
+		assert.Equal(t, []*Metric{metrics[4], metrics[0], metrics[1], metrics[2], metrics[3]}, page.Metrics)
+	})
+
+	t.Run("cursor pagination", func(t *testing.T) {
+		query := &MetricQuery{SortBy: MetricSortByID, Limit: 2}
+		var ids []MetricID
+		for {
+			page := query.Page(metrics)
+			for _, metric := range page.Metrics {
+				ids = append(ids, metric.MetricID)
+			}
+			if page.Next == nil {
+				break
+			}
+			query.After = page.Next
+		}
+		assert.Equal(t, []MetricID{
+			metrics[2].MetricID, metrics[1].MetricID, metrics[0].MetricID, metrics[4].MetricID, metrics[3].MetricID,
+		}, ids)
+	})
+}
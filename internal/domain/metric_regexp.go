@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+const maxPOSIXRepeat = 255
+
+var ErrUnsupportedRegexp = errors.New("unsupported regular expression")
+
+func AnchorRegexp(expr string) string {
+	return "^(?:" + expr + ")$"
+}
+
+func CompileAnchoredRegexp(expr string) (*regexp.Regexp, error) {
+	anchored := AnchorRegexp(expr)
+	if _, err := POSIXRegexp(anchored); err != nil {
+		return nil, err
+	}
+	re, err := regexp.Compile(anchored)
+	if err != nil {
+		return nil, ErrUnsupportedRegexp
+	}
+	return re, nil
+}
+
+func POSIXRegexp(expr string) (string, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return "", ErrUnsupportedRegexp
+	}
+	var sb strings.Builder
+	if err := writePOSIXRegexp(&sb, re); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+func writePOSIXRegexp(sb *strings.Builder, re *syntax.Regexp) error {
+	switch re.Op {
+	case syntax.OpEmptyMatch:
+		sb.WriteString("(?:)")
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if re.Flags&syntax.FoldCase != 0 && unicode.SimpleFold(r) != r {
+				writePOSIXClass(sb, foldRanges(r))
+				continue
+			}
+			writePOSIXRune(sb, r, false)
+		}
+	case syntax.OpCharClass:
+		writePOSIXClass(sb, re.Rune)
+	case syntax.OpAnyCharNotNL:
+		sb.WriteString(`[^\n]`)
+	case syntax.OpAnyChar:
+		sb.WriteString(".")
+	case syntax.OpBeginText:
+		sb.WriteString("^")
+	case syntax.OpEndText:
+		sb.WriteString("$")
+	case syntax.OpCapture:
+		sb.WriteString("(")
+		if err := writePOSIXRegexp(sb, re.Sub[0]); err != nil {
+			return err
+		}
+		sb.WriteString(")")
+	case syntax.OpStar, syntax.OpPlus, syntax.OpQuest, syntax.OpRepeat:
+		if re.Flags&syntax.NonGreedy != 0 {
+			return ErrUnsupportedRegexp
+		}
+		if err := writePOSIXAtom(sb, re.Sub[0]); err != nil {
+			return err
+		}
+		switch re.Op {
+		case syntax.OpStar:
+			sb.WriteString("*")
+		case syntax.OpPlus:
+			sb.WriteString("+")
+		case syntax.OpQuest:
+			sb.WriteString("?")
+		default:
+			if re.Min > maxPOSIXRepeat || re.Max > maxPOSIXRepeat {
+				return ErrUnsupportedRegexp
+			}
+			switch {
+			case re.Max == -1:
+				fmt.Fprintf(sb, "{%d,}", re.Min)
+			case re.Max == re.Min:
+				fmt.Fprintf(sb, "{%d}", re.Min)
+			default:
+				fmt.Fprintf(sb, "{%d,%d}", re.Min, re.Max)
+			}
+		}
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if err := writePOSIXRegexp(sb, sub); err != nil {
+				return err
+			}
+		}
+	case syntax.OpAlternate:
+		sb.WriteString("(?:")
+		for i, sub := range re.Sub {
+			if i > 0 {
+				sb.WriteString("|")
+			}
+			if err := writePOSIXRegexp(sb, sub); err != nil {
+				return err
+			}
+		}
+		sb.WriteString(")")
+	default:
+		return ErrUnsupportedRegexp
+	}
+	return nil
+}
+
+func writePOSIXAtom(sb *strings.Builder, re *syntax.Regexp) error {
+	single := re.Op == syntax.OpCharClass || re.Op == syntax.OpAnyChar || re.Op == syntax.OpAnyCharNotNL ||
+		re.Op == syntax.OpCapture || (re.Op == syntax.OpLiteral && len(re.Rune) == 1)
+	if single {
+		return writePOSIXRegexp(sb, re)
+	}
+	sb.WriteString("(?:")
+	if err := writePOSIXRegexp(sb, re); err != nil {
+		return err
+	}
+	sb.WriteString(")")
+	return nil
+}
+
+func writePOSIXClass(sb *strings.Builder, ranges []rune) {
+	sb.WriteString("[")
+	for i := 0; i+1 < len(ranges); i += 2 {
+		lo, hi := clampPOSIXRune(ranges[i], 1), clampPOSIXRune(ranges[i+1], -1)
+		if lo > hi {
+			continue
+		}
+		writePOSIXRune(sb, lo, true)
+		if hi > lo {
+			sb.WriteString("-")
+			writePOSIXRune(sb, hi, true)
+		}
+	}
+	sb.WriteString("]")
+}
+
+func clampPOSIXRune(r rune, direction int) rune {
+	switch {
+	case r == 0:
+		return 1
+	case r >= 0xD800 && r <= 0xDFFF && direction > 0:
+		return 0xE000
+	case r >= 0xD800 && r <= 0xDFFF:
+		return 0xD7FF
+	default:
+		return r
+	}
+}
+
+func writePOSIXRune(sb *strings.Builder, r rune, inClass bool) {
+	switch {
+	case !unicode.IsPrint(r):
+		fmt.Fprintf(sb, `\U%08X`, r)
+	case inClass && strings.ContainsRune(`\]-[^`, r):
+		sb.WriteByte('\\')
+		sb.WriteRune(r)
+	case !inClass && strings.ContainsRune(`\^$.[]|()*+?{}`, r):
+		sb.WriteByte('\\')
+		sb.WriteRune(r)
+	default:
+		sb.WriteRune(r)
+	}
+}
+
+func foldRanges(r rune) []rune {
+	var ranges []rune
+	for f := r; ; {
+		ranges = append(ranges, f, f)
+		if f = unicode.SimpleFold(f); f == r {
+			return ranges
+		}
+	}
+}
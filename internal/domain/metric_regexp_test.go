@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPOSIXRegexp(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+		err      error
+	}{
+		{expr: AnchorRegexp("Heap.*"), expected: `^Heap[^\n]*$`},
+		{expr: AnchorRegexp("eu-(west|east)-[0-9]+"), expected: `^eu-((?:west|east))-[0-9]+$`},
+		{expr: AnchorRegexp(`\d{2,4}\.x`), expected: `^[0-9]{2,4}\.x$`},
+		{expr: AnchorRegexp(`a(?:bc)?`), expected: `^a(?:bc)?$`},
+		{expr: AnchorRegexp(`(?i)ab`), expected: `^[Aa][Bb]$`},
+		{expr: AnchorRegexp(`[^a]`), expected: "^[\\U00000001-`b-\\U0010FFFF]$"},
+		{expr: AnchorRegexp(`\bHeap`), err: ErrUnsupportedRegexp},
+		{expr: AnchorRegexp(`a.*?`), err: ErrUnsupportedRegexp},
+		{expr: AnchorRegexp(`a{1,300}`), err: ErrUnsupportedRegexp},
+		{expr: AnchorRegexp(`(?m)^a`), err: ErrUnsupportedRegexp},
+		{expr: "(", err: ErrUnsupportedRegexp},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			pattern, err := POSIXRegexp(tt.expr)
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, pattern)
+		})
+	}
+}
+
+func TestCompileAnchoredRegexp(t *testing.T) {
+	re, err := CompileAnchoredRegexp("Heap.*")
+	require.NoError(t, err)
+	assert.True(t, re.MatchString("HeapAlloc"))
+	assert.False(t, re.MatchString("xHeap"))
+
+	_, err = CompileAnchoredRegexp(`\bHeap`)
+	assert.Equal(t, ErrUnsupportedRegexp, err)
+}
@@ -0,0 +1,8 @@
+package domain
+
+import "time"
+
+type MetricSample struct {
+	Metric
+	Timestamp time.Time `json:"timestamp"`
+}
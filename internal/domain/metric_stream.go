@@ -0,0 +1,70 @@
+package domain
+
+import "sync/atomic"
+
+type MetricEventKind string
+
+const (
+	MetricEventUpdated MetricEventKind = "metric"
+	MetricEventDeleted MetricEventKind = "deleted"
+)
+
+type MetricEvent struct {
+	Kind   MetricEventKind
+	Seq    uint64
+	Metric *Metric
+}
+
+func NewMetricEvents(kind MetricEventKind, seq uint64, metrics []*Metric) []*MetricEvent {
+	events := make([]*MetricEvent, 0, len(metrics))
+	for _, metric := range metrics {
+		events = append(events, &MetricEvent{Kind: kind, Seq: seq, Metric: metric})
+	}
+	return events
+}
+
+type MetricSubscription struct {
+	query   *MetricQuery
+	events  chan *MetricEvent
+	dropped atomic.Uint64
+}
+
+func NewMetricSubscription(query *MetricQuery, buffer int) *MetricSubscription {
+	if buffer < 1 {
+		buffer = 1
+	}
+	return &MetricSubscription{
+		query:  query,
+		events: make(chan *MetricEvent, buffer),
+	}
+}
+
+func (sub *MetricSubscription) Events() <-chan *MetricEvent {
+	return sub.events
+}
+
+func (sub *MetricSubscription) TakeDropped() uint64 {
+	return sub.dropped.Swap(0)
+}
+
+func (sub *MetricSubscription) Finish() {
+	close(sub.events)
+}
+
+func (sub *MetricSubscription) Offer(event *MetricEvent) {
+	if !sub.query.Matches(event.Metric) {
+		return
+	}
+	for {
+		select {
+		case sub.events <- event:
+			return
+		default:
+		}
+		select {
+		case <-sub.events:
+			sub.dropped.Add(1)
+		default:
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"math"
+	"sort"
+)
+
+const SummaryMaxObservations = 1024
+
+var DefaultSummaryQuantiles = []float64{0.5, 0.9, 0.99}
+
+type SummaryValue struct {
+	Quantiles    []float64 `json:"quantiles,omitempty"`
+	Values       []float64 `json:"values,omitempty"`
+	Observations []float64 `json:"observations,omitempty"`
+	Sum          float64   `json:"sum"`
+	Count        int64     `json:"count"`
+}
+
+func NewSummary(quantiles []float64) *SummaryValue {
+	return &SummaryValue{Quantiles: append([]float64(nil), quantiles...)}
+}
+
+func (s *SummaryValue) Observe(value float64) {
+	s.Observations = append(s.Observations, value)
+	s.trim()
+	s.Sum += value
+	s.Count++
+}
+
+func (s *SummaryValue) Merge(other *SummaryValue) {
+	if len(other.Quantiles) > 0 {
+		s.Quantiles = append([]float64(nil), other.Quantiles...)
+	}
+	s.Observations = append(s.Observations, other.Observations...)
+	s.trim()
+	if other.Count == 0 {
+		for _, value := range other.Observations {
+			s.Sum += value
+			s.Count++
+		}
+		return
+	}
+	s.Sum += other.Sum
+	s.Count += other.Count
+}
+
+func (s *SummaryValue) Quantile(q float64) float64 {
+	if len(s.Observations) == 0 {
+		return math.NaN()
+	}
+	sorted := append([]float64(nil), s.Observations...)
+	sort.Float64s(sorted)
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	if lower == upper {
+		return sorted[lower]
+	}
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+}
+
+func (s *SummaryValue) QuantileValues() []float64 {
+	quantiles := s.Quantiles
+	if len(quantiles) == 0 {
+		quantiles = DefaultSummaryQuantiles
+	}
+	values := make([]float64, len(quantiles))
+	for i, q := range quantiles {
+		values[i] = s.Quantile(q)
+	}
+	return values
+}
+
+func (s *SummaryValue) Clone() *SummaryValue {
+	if s == nil {
+		return nil
+	}
+	return &SummaryValue{
+		Quantiles:    append([]float64(nil), s.Quantiles...),
+		Values:       append([]float64(nil), s.Values...),
+		Observations: append([]float64(nil), s.Observations...),
+		Sum:          s.Sum,
+		Count:        s.Count,
+	}
+}
+
+func (s *SummaryValue) Report() *SummaryValue {
+	quantiles := s.Quantiles
+	if len(quantiles) == 0 {
+		quantiles = DefaultSummaryQuantiles
+	}
+	values := s.Values
+	if len(s.Observations) > 0 || len(values) != len(quantiles) {
+		values = s.QuantileValues()
+	}
+	return &SummaryValue{
+		Quantiles: append([]float64(nil), quantiles...),
+		Values:    append([]float64(nil), values...),
+		Sum:       s.Sum,
+		Count:     s.Count,
+	}
+}
+
+func (s *SummaryValue) Sketch() *SummaryValue {
+	if s == nil {
+		return nil
+	}
+	return s.Report()
+}
+
+func (s *SummaryValue) trim() {
+	if len(s.Observations) > SummaryMaxObservations {
+		s.Observations = append([]float64(nil), s.Observations[len(s.Observations)-SummaryMaxObservations:]...)
+	}
+}
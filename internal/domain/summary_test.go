@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummaryValue_Quantile(t *testing.T) {
+	s := NewSummary([]float64{0, 0.5, 1})
+	for _, v := range []float64{4, 1, 3, 2, 5} {
+		s.Observe(v)
+	}
+	assert.Equal(t, 1.0, s.Quantile(0))
+	assert.Equal(t, 3.0, s.Quantile(0.5))
+	assert.Equal(t, 5.0, s.Quantile(1))
+	assert.Equal(t, 1.4, math.Round(s.Quantile(0.1)*10)/10)
+	assert.Equal(t, []float64{1, 3, 5}, s.QuantileValues())
+	assert.True(t, math.IsNaN(NewSummary(nil).Quantile(0.5)))
+}
+
+func TestSummaryValue_Merge(t *testing.T) {
+	s := NewSummary(nil)
+	s.Observe(1)
+	s.Merge(&SummaryValue{Observations: []float64{2, 3}})
+	assert.Equal(t, int64(3), s.Count)
+	assert.Equal(t, 6.0, s.Sum)
+	s.Merge(&SummaryValue{Quantiles: []float64{0.5}, Observations: []float64{4}, Count: 10, Sum: 40})
+	assert.Equal(t, int64(13), s.Count)
+	assert.Equal(t, 46.0, s.Sum)
+	assert.Equal(t, []float64{0.5}, s.Quantiles)
+}
+
+func TestSummaryValue_BoundedObservations(t *testing.T) {
+	s := NewSummary(nil)
+	for i := 0; i < SummaryMaxObservations+10; i++ {
+		s.Observe(float64(i))
+	}
+	assert.Len(t, s.Observations, SummaryMaxObservations)
+	assert.Equal(t, 10.0, s.Observations[0])
+	assert.Equal(t, int64(SummaryMaxObservations+10), s.Count)
+}
+
+func TestSummaryValue_Report(t *testing.T) {
+	s := NewSummary(nil)
+	s.Observe(2)
+	report := s.Report()
+	assert.Equal(t, DefaultSummaryQuantiles, report.Quantiles)
+	assert.Equal(t, []float64{2, 2, 2}, report.Values)
+	assert.Nil(t, report.Observations)
+	assert.Equal(t, int64(1), report.Count)
+}
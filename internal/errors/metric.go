@@ -3,37 +3,122 @@ package errors
 import (
 	"errors"
 	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var (
-	ErrInvalidMetricID           = errors.New("invalid id: only letters and numbers are allowed")
-	ErrInvalidMetricType         = errors.New("invalid Type: must be 'gauge' or 'counter'")
-	ErrEmptyMetricValue          = errors.New("invalid metric value: cannot be empty")
-	ErrInvalidCounterMetricValue = errors.New("invalid 'counter' metric value: must be int64")
-	ErrInvalidGaugeMetricValue   = errors.New("invalid 'gauge' metric value: must be float64")
-	ErrMetricNotFound            = errors.New("metric not found")
-	ErrMetricGetByIDInternal     = errors.New("internal error")
-	ErrMetricListInternal        = errors.New("internal error")
-	ErrMetricIsNotUpdated        = errors.New("metric is not updated")
+	ErrInvalidMetricID             = errors.New("invalid id: only letters and numbers are allowed")
+	ErrInvalidMetricType           = errors.New("invalid Type: must be 'gauge', 'counter', 'histogram' or 'summary'")
+	ErrInvalidMetricLabels         = errors.New("invalid labels: names must start with a letter or underscore and contain only letters, numbers and underscores")
+	ErrEmptyMetricValue            = errors.New("invalid metric value: cannot be empty")
+	ErrInvalidCounterMetricValue   = errors.New("invalid 'counter' metric value: must be int64")
+	ErrInvalidGaugeMetricValue     = errors.New("invalid 'gauge' metric value: must be float64")
+	ErrInvalidHistogramMetricValue = errors.New("invalid 'histogram' metric value: buckets must be increasing and counts must cover every bucket plus +Inf")
+	ErrInvalidSummaryMetricValue   = errors.New("invalid 'summary' metric value: quantiles must be within [0, 1]")
+	ErrHistogramBucketsMismatch    = errors.New("invalid 'histogram' metric value: buckets do not match the stored metric")
+	ErrMetricNotFound              = errors.New("metric not found")
+	ErrMetricGetByIDInternal       = errors.New("internal error")
+	ErrMetricListInternal          = errors.New("internal error")
+	ErrMetricIsNotUpdated          = errors.New("metric is not updated")
+	ErrInvalidTimeRange            = errors.New("invalid time range: 'from' and 'to' must be unix seconds or RFC3339 and 'from' must not be after 'to'")
+	ErrMetricHistoryInternal       = errors.New("internal error")
+	ErrAlertListInternal           = errors.New("internal error")
+	ErrInvalidMetricQuery          = errors.New("invalid query: check type, regex, label matchers, sort and limit")
+	ErrInvalidMetricCursor         = errors.New("invalid cursor")
+	ErrMetricQueryInternal         = errors.New("internal error")
+	ErrMetricIsNotDeleted          = errors.New("metric is not deleted")
+	ErrMetricResetNotSupported     = errors.New("invalid reset: only 'counter' metrics can be reset")
+	ErrInvalidLineProtocol         = errors.New("invalid line protocol: expected 'measurement[,tag=value] field=value[,field=value] [timestamp]'")
+	ErrInvalidRemoteWrite          = errors.New("invalid remote write request: expected snappy-compressed protobuf WriteRequest")
 )
 
+var metricErrors = []error{
+	ErrInvalidMetricID,
+	ErrInvalidMetricType,
+	ErrInvalidMetricLabels,
+	ErrEmptyMetricValue,
+	ErrInvalidCounterMetricValue,
+	ErrInvalidGaugeMetricValue,
+	ErrInvalidHistogramMetricValue,
+	ErrInvalidSummaryMetricValue,
+	ErrHistogramBucketsMismatch,
+	ErrMetricNotFound,
+	ErrMetricGetByIDInternal,
+	ErrMetricListInternal,
+	ErrMetricIsNotUpdated,
+	ErrInvalidTimeRange,
+	ErrMetricHistoryInternal,
+	ErrAlertListInternal,
+	ErrInvalidMetricQuery,
+	ErrInvalidMetricCursor,
+	ErrMetricQueryInternal,
+	ErrMetricIsNotDeleted,
+	ErrMetricResetNotSupported,
+	ErrInvalidLineProtocol,
+	ErrInvalidRemoteWrite,
+}
+
+func unwrapMetricError(err error) error {
+	for _, target := range metricErrors {
+		if errors.Is(err, target) {
+			return target
+		}
+	}
+	return err
+}
+
 func MakeMetricErrorResponse(w http.ResponseWriter, err error) {
-	switch err {
+	switch err = unwrapMetricError(err); err {
 	case ErrInvalidMetricID:
 		http.Error(w, err.Error(), http.StatusBadRequest)
 	case ErrInvalidMetricType:
 		http.Error(w, err.Error(), http.StatusBadRequest)
+	case ErrInvalidMetricLabels:
+		http.Error(w, err.Error(), http.StatusBadRequest)
 	case ErrEmptyMetricValue:
 		http.Error(w, err.Error(), http.StatusBadRequest)
 	case ErrInvalidCounterMetricValue:
 		http.Error(w, err.Error(), http.StatusBadRequest)
 	case ErrInvalidGaugeMetricValue:
 		http.Error(w, err.Error(), http.StatusBadRequest)
+	case ErrInvalidHistogramMetricValue, ErrInvalidSummaryMetricValue, ErrHistogramBucketsMismatch:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case ErrInvalidTimeRange:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case ErrInvalidMetricQuery, ErrInvalidMetricCursor:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case ErrMetricResetNotSupported:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case ErrInvalidLineProtocol:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case ErrInvalidRemoteWrite:
+		http.Error(w, err.Error(), http.StatusBadRequest)
 	case ErrMetricNotFound:
 		http.Error(w, err.Error(), http.StatusNotFound)
-	case ErrMetricGetByIDInternal, ErrMetricListInternal, ErrMetricIsNotUpdated:
+	case ErrMetricGetByIDInternal, ErrMetricListInternal, ErrMetricIsNotUpdated, ErrMetricHistoryInternal, ErrAlertListInternal,
+		ErrMetricQueryInternal, ErrMetricIsNotDeleted:
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 	default:
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 	}
 }
+
+func MakeMetricErrorStatus(err error) error {
+	switch err = unwrapMetricError(err); err {
+	case ErrInvalidMetricID, ErrInvalidMetricType, ErrInvalidMetricLabels, ErrEmptyMetricValue,
+		ErrInvalidCounterMetricValue, ErrInvalidGaugeMetricValue, ErrInvalidHistogramMetricValue,
+		ErrInvalidSummaryMetricValue, ErrHistogramBucketsMismatch, ErrInvalidTimeRange,
+		ErrInvalidMetricQuery, ErrInvalidMetricCursor, ErrMetricResetNotSupported, ErrInvalidLineProtocol,
+		ErrInvalidRemoteWrite:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case ErrMetricNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case ErrMetricGetByIDInternal, ErrMetricListInternal, ErrMetricIsNotUpdated, ErrMetricHistoryInternal, ErrAlertListInternal,
+		ErrMetricQueryInternal, ErrMetricIsNotDeleted:
+		return status.Error(codes.Internal, err.Error())
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
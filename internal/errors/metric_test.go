@@ -2,12 +2,15 @@ package errors
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestMakeMetricErrorResponse(t *testing.T) {
@@ -27,7 +30,13 @@ func TestMakeMetricErrorResponse(t *testing.T) {
 			name:       "ErrInvalidMetricType",
 			err:        ErrInvalidMetricType,
 			statusCode: http.StatusBadRequest,
-			expected:   "invalid Type: must be 'gauge' or 'counter'",
+			expected:   "invalid Type: must be 'gauge', 'counter', 'histogram' or 'summary'",
+		},
+		{
+			name:       "ErrInvalidMetricLabels",
+			err:        ErrInvalidMetricLabels,
+			statusCode: http.StatusBadRequest,
+			expected:   ErrInvalidMetricLabels.Error(),
 		},
 		{
 			name:       "ErrEmptyMetricValue",
@@ -47,6 +56,24 @@ func TestMakeMetricErrorResponse(t *testing.T) {
 			statusCode: http.StatusBadRequest,
 			expected:   "invalid 'gauge' metric value: must be float64",
 		},
+		{
+			name:       "ErrInvalidHistogramMetricValue",
+			err:        ErrInvalidHistogramMetricValue,
+			statusCode: http.StatusBadRequest,
+			expected:   ErrInvalidHistogramMetricValue.Error(),
+		},
+		{
+			name:       "ErrInvalidSummaryMetricValue",
+			err:        ErrInvalidSummaryMetricValue,
+			statusCode: http.StatusBadRequest,
+			expected:   ErrInvalidSummaryMetricValue.Error(),
+		},
+		{
+			name:       "ErrHistogramBucketsMismatch",
+			err:        ErrHistogramBucketsMismatch,
+			statusCode: http.StatusBadRequest,
+			expected:   ErrHistogramBucketsMismatch.Error(),
+		},
 		{
 			name:       "ErrMetricNotFound",
 			err:        ErrMetricNotFound,
@@ -59,6 +86,42 @@ func TestMakeMetricErrorResponse(t *testing.T) {
 			statusCode: http.StatusInternalServerError,
 			expected:   "internal error",
 		},
+		{
+			name:       "ErrMetricResetNotSupported",
+			err:        ErrMetricResetNotSupported,
+			statusCode: http.StatusBadRequest,
+			expected:   ErrMetricResetNotSupported.Error(),
+		},
+		{
+			name:       "ErrInvalidLineProtocol",
+			err:        ErrInvalidLineProtocol,
+			statusCode: http.StatusBadRequest,
+			expected:   ErrInvalidLineProtocol.Error(),
+		},
+		{
+			name:       "ErrInvalidRemoteWrite",
+			err:        ErrInvalidRemoteWrite,
+			statusCode: http.StatusBadRequest,
+			expected:   ErrInvalidRemoteWrite.Error(),
+		},
+		{
+			name:       "ErrMetricIsNotDeleted",
+			err:        ErrMetricIsNotDeleted,
+			statusCode: http.StatusInternalServerError,
+			expected:   "metric is not deleted",
+		},
+		{
+			name:       "Wrapped ErrMetricNotFound",
+			err:        fmt.Errorf("operation failed: %w", ErrMetricNotFound),
+			statusCode: http.StatusNotFound,
+			expected:   "metric not found",
+		},
+		{
+			name:       "Wrapped ErrHistogramBucketsMismatch",
+			err:        fmt.Errorf("operation failed: %w", ErrHistogramBucketsMismatch),
+			statusCode: http.StatusBadRequest,
+			expected:   ErrHistogramBucketsMismatch.Error(),
+		},
 		{
 			name:       "Unknown error",
 			err:        errors.New("some unknown error"),
@@ -76,3 +139,29 @@ func TestMakeMetricErrorResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeMetricErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code codes.Code
+	}{
+		{name: "invalid id", err: ErrInvalidMetricID, code: codes.InvalidArgument},
+		{name: "invalid histogram", err: ErrHistogramBucketsMismatch, code: codes.InvalidArgument},
+		{name: "not found", err: ErrMetricNotFound, code: codes.NotFound},
+		{name: "not updated", err: ErrMetricIsNotUpdated, code: codes.Internal},
+		{name: "reset not supported", err: ErrMetricResetNotSupported, code: codes.InvalidArgument},
+		{name: "invalid line protocol", err: ErrInvalidLineProtocol, code: codes.InvalidArgument},
+		{name: "invalid remote write", err: ErrInvalidRemoteWrite, code: codes.InvalidArgument},
+		{name: "not deleted", err: ErrMetricIsNotDeleted, code: codes.Internal},
+		{name: "wrapped not found", err: fmt.Errorf("operation failed: %w", ErrMetricNotFound), code: codes.NotFound},
+		{name: "unknown", err: errors.New("boom"), code: codes.Internal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st, ok := status.FromError(MakeMetricErrorStatus(tt.err))
+			assert.True(t, ok)
+			assert.Equal(t, tt.code, st.Code())
+		})
+	}
+}
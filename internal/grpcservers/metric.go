@@ -0,0 +1,99 @@
+package grpcservers
+
+import (
+	"context"
+	"go-metrics/internal/converters"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/proto"
+	"go-metrics/internal/usecases"
+)
+
+type MetricUpdateService interface {
+	Update(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error)
+}
+
+type MetricGetByIDService interface {
+	GetByID(ctx context.Context, id *domain.MetricID) (*domain.Metric, error)
+}
+
+type MetricListService interface {
+	List(ctx context.Context) ([]*domain.Metric, error)
+}
+
+type MetricServer struct {
+	proto.UnimplementedMetricsServer
+	u MetricUpdateService
+	g MetricGetByIDService
+	l MetricListService
+}
+
+func NewMetricServer(
+	u MetricUpdateService,
+	g MetricGetByIDService,
+	l MetricListService,
+) *MetricServer {
+	return &MetricServer{
+		u: u,
+		g: g,
+		l: l,
+	}
+}
+
+func (s *MetricServer) UpdateBatch(
+	ctx context.Context, req *proto.UpdateBatchRequest,
+) (*proto.UpdateBatchResponse, error) {
+	metrics := make([]*domain.Metric, 0, len(req.GetMetrics()))
+	for _, m := range req.GetMetrics() {
+		r := &usecases.MetricUpdateBodyRequest{
+			ID:        m.GetId(),
+			Type:      m.GetType(),
+			Labels:    m.GetLabels(),
+			Delta:     m.Delta,
+			Value:     m.Value,
+			Histogram: converters.ConvertProtoToHistogram(m.GetHistogram()),
+			Summary:   converters.ConvertProtoToSummary(m.GetSummary()),
+		}
+		if err := usecases.ValidateMetricUpdateBodyRequest(r); err != nil {
+			return nil, errors.MakeMetricErrorStatus(err)
+		}
+		metrics = append(metrics, usecases.ConvertMetricUpdateBodyRequestToDomain(r))
+	}
+	updated, err := s.u.Update(ctx, metrics)
+	if err != nil {
+		return nil, errors.MakeMetricErrorStatus(err)
+	}
+	return &proto.UpdateBatchResponse{Metrics: convertMetricsToProto(updated)}, nil
+}
+
+func (s *MetricServer) Get(ctx context.Context, req *proto.GetRequest) (*proto.GetResponse, error) {
+	r := &usecases.MetricGetByIDBodyRequest{
+		ID:     req.GetId(),
+		Type:   req.GetType(),
+		Labels: req.GetLabels(),
+	}
+	if err := usecases.ValidateMetricGetByIDBodyRequest(r); err != nil {
+		return nil, errors.MakeMetricErrorStatus(err)
+	}
+	metric, err := s.g.GetByID(ctx, usecases.ConvertMetricGetByIDBodyRequestToDomain(r))
+	if err != nil {
+		return nil, errors.MakeMetricErrorStatus(err)
+	}
+	return &proto.GetResponse{Metric: converters.ConvertMetricToProto(metric)}, nil
+}
+
+func (s *MetricServer) List(ctx context.Context, req *proto.ListRequest) (*proto.ListResponse, error) {
+	metrics, err := s.l.List(ctx)
+	if err != nil {
+		return nil, errors.MakeMetricErrorStatus(err)
+	}
+	return &proto.ListResponse{Metrics: convertMetricsToProto(metrics)}, nil
+}
+
+func convertMetricsToProto(metrics []*domain.Metric) []*proto.Metric {
+	result := make([]*proto.Metric, 0, len(metrics))
+	for _, metric := range metrics {
+		result = append(result, converters.ConvertMetricToProto(metric))
+	}
+	return result
+}
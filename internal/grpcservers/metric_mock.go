@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/grpcservers/metric.go
+
+// Package grpcservers is a generated GoMock package.
+package grpcservers
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricUpdateService is a mock of MetricUpdateService interface.
+type MockMetricUpdateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricUpdateServiceMockRecorder
+}
+
+// MockMetricUpdateServiceMockRecorder is the mock recorder for MockMetricUpdateService.
+type MockMetricUpdateServiceMockRecorder struct {
+	mock *MockMetricUpdateService
+}
+
+// NewMockMetricUpdateService creates a new mock instance.
+func NewMockMetricUpdateService(ctrl *gomock.Controller) *MockMetricUpdateService {
+	mock := &MockMetricUpdateService{ctrl: ctrl}
+	mock.recorder = &MockMetricUpdateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricUpdateService) EXPECT() *MockMetricUpdateServiceMockRecorder {
+	return m.recorder
+}
+
+// Update mocks base method.
+func (m *MockMetricUpdateService) Update(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, metrics)
+	ret0, _ := ret[0].([]*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMetricUpdateServiceMockRecorder) Update(ctx, metrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMetricUpdateService)(nil).Update), ctx, metrics)
+}
+
+// MockMetricGetByIDService is a mock of MetricGetByIDService interface.
+type MockMetricGetByIDService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricGetByIDServiceMockRecorder
+}
+
+// MockMetricGetByIDServiceMockRecorder is the mock recorder for MockMetricGetByIDService.
+type MockMetricGetByIDServiceMockRecorder struct {
+	mock *MockMetricGetByIDService
+}
+
+// NewMockMetricGetByIDService creates a new mock instance.
+func NewMockMetricGetByIDService(ctrl *gomock.Controller) *MockMetricGetByIDService {
+	mock := &MockMetricGetByIDService{ctrl: ctrl}
+	mock.recorder = &MockMetricGetByIDServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricGetByIDService) EXPECT() *MockMetricGetByIDServiceMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockMetricGetByIDService) GetByID(ctx context.Context, id *domain.MetricID) (*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockMetricGetByIDServiceMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMetricGetByIDService)(nil).GetByID), ctx, id)
+}
+
+// MockMetricListService is a mock of MetricListService interface.
+type MockMetricListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricListServiceMockRecorder
+}
+
+// MockMetricListServiceMockRecorder is the mock recorder for MockMetricListService.
+type MockMetricListServiceMockRecorder struct {
+	mock *MockMetricListService
+}
+
+// NewMockMetricListService creates a new mock instance.
+func NewMockMetricListService(ctrl *gomock.Controller) *MockMetricListService {
+	mock := &MockMetricListService{ctrl: ctrl}
+	mock.recorder = &MockMetricListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricListService) EXPECT() *MockMetricListServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockMetricListService) List(ctx context.Context) ([]*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMetricListServiceMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMetricListService)(nil).List), ctx)
+}
@@ -0,0 +1,135 @@
+package grpcservers
+
+import (
+	"context"
+	e "errors"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/proto"
+	"net"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type metricServerMocks struct {
+	update *MockMetricUpdateService
+	get    *MockMetricGetByIDService
+	list   *MockMetricListService
+}
+
+func newMetricClient(t *testing.T, ctrl *gomock.Controller) (proto.MetricsClient, *metricServerMocks) {
+	mocks := &metricServerMocks{
+		update: NewMockMetricUpdateService(ctrl),
+		get:    NewMockMetricGetByIDService(ctrl),
+		list:   NewMockMetricListService(ctrl),
+	}
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	proto.RegisterMetricsServer(server, NewMetricServer(mocks.update, mocks.get, mocks.list))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return proto.NewMetricsClient(conn), mocks
+}
+
+func TestMetricServer_UpdateBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client, mocks := newMetricClient(t, ctrl)
+	delta := int64(3)
+	value := 1.5
+	expected := []*domain.Metric{
+		{MetricID: domain.MetricID{ID: "PollCount", Type: domain.Counter, Labels: domain.NewLabels(map[string]string{"host": "a"})}, Delta: &delta},
+		{MetricID: domain.MetricID{ID: "Alloc", Type: domain.Gauge}, Value: &value},
+	}
+	mocks.update.EXPECT().Update(gomock.Any(), expected).Return(expected, nil)
+
+	resp, err := client.UpdateBatch(context.Background(), &proto.UpdateBatchRequest{Metrics: []*proto.Metric{
+		{Id: "PollCount", Type: "counter", Labels: map[string]string{"host": "a"}, Delta: &delta},
+		{Id: "Alloc", Type: "gauge", Value: &value},
+	}})
+	require.NoError(t, err)
+	require.Len(t, resp.GetMetrics(), 2)
+	assert.Equal(t, int64(3), resp.GetMetrics()[0].GetDelta())
+	assert.Equal(t, map[string]string{"host": "a"}, resp.GetMetrics()[0].GetLabels())
+	assert.Equal(t, 1.5, resp.GetMetrics()[1].GetValue())
+}
+
+func TestMetricServer_UpdateBatch_InvalidMetric(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client, _ := newMetricClient(t, ctrl)
+
+	_, err := client.UpdateBatch(context.Background(), &proto.UpdateBatchRequest{Metrics: []*proto.Metric{
+		{Id: "PollCount", Type: "counter"},
+	}})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, errors.ErrInvalidCounterMetricValue.Error(), st.Message())
+}
+
+func TestMetricServer_Get(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client, mocks := newMetricClient(t, ctrl)
+	value := 42.0
+	id := &domain.MetricID{ID: "Alloc", Type: domain.Gauge}
+	mocks.get.EXPECT().GetByID(gomock.Any(), id).Return(&domain.Metric{MetricID: *id, Value: &value}, nil)
+
+	resp, err := client.Get(context.Background(), &proto.GetRequest{Id: "Alloc", Type: "gauge"})
+	require.NoError(t, err)
+	assert.Equal(t, 42.0, resp.GetMetric().GetValue())
+}
+
+func TestMetricServer_Get_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client, mocks := newMetricClient(t, ctrl)
+	mocks.get.EXPECT().GetByID(gomock.Any(), gomock.Any()).Return(nil, errors.ErrMetricNotFound)
+
+	_, err := client.Get(context.Background(), &proto.GetRequest{Id: "Alloc", Type: "gauge"})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+}
+
+func TestMetricServer_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client, mocks := newMetricClient(t, ctrl)
+	value := 1.0
+	mocks.list.EXPECT().List(gomock.Any()).Return([]*domain.Metric{
+		{MetricID: domain.MetricID{ID: "Alloc", Type: domain.Gauge}, Value: &value},
+	}, nil)
+
+	resp, err := client.List(context.Background(), &proto.ListRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetMetrics(), 1)
+	assert.Equal(t, "Alloc", resp.GetMetrics()[0].GetId())
+}
+
+func TestMetricServer_List_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	client, mocks := newMetricClient(t, ctrl)
+	mocks.list.EXPECT().List(gomock.Any()).Return(nil, e.New("list error"))
+
+	_, err := client.List(context.Background(), &proto.ListRequest{})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.Internal, st.Code())
+}
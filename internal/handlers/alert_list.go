@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/usecases"
+	"net/http"
+)
+
+type AlertListUsecase interface {
+	Execute(ctx context.Context) (*usecases.AlertListResponse, error)
+}
+
+func AlertListHandler(uc AlertListUsecase) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := uc.Execute(r.Context())
+		if err != nil {
+			errors.MakeMetricErrorResponse(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			errors.MakeMetricErrorResponse(w, err)
+			return
+		}
+	}
+}
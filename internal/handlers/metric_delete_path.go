@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"context"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/usecases"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type MetricDeletePathUsecase interface {
+	Execute(ctx context.Context, req *usecases.MetricDeletePathRequest) (*usecases.MetricDeletePathResponse, error)
+}
+
+func MetricDeletePathHandler(uc MetricDeletePathUsecase) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req usecases.MetricDeletePathRequest
+		req.Type = chi.URLParam(r, "type")
+		req.Name = chi.URLParam(r, "name")
+		req.Labels = r.URL.Query()["label"]
+		resp, err := uc.Execute(r.Context(), &req)
+		if err != nil {
+			errors.MakeMetricErrorResponse(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(string(*resp)))
+	}
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/usecases"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type MetricHistoryPathUsecase interface {
+	Execute(ctx context.Context, req *usecases.MetricHistoryPathRequest) (*usecases.MetricHistoryPathResponse, error)
+}
+
+func MetricHistoryPathHandler(uc MetricHistoryPathUsecase) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req usecases.MetricHistoryPathRequest
+		req.Type = chi.URLParam(r, "type")
+		req.Name = chi.URLParam(r, "name")
+		query := r.URL.Query()
+		req.From = query.Get("from")
+		req.To = query.Get("to")
+		req.Labels = query["label"]
+		resp, err := uc.Execute(r.Context(), &req)
+		if err != nil {
+			errors.MakeMetricErrorResponse(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			errors.MakeMetricErrorResponse(w, err)
+			return
+		}
+	}
+}
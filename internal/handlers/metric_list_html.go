@@ -8,17 +8,23 @@ import (
 )
 
 type MetricListHTMLUsecase interface {
-	Execute(ctx context.Context) (*usecases.MetricListHTMLResponse, error)
+	Execute(ctx context.Context, req *usecases.MetricListHTMLRequest) (*usecases.MetricListHTMLResponse, error)
 }
 
 func MetricListHTMLHandler(uc MetricListHTMLUsecase) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		resp, err := uc.Execute(r.Context())
+		query := r.URL.Query()
+		req := usecases.MetricListHTMLRequest{
+			Type:    query.Get("type"),
+			Name:    query.Get("name"),
+			Refresh: query.Get("refresh"),
+		}
+		resp, err := uc.Execute(r.Context(), &req)
 		if err != nil {
 			errors.MakeMetricErrorResponse(w, err)
 			return
 		}
-		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 		w.WriteHeader(http.StatusOK)
 		_, err = w.Write([]byte(resp.HTML))
 		if err != nil {
@@ -0,0 +1,25 @@
+package handlers
+
+import (
+	"context"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/usecases"
+	"net/http"
+)
+
+type MetricListPrometheusUsecase interface {
+	Execute(ctx context.Context) (*usecases.MetricListPrometheusResponse, error)
+}
+
+func MetricListPrometheusHandler(uc MetricListPrometheusUsecase) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		resp, err := uc.Execute(r.Context())
+		if err != nil {
+			errors.MakeMetricErrorResponse(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", usecases.PrometheusContentType)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(resp.Text))
+	}
+}
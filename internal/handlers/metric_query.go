@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/usecases"
+	"net/http"
+)
+
+type MetricQueryUsecase interface {
+	Execute(ctx context.Context, req *usecases.MetricQueryRequest) (*usecases.MetricQueryResponse, error)
+}
+
+func MetricQueryHandler(uc MetricQueryUsecase) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		req := usecases.MetricQueryRequest{
+			Type:   query.Get("type"),
+			Prefix: query.Get("prefix"),
+			Regex:  query.Get("regex"),
+			Labels: query["label"],
+			Sort:   query.Get("sort"),
+			Limit:  query.Get("limit"),
+			Cursor: query.Get("cursor"),
+		}
+		resp, err := uc.Execute(r.Context(), &req)
+		if err != nil {
+			errors.MakeMetricErrorResponse(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			errors.MakeMetricErrorResponse(w, err)
+			return
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/repositories"
+	"go-metrics/internal/services"
+	"go-metrics/internal/usecases"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type wrappingUnitOfWork struct{}
+
+func (wrappingUnitOfWork) Do(ctx context.Context, operation func(ctx context.Context) error) error {
+	if err := operation(ctx); err != nil {
+		return fmt.Errorf("operation failed: %w", err)
+	}
+	return nil
+}
+
+func TestMetricResetPathHandler_WrappedErrors(t *testing.T) {
+	storage := repositories.NewMetricMemoryStorage(map[domain.MetricID]*domain.Metric{})
+	svc := services.NewMetricResetService(
+		repositories.NewMetricMemorySaveRepository(storage),
+		repositories.NewMetricMemoryFindRepository(storage),
+		repositories.NewMetricHistoryMemoryRepository(0),
+		wrappingUnitOfWork{},
+		nil,
+	)
+	r := chi.NewRouter()
+	r.Post("/reset/{type}/{name}", MetricResetPathHandler(usecases.NewMetricResetPathUsecase(svc)))
+
+	req := httptest.NewRequest(http.MethodPost, "/reset/counter/Missing", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "metric not found\n", rr.Body.String())
+}
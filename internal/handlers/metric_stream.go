@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/usecases"
+	"net/http"
+	"time"
+)
+
+type MetricStreamUsecase interface {
+	Execute(ctx context.Context, req *usecases.MetricStreamRequest) (*usecases.MetricStream, error)
+}
+
+func MetricStreamHandler(uc MetricStreamUsecase, keepAlive time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		req := usecases.MetricStreamRequest{
+			Type:   query.Get("type"),
+			Prefix: query.Get("prefix"),
+			Regex:  query.Get("regex"),
+			Labels: query["label"],
+		}
+		stream, err := uc.Execute(r.Context(), &req)
+		if err != nil {
+			errors.MakeMetricErrorResponse(w, err)
+			return
+		}
+		defer stream.Close()
+
+		rc := http.NewResponseController(w)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		if _, err := fmt.Fprint(w, ": connected\n\n"); err != nil || rc.Flush() != nil {
+			return
+		}
+
+		var tick <-chan time.Time
+		if keepAlive > 0 {
+			ticker := time.NewTicker(keepAlive)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-tick:
+				if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+					return
+				}
+			case event, ok := <-stream.Events():
+				if !ok {
+					return
+				}
+				if dropped := stream.TakeDropped(); dropped > 0 {
+					if _, err := fmt.Fprintf(w, "event: dropped\ndata: {\"count\":%d}\n\n", dropped); err != nil {
+						return
+					}
+				}
+				data, err := json.Marshal(usecases.NewMetricGetByIDResponse(event.Metric))
+				if err != nil {
+					continue
+				}
+				if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Kind, data); err != nil {
+					return
+				}
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"bufio"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/services"
+	"go-metrics/internal/usecases"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricStreamHandler(t *testing.T) {
+	svc := services.NewMetricStreamService(10)
+	server := httptest.NewServer(MetricStreamHandler(usecases.NewMetricStreamUsecase(svc), 20*time.Millisecond))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "?type=gauge&regex=cpu.*")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(resp.Body)
+	readLine := func() string {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		return strings.TrimSuffix(line, "\n")
+	}
+	require.Equal(t, ": connected", readLine())
+	require.Equal(t, "", readLine())
+	require.Equal(t, ": keepalive", readLine())
+	require.Equal(t, "", readLine())
+
+	value := 0.5
+	cpu := &domain.Metric{MetricID: domain.MetricID{ID: "cpu_user", Type: domain.Gauge}, Value: &value}
+	svc.Publish(domain.NewMetricEvents(domain.MetricEventUpdated, svc.Sequence(), []*domain.Metric{
+		{MetricID: domain.MetricID{ID: "mem_free", Type: domain.Gauge}, Value: &value},
+		cpu,
+	}))
+	svc.Publish(domain.NewMetricEvents(domain.MetricEventDeleted, svc.Sequence(), []*domain.Metric{cpu}))
+	nextEvent := func() string {
+		line := readLine()
+		for strings.HasPrefix(line, ":") || line == "" {
+			line = readLine()
+		}
+		return line
+	}
+	assert.Equal(t, "event: metric", nextEvent())
+	assert.Equal(t, `data: {"id":"cpu_user","type":"gauge","value":0.5}`, readLine())
+	assert.Equal(t, "event: deleted", nextEvent())
+	assert.Equal(t, `data: {"id":"cpu_user","type":"gauge","value":0.5}`, readLine())
+
+	svc.Close()
+	for {
+		if _, err := reader.ReadString('\n'); err != nil {
+			break
+		}
+	}
+}
+
+func TestMetricStreamHandler_InvalidFilter(t *testing.T) {
+	handler := MetricStreamHandler(usecases.NewMetricStreamUsecase(services.NewMetricStreamService(1)), time.Second)
+	req := httptest.NewRequest(http.MethodGet, "/stream?regex=(", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"context"
+	e "errors"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/usecases"
+	"io"
+	"net/http"
+)
+
+const MetricWriteMaxBodySize = 16 << 20
+
+type MetricWriteInfluxUsecase interface {
+	Execute(ctx context.Context, req *usecases.MetricWriteInfluxRequest) error
+}
+
+func MetricWriteInfluxHandler(uc MetricWriteInfluxUsecase) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := readMetricWriteBody(w, r)
+		if err != nil {
+			return
+		}
+		req := usecases.MetricWriteInfluxRequest{
+			Body:      string(body),
+			Precision: r.URL.Query().Get("precision"),
+		}
+		if err := uc.Execute(r.Context(), &req); err != nil {
+			errors.MakeMetricErrorResponse(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func readMetricWriteBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MetricWriteMaxBodySize))
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case e.As(err, &maxBytesErr):
+		http.Error(w, "Body too large", http.StatusRequestEntityTooLarge)
+	case err != nil:
+		http.Error(w, "Invalid body", http.StatusBadRequest)
+	}
+	return body, err
+}
@@ -0,0 +1,20 @@
+package handlers
+
+import (
+	"go-metrics/internal/usecases"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricWriteInfluxHandler_BodyTooLarge(t *testing.T) {
+	handler := MetricWriteInfluxHandler(usecases.NewMetricWriteInfluxUsecase(nil))
+	body := strings.Repeat("x", MetricWriteMaxBodySize+1)
+	req := httptest.NewRequest(http.MethodPost, "/api/v2/write", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+}
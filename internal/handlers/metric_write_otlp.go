@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"context"
+	"go-metrics/internal/errors"
+	"mime"
+	"net/http"
+
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	otlpContentTypeProtobuf = "application/x-protobuf"
+	otlpContentTypeJSON     = "application/json"
+)
+
+type MetricWriteOTLPUsecase interface {
+	Execute(
+		ctx context.Context,
+		req *colmetricspb.ExportMetricsServiceRequest,
+	) (*colmetricspb.ExportMetricsServiceResponse, error)
+}
+
+func MetricWriteOTLPHandler(uc MetricWriteOTLPUsecase) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if contentType != otlpContentTypeProtobuf && contentType != otlpContentTypeJSON {
+			http.Error(w, "Unsupported content type", http.StatusUnsupportedMediaType)
+			return
+		}
+		body, err := readMetricWriteBody(w, r)
+		if err != nil {
+			return
+		}
+		var req colmetricspb.ExportMetricsServiceRequest
+		if contentType == otlpContentTypeJSON {
+			err = protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(body, &req)
+		} else {
+			err = proto.Unmarshal(body, &req)
+		}
+		if err != nil {
+			http.Error(w, "Invalid OTLP payload", http.StatusBadRequest)
+			return
+		}
+		resp, err := uc.Execute(r.Context(), &req)
+		if err != nil {
+			errors.MakeMetricErrorResponse(w, err)
+			return
+		}
+		var data []byte
+		if contentType == otlpContentTypeJSON {
+			data, err = protojson.Marshal(resp)
+		} else {
+			data, err = proto.Marshal(resp)
+		}
+		if err != nil {
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.WriteHeader(http.StatusOK)
+		w.Write(data)
+	}
+}
@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"context"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/usecases"
+	"mime"
+	"net/http"
+)
+
+const remoteWriteV1Proto = "prometheus.WriteRequest"
+
+type MetricWriteRemoteUsecase interface {
+	Execute(ctx context.Context, req *usecases.MetricWriteRemoteRequest) error
+}
+
+func MetricWriteRemoteHandler(uc MetricWriteRemoteUsecase) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, params, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err == nil {
+			if proto, ok := params["proto"]; ok && proto != remoteWriteV1Proto {
+				http.Error(w, "Unsupported remote write protocol", http.StatusUnsupportedMediaType)
+				return
+			}
+		}
+		body, err := readMetricWriteBody(w, r)
+		if err != nil {
+			return
+		}
+		if err := uc.Execute(r.Context(), &usecases.MetricWriteRemoteRequest{Body: body}); err != nil {
+			errors.MakeMetricErrorResponse(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
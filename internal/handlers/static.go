@@ -0,0 +1,10 @@
+package handlers
+
+import (
+	"io/fs"
+	"net/http"
+)
+
+func StaticHandler(fsys fs.FS) http.HandlerFunc {
+	return http.StripPrefix("/static/", http.FileServer(http.FS(fsys))).ServeHTTP
+}
@@ -0,0 +1,181 @@
+package listeners
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/parsers"
+	"go-metrics/pkg/log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	graphiteBatchSize   = 1000
+	graphiteIdleTimeout = 5 * time.Minute
+)
+
+type GraphiteListener struct {
+	svc          MetricUpdateService
+	maxConns     int
+	maxLineBytes int
+	conns        map[net.Conn]struct{}
+	closing      bool
+	mu           sync.Mutex
+	wg           sync.WaitGroup
+	done         chan struct{}
+}
+
+func NewGraphiteListener(svc MetricUpdateService, maxConns int, maxLineBytes int) *GraphiteListener {
+	return &GraphiteListener{
+		svc:          svc,
+		maxConns:     maxConns,
+		maxLineBytes: maxLineBytes,
+		conns:        make(map[net.Conn]struct{}),
+		done:         make(chan struct{}),
+	}
+}
+
+func (l *GraphiteListener) Serve(ctx context.Context, ln net.Listener) {
+	defer close(l.done)
+	stop := context.AfterFunc(ctx, func() {
+		ln.Close()
+		l.closeConns()
+	})
+	defer stop()
+	slots := make(chan struct{}, l.maxConns)
+	for {
+		conn, err := ln.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			break
+		}
+		if err != nil {
+			log.Error("Failed to accept Graphite connection", "error", err)
+			continue
+		}
+		select {
+		case slots <- struct{}{}:
+		default:
+			log.Error("Graphite connection limit reached, rejecting connection",
+				"remote", conn.RemoteAddr().String(), "limit", l.maxConns)
+			conn.Close()
+			continue
+		}
+		l.track(conn)
+		l.wg.Add(1)
+		go func() {
+			defer l.wg.Done()
+			defer func() { <-slots }()
+			defer l.untrack(conn)
+			l.handle(context.WithoutCancel(ctx), conn)
+		}()
+	}
+	l.wg.Wait()
+}
+
+func (l *GraphiteListener) Stop(ctx context.Context) {
+	select {
+	case <-l.done:
+		log.Info("Graphite listener stopped")
+	case <-ctx.Done():
+		log.Error("Graphite listener did not stop in time", "error", ctx.Err())
+	}
+}
+
+func (l *GraphiteListener) handle(ctx context.Context, conn net.Conn) {
+	reader := bufio.NewReaderSize(conn, l.maxLineBytes)
+	batch := make([]*domain.Metric, 0, graphiteBatchSize)
+	for {
+		l.extendDeadline(conn)
+		line, err := reader.ReadSlice('\n')
+		if errors.Is(err, bufio.ErrBufferFull) {
+			log.Error("Graphite line exceeds size limit, skipping", "limit", l.maxLineBytes)
+			err = skipLine(reader)
+			line = nil
+		}
+		if metric := l.parse(line); metric != nil {
+			batch = append(batch, metric)
+		}
+		if len(batch) > 0 && (err != nil || len(batch) >= graphiteBatchSize || reader.Buffered() == 0) {
+			l.flush(ctx, batch)
+			batch = batch[:0]
+		}
+		if err != nil {
+			conn.Close()
+			return
+		}
+	}
+}
+
+func (l *GraphiteListener) parse(line []byte) *domain.Metric {
+	text := strings.TrimSpace(string(line))
+	if text == "" {
+		return nil
+	}
+	sample, err := parsers.ParseGraphiteLine(text)
+	if err != nil {
+		log.Error("Failed to parse Graphite line", "error", err)
+		return nil
+	}
+	value := sample.Value
+	return &domain.Metric{
+		MetricID: domain.MetricID{
+			ID:     sample.Name,
+			Type:   domain.Gauge,
+			Labels: domain.NewLabels(sample.Labels),
+		},
+		Value:     &value,
+		UpdatedAt: sample.Timestamp,
+	}
+}
+
+func (l *GraphiteListener) flush(ctx context.Context, batch []*domain.Metric) {
+	metrics := append([]*domain.Metric(nil), batch...)
+	if _, err := l.svc.Update(ctx, metrics); err != nil {
+		log.Error("Failed to write Graphite metrics", "count", len(metrics), "error", err)
+	}
+}
+
+func (l *GraphiteListener) track(conn net.Conn) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.conns[conn] = struct{}{}
+	if l.closing {
+		conn.SetReadDeadline(time.Now())
+	}
+}
+
+func (l *GraphiteListener) untrack(conn net.Conn) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.conns, conn)
+}
+
+func (l *GraphiteListener) extendDeadline(conn net.Conn) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.closing {
+		conn.SetReadDeadline(time.Now().Add(graphiteIdleTimeout))
+	}
+}
+
+func (l *GraphiteListener) closeConns() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closing = true
+	for conn := range l.conns {
+		conn.SetReadDeadline(time.Now())
+	}
+}
+
+func skipLine(reader *bufio.Reader) error {
+	for {
+		_, err := reader.ReadSlice('\n')
+		if !errors.Is(err, bufio.ErrBufferFull) {
+			return err
+		}
+	}
+}
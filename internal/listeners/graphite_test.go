@@ -0,0 +1,139 @@
+package listeners
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type graphiteRecorder struct {
+	metrics []*domain.Metric
+	mu      sync.Mutex
+}
+
+func (r *graphiteRecorder) Update(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, metrics...)
+	return metrics, nil
+}
+
+func (r *graphiteRecorder) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.metrics)
+}
+
+func startGraphiteListener(t *testing.T, listener *GraphiteListener) (string, context.CancelFunc) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	go listener.Serve(ctx, ln)
+	return ln.Addr().String(), cancel
+}
+
+func stopGraphiteListener(t *testing.T, listener *GraphiteListener, cancel context.CancelFunc) {
+	cancel()
+	select {
+	case <-listener.done:
+	case <-time.After(time.Second):
+		t.Fatal("graphite listener did not stop")
+	}
+}
+
+func TestGraphiteListener_WritesGauges(t *testing.T) {
+	recorder := &graphiteRecorder{}
+	listener := NewGraphiteListener(recorder, 10, 128)
+	addr, cancel := startGraphiteListener(t, listener)
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("servers.web-1.load 0.5 1700000000\nbad line here now\ndisk.used;host=h1 42"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	require.Eventually(t, func() bool { return recorder.count() == 2 }, time.Second, 10*time.Millisecond)
+	stopGraphiteListener(t, listener, cancel)
+
+	load, used := 0.5, 42.0
+	assert.ElementsMatch(t, []*domain.Metric{
+		{
+			MetricID:  domain.MetricID{ID: "servers_web_1_load", Type: domain.Gauge},
+			Value:     &load,
+			UpdatedAt: time.Unix(1700000000, 0),
+		},
+		{
+			MetricID: domain.MetricID{
+				ID: "disk_used", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "h1"}),
+			},
+			Value: &used,
+		},
+	}, recorder.metrics)
+}
+
+func TestGraphiteListener_SkipsLongLines(t *testing.T) {
+	recorder := &graphiteRecorder{}
+	listener := NewGraphiteListener(recorder, 10, 32)
+	addr, cancel := startGraphiteListener(t, listener)
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	_, err = conn.Write([]byte(strings.Repeat("a", 100) + " 1\nok 2\n"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	require.Eventually(t, func() bool { return recorder.count() == 1 }, time.Second, 10*time.Millisecond)
+	stopGraphiteListener(t, listener, cancel)
+	assert.Equal(t, "ok", recorder.metrics[0].ID)
+}
+
+func TestGraphiteListener_RejectsConnectionsOverLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	listener := NewGraphiteListener(NewMockMetricUpdateService(ctrl), 1, 128)
+	addr, cancel := startGraphiteListener(t, listener)
+	defer stopGraphiteListener(t, listener, cancel)
+
+	first, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer first.Close()
+	require.Eventually(t, func() bool {
+		listener.mu.Lock()
+		defer listener.mu.Unlock()
+		return len(listener.conns) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	second, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer second.Close()
+	second.SetReadDeadline(time.Now().Add(time.Second))
+	_, err = second.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestGraphiteListener_ShutdownClosesOpenConnections(t *testing.T) {
+	recorder := &graphiteRecorder{}
+	listener := NewGraphiteListener(recorder, 10, 128)
+	addr, cancel := startGraphiteListener(t, listener)
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("queue.depth 7\n"))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return recorder.count() == 1 }, time.Second, 10*time.Millisecond)
+
+	stopGraphiteListener(t, listener, cancel)
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+	assert.Empty(t, listener.conns)
+}
@@ -0,0 +1,126 @@
+package listeners
+
+import (
+	"context"
+	"errors"
+	"go-metrics/internal/domain"
+	e "go-metrics/internal/errors"
+	"go-metrics/internal/parsers"
+	"go-metrics/pkg/log"
+	"net"
+	"sync"
+	"time"
+)
+
+const statsDMaxPacketSize = 65535
+
+type MetricUpdateService interface {
+	Update(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error)
+}
+
+type MetricGetByIDService interface {
+	GetByID(ctx context.Context, id *domain.MetricID) (*domain.Metric, error)
+}
+
+type StatsDListener struct {
+	svc           MetricUpdateService
+	get           MetricGetByIDService
+	aggregator    *StatsDAggregator
+	flushInterval time.Duration
+	done          chan struct{}
+}
+
+func NewStatsDListener(svc MetricUpdateService, get MetricGetByIDService, flushInterval time.Duration) *StatsDListener {
+	return &StatsDListener{
+		svc:           svc,
+		get:           get,
+		aggregator:    NewStatsDAggregator(),
+		flushInterval: flushInterval,
+		done:          make(chan struct{}),
+	}
+}
+
+func (l *StatsDListener) Serve(ctx context.Context, conn net.PacketConn) {
+	defer close(l.done)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		l.read(conn)
+	}()
+	ticker := time.NewTicker(l.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			l.Flush(ctx)
+		case <-ctx.Done():
+			conn.Close()
+			wg.Wait()
+			return
+		}
+	}
+}
+
+func (l *StatsDListener) Stop(ctx context.Context) {
+	select {
+	case <-l.done:
+	case <-ctx.Done():
+		return
+	}
+	log.Info("StatsD listener is stopping, flushing pending metrics")
+	l.Flush(ctx)
+}
+
+func (l *StatsDListener) Handle(packet []byte) {
+	samples, err := parsers.ParseStatsDPacket(packet)
+	if err != nil {
+		log.Error("Failed to parse StatsD packet", "error", err)
+	}
+	for _, sample := range samples {
+		l.aggregator.Add(sample)
+	}
+}
+
+func (l *StatsDListener) Flush(ctx context.Context) {
+	l.seed(ctx)
+	metrics := l.aggregator.Flush()
+	if len(metrics) == 0 {
+		return
+	}
+	if _, err := l.svc.Update(ctx, metrics); err != nil {
+		log.Error("Failed to flush StatsD metrics", "count", len(metrics), "error", err)
+		l.aggregator.Requeue(metrics)
+	}
+}
+
+func (l *StatsDListener) seed(ctx context.Context) {
+	for _, id := range l.aggregator.Unseeded() {
+		var value float64
+		metric, err := l.get.GetByID(ctx, &id)
+		switch {
+		case err == nil && metric.Value != nil:
+			value = *metric.Value
+		case err != nil && !errors.Is(err, e.ErrMetricNotFound):
+			log.Error("Failed to load stored StatsD gauge", "id", id.ID, "error", err)
+			continue
+		}
+		l.aggregator.Seed(id, value)
+	}
+}
+
+func (l *StatsDListener) read(conn net.PacketConn) {
+	buf := make([]byte, statsDMaxPacketSize)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if n > 0 {
+			l.Handle(buf[:n])
+		}
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			log.Error("Failed to read StatsD packet", "error", err)
+		}
+	}
+}
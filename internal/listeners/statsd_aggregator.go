@@ -0,0 +1,207 @@
+package listeners
+
+import (
+	"go-metrics/internal/domain"
+	"go-metrics/internal/parsers"
+	"math"
+	"sync"
+)
+
+const StatsDMaxIdleFlushes = 10
+
+type statsDCounter struct {
+	sum  float64
+	idle int
+}
+
+type statsDGauge struct {
+	value  float64
+	seeded bool
+	dirty  bool
+	idle   int
+}
+
+type statsDTimer struct {
+	summary *domain.SummaryValue
+	count   float64
+	sum     float64
+}
+
+type StatsDAggregator struct {
+	counters map[domain.MetricID]*statsDCounter
+	gauges   map[domain.MetricID]*statsDGauge
+	timers   map[domain.MetricID]*statsDTimer
+	sets     map[domain.MetricID]map[string]struct{}
+	mu       sync.Mutex
+}
+
+func NewStatsDAggregator() *StatsDAggregator {
+	return &StatsDAggregator{
+		counters: make(map[domain.MetricID]*statsDCounter),
+		gauges:   make(map[domain.MetricID]*statsDGauge),
+		timers:   make(map[domain.MetricID]*statsDTimer),
+		sets:     make(map[domain.MetricID]map[string]struct{}),
+	}
+}
+
+func (a *StatsDAggregator) Add(sample *parsers.StatsDSample) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	weight := 1 / sample.SampleRate
+	switch sample.Type {
+	case parsers.StatsDCounter:
+		counter := a.counter(statsDMetricID(sample, domain.Counter))
+		counter.sum += sample.Value * weight
+		counter.idle = 0
+	case parsers.StatsDGauge:
+		gauge := a.gauge(statsDMetricID(sample, domain.Gauge))
+		if sample.Relative {
+			gauge.value += sample.Value
+		} else {
+			gauge.value = sample.Value
+			gauge.seeded = true
+		}
+		gauge.dirty = true
+		gauge.idle = 0
+	case parsers.StatsDTimer, parsers.StatsDHistogram, parsers.StatsDDistribution:
+		timer := a.timer(statsDMetricID(sample, domain.Summary))
+		timer.summary.Observe(sample.Value)
+		timer.count += weight
+		timer.sum += sample.Value * weight
+	case parsers.StatsDSet:
+		id := statsDMetricID(sample, domain.Gauge)
+		members, exists := a.sets[id]
+		if !exists {
+			members = make(map[string]struct{})
+			a.sets[id] = members
+		}
+		members[sample.Member] = struct{}{}
+	}
+}
+
+func (a *StatsDAggregator) Flush() []*domain.Metric {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	metrics := make([]*domain.Metric, 0, len(a.counters)+len(a.gauges)+len(a.timers)+len(a.sets))
+	for id, counter := range a.counters {
+		whole := math.Trunc(counter.sum)
+		counter.sum -= whole
+		if whole == 0 {
+			if counter.idle++; counter.idle >= StatsDMaxIdleFlushes {
+				delete(a.counters, id)
+			}
+			continue
+		}
+		counter.idle = 0
+		delta := int64(whole)
+		metrics = append(metrics, &domain.Metric{MetricID: id, Delta: &delta})
+	}
+	for id, gauge := range a.gauges {
+		if !gauge.seeded {
+			continue
+		}
+		if !gauge.dirty {
+			if gauge.idle++; gauge.idle >= StatsDMaxIdleFlushes {
+				delete(a.gauges, id)
+			}
+			continue
+		}
+		gauge.dirty = false
+		value := gauge.value
+		metrics = append(metrics, &domain.Metric{MetricID: id, Value: &value})
+	}
+	for id, timer := range a.timers {
+		timer.summary.Count = int64(math.Round(timer.count))
+		timer.summary.Sum = timer.sum
+		metrics = append(metrics, &domain.Metric{MetricID: id, Summary: timer.summary})
+	}
+	for id, members := range a.sets {
+		value := float64(len(members))
+		metrics = append(metrics, &domain.Metric{MetricID: id, Value: &value})
+	}
+	a.timers = make(map[domain.MetricID]*statsDTimer)
+	a.sets = make(map[domain.MetricID]map[string]struct{})
+	return metrics
+}
+
+func (a *StatsDAggregator) Requeue(metrics []*domain.Metric) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, metric := range metrics {
+		switch {
+		case metric.Type == domain.Counter && metric.Delta != nil:
+			a.counter(metric.MetricID).sum += float64(*metric.Delta)
+		case metric.Type == domain.Summary && metric.Summary != nil:
+			timer := a.timer(metric.MetricID)
+			timer.count += float64(metric.Summary.Count)
+			timer.sum += metric.Summary.Sum
+			timer.summary.Merge(metric.Summary)
+		case metric.Type == domain.Gauge && metric.Value != nil:
+			if _, exists := a.sets[metric.MetricID]; exists {
+				continue
+			}
+			gauge := a.gauge(metric.MetricID)
+			if !gauge.seeded {
+				gauge.value += *metric.Value
+				gauge.seeded = true
+			}
+			gauge.dirty = true
+		}
+	}
+}
+
+func (a *StatsDAggregator) Unseeded() []domain.MetricID {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	var ids []domain.MetricID
+	for id, gauge := range a.gauges {
+		if !gauge.seeded {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (a *StatsDAggregator) Seed(id domain.MetricID, value float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if gauge, exists := a.gauges[id]; exists && !gauge.seeded {
+		gauge.value += value
+		gauge.seeded = true
+	}
+}
+
+func (a *StatsDAggregator) counter(id domain.MetricID) *statsDCounter {
+	counter, exists := a.counters[id]
+	if !exists {
+		counter = &statsDCounter{}
+		a.counters[id] = counter
+	}
+	return counter
+}
+
+func (a *StatsDAggregator) gauge(id domain.MetricID) *statsDGauge {
+	gauge, exists := a.gauges[id]
+	if !exists {
+		gauge = &statsDGauge{}
+		a.gauges[id] = gauge
+	}
+	return gauge
+}
+
+func (a *StatsDAggregator) timer(id domain.MetricID) *statsDTimer {
+	timer, exists := a.timers[id]
+	if !exists {
+		timer = &statsDTimer{summary: domain.NewSummary(nil)}
+		a.timers[id] = timer
+	}
+	return timer
+}
+
+func statsDMetricID(sample *parsers.StatsDSample, metricType domain.MetricType) domain.MetricID {
+	return domain.MetricID{
+		ID:     sample.Name,
+		Type:   metricType,
+		Labels: domain.NewLabels(sample.Labels),
+	}
+}
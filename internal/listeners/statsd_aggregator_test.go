@@ -0,0 +1,103 @@
+package listeners
+
+import (
+	"go-metrics/internal/domain"
+	"go-metrics/internal/parsers"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func addStatsDLines(t *testing.T, aggregator *StatsDAggregator, lines ...string) {
+	for _, line := range lines {
+		sample, err := parsers.ParseStatsDLine(line)
+		require.NoError(t, err)
+		aggregator.Add(sample)
+	}
+}
+
+func flushByID(aggregator *StatsDAggregator) map[domain.MetricID]*domain.Metric {
+	result := make(map[domain.MetricID]*domain.Metric)
+	for _, metric := range aggregator.Flush() {
+		result[metric.MetricID] = metric
+	}
+	return result
+}
+
+func TestStatsDAggregator_Counters(t *testing.T) {
+	aggregator := NewStatsDAggregator()
+	addStatsDLines(t, aggregator, "hits:1|c", "hits:2|c", "hits:1|c|@0.1", "hits:1|c|#env:prod")
+	result := flushByID(aggregator)
+	require.Len(t, result, 2)
+	assert.Equal(t, int64(13), *result[domain.MetricID{ID: "hits", Type: domain.Counter}].Delta)
+	labels := domain.NewLabels(map[string]string{"env": "prod"})
+	assert.Equal(t, int64(1), *result[domain.MetricID{ID: "hits", Type: domain.Counter, Labels: labels}].Delta)
+	assert.Empty(t, aggregator.Flush())
+}
+
+func TestStatsDAggregator_Gauges(t *testing.T) {
+	aggregator := NewStatsDAggregator()
+	id := domain.MetricID{ID: "queue", Type: domain.Gauge}
+	addStatsDLines(t, aggregator, "queue:10|g", "queue:+5|g", "queue:-3|g")
+	assert.Equal(t, 12.0, *flushByID(aggregator)[id].Value)
+	assert.Empty(t, aggregator.Flush())
+	addStatsDLines(t, aggregator, "queue:+1|g")
+	assert.Equal(t, 13.0, *flushByID(aggregator)[id].Value)
+}
+
+func TestStatsDAggregator_Timers(t *testing.T) {
+	aggregator := NewStatsDAggregator()
+	addStatsDLines(t, aggregator, "db:100|ms", "db:200|ms|@0.5", "db:300|h")
+	summary := flushByID(aggregator)[domain.MetricID{ID: "db", Type: domain.Summary}].Summary
+	require.NotNil(t, summary)
+	assert.Equal(t, int64(4), summary.Count)
+	assert.Equal(t, 800.0, summary.Sum)
+	assert.Equal(t, []float64{100, 200, 300}, summary.Observations)
+}
+
+func TestStatsDAggregator_Sets(t *testing.T) {
+	aggregator := NewStatsDAggregator()
+	addStatsDLines(t, aggregator, "users:alice|s", "users:bob|s", "users:alice|s")
+	assert.Equal(t, 2.0, *flushByID(aggregator)[domain.MetricID{ID: "users", Type: domain.Gauge}].Value)
+}
+
+func TestStatsDAggregator_CounterRemainder(t *testing.T) {
+	aggregator := NewStatsDAggregator()
+	id := domain.MetricID{ID: "hits", Type: domain.Counter}
+	addStatsDLines(t, aggregator, "hits:1|c|@0.4")
+	assert.Equal(t, int64(2), *flushByID(aggregator)[id].Delta)
+	addStatsDLines(t, aggregator, "hits:1|c|@0.4")
+	assert.Equal(t, int64(3), *flushByID(aggregator)[id].Delta)
+	addStatsDLines(t, aggregator, "hits:0.4|c")
+	assert.Empty(t, aggregator.Flush())
+	addStatsDLines(t, aggregator, "hits:0.6|c")
+	assert.Equal(t, int64(1), *flushByID(aggregator)[id].Delta)
+}
+
+func TestStatsDAggregator_ExpiresIdleEntries(t *testing.T) {
+	aggregator := NewStatsDAggregator()
+	addStatsDLines(t, aggregator, "queue:10|g", "hits:0.5|c")
+	aggregator.Flush()
+	for i := 0; i < StatsDMaxIdleFlushes; i++ {
+		assert.Empty(t, aggregator.Flush())
+	}
+	assert.Empty(t, aggregator.gauges)
+	assert.Empty(t, aggregator.counters)
+	id := domain.MetricID{ID: "queue", Type: domain.Gauge}
+	addStatsDLines(t, aggregator, "queue:+1|g")
+	assert.Empty(t, aggregator.Flush())
+	assert.Equal(t, []domain.MetricID{id}, aggregator.Unseeded())
+	aggregator.Seed(id, 10)
+	assert.Empty(t, aggregator.Unseeded())
+	assert.Equal(t, 11.0, *flushByID(aggregator)[id].Value)
+}
+
+func TestStatsDAggregator_AbsoluteGaugeSkipsSeed(t *testing.T) {
+	aggregator := NewStatsDAggregator()
+	id := domain.MetricID{ID: "queue", Type: domain.Gauge}
+	addStatsDLines(t, aggregator, "queue:+1|g", "queue:5|g", "queue:+1|g")
+	assert.Empty(t, aggregator.Unseeded())
+	aggregator.Seed(id, 10)
+	assert.Equal(t, 6.0, *flushByID(aggregator)[id].Value)
+}
@@ -0,0 +1,89 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/listeners/statsd.go
+
+// Package listeners is a generated GoMock package.
+package listeners
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricUpdateService is a mock of MetricUpdateService interface.
+type MockMetricUpdateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricUpdateServiceMockRecorder
+}
+
+// MockMetricUpdateServiceMockRecorder is the mock recorder for MockMetricUpdateService.
+type MockMetricUpdateServiceMockRecorder struct {
+	mock *MockMetricUpdateService
+}
+
+// NewMockMetricUpdateService creates a new mock instance.
+func NewMockMetricUpdateService(ctrl *gomock.Controller) *MockMetricUpdateService {
+	mock := &MockMetricUpdateService{ctrl: ctrl}
+	mock.recorder = &MockMetricUpdateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricUpdateService) EXPECT() *MockMetricUpdateServiceMockRecorder {
+	return m.recorder
+}
+
+// Update mocks base method.
+func (m *MockMetricUpdateService) Update(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, metrics)
+	ret0, _ := ret[0].([]*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMetricUpdateServiceMockRecorder) Update(ctx, metrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMetricUpdateService)(nil).Update), ctx, metrics)
+}
+
+// MockMetricGetByIDService is a mock of MetricGetByIDService interface.
+type MockMetricGetByIDService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricGetByIDServiceMockRecorder
+}
+
+// MockMetricGetByIDServiceMockRecorder is the mock recorder for MockMetricGetByIDService.
+type MockMetricGetByIDServiceMockRecorder struct {
+	mock *MockMetricGetByIDService
+}
+
+// NewMockMetricGetByIDService creates a new mock instance.
+func NewMockMetricGetByIDService(ctrl *gomock.Controller) *MockMetricGetByIDService {
+	mock := &MockMetricGetByIDService{ctrl: ctrl}
+	mock.recorder = &MockMetricGetByIDServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricGetByIDService) EXPECT() *MockMetricGetByIDServiceMockRecorder {
+	return m.recorder
+}
+
+// GetByID mocks base method.
+func (m *MockMetricGetByIDService) GetByID(ctx context.Context, id *domain.MetricID) (*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockMetricGetByIDServiceMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockMetricGetByIDService)(nil).GetByID), ctx, id)
+}
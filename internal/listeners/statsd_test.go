@@ -0,0 +1,126 @@
+package listeners
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsDListener_ServeFlushesOnStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := NewMockMetricUpdateService(ctrl)
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	listener := NewStatsDListener(mockService, NewMockMetricGetByIDService(ctrl), time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	go listener.Serve(ctx, conn)
+
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	require.NoError(t, err)
+	defer client.Close()
+	_, err = client.Write([]byte("hits:1|c\nhits:2|c\nload:0.5|g"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		listener.aggregator.mu.Lock()
+		defer listener.aggregator.mu.Unlock()
+		return len(listener.aggregator.counters) == 1 && len(listener.aggregator.gauges) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	var flushed []*domain.Metric
+	mockService.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+			flushed = metrics
+			return metrics, nil
+		},
+	)
+	cancel()
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), time.Second)
+	defer stopCancel()
+	listener.Stop(stopCtx)
+
+	delta := int64(3)
+	value := 0.5
+	assert.ElementsMatch(t, []*domain.Metric{
+		{MetricID: domain.MetricID{ID: "hits", Type: domain.Counter}, Delta: &delta},
+		{MetricID: domain.MetricID{ID: "load", Type: domain.Gauge}, Value: &value},
+	}, flushed)
+}
+
+func TestStatsDListener_FlushSkipsEmptyInterval(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	listener := NewStatsDListener(NewMockMetricUpdateService(ctrl), NewMockMetricGetByIDService(ctrl), time.Hour)
+	listener.Handle([]byte("garbage"))
+	listener.Flush(context.Background())
+}
+
+func TestStatsDListener_FlushRequeuesOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := NewMockMetricUpdateService(ctrl)
+	listener := NewStatsDListener(mockService, NewMockMetricGetByIDService(ctrl), time.Hour)
+	listener.Handle([]byte("hits:2|c\nload:1|g\ndb:10|ms"))
+	mockService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+	listener.Flush(context.Background())
+
+	listener.Handle([]byte("hits:1|c\ndb:20|ms"))
+	var flushed []*domain.Metric
+	mockService.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+			flushed = metrics
+			return metrics, nil
+		},
+	)
+	listener.Flush(context.Background())
+
+	result := make(map[domain.MetricID]*domain.Metric)
+	for _, metric := range flushed {
+		result[metric.MetricID] = metric
+	}
+	require.Len(t, result, 3)
+	assert.Equal(t, int64(3), *result[domain.MetricID{ID: "hits", Type: domain.Counter}].Delta)
+	assert.Equal(t, 1.0, *result[domain.MetricID{ID: "load", Type: domain.Gauge}].Value)
+	summary := result[domain.MetricID{ID: "db", Type: domain.Summary}].Summary
+	assert.Equal(t, int64(2), summary.Count)
+	assert.Equal(t, 30.0, summary.Sum)
+}
+
+func TestStatsDListener_FlushSeedsRelativeGauges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := NewMockMetricUpdateService(ctrl)
+	mockGet := NewMockMetricGetByIDService(ctrl)
+	listener := NewStatsDListener(mockService, mockGet, time.Hour)
+	listener.Handle([]byte("queue:+1|g\nfresh:+2|g\nbroken:+3|g"))
+	stored := 7.0
+	mockGet.EXPECT().GetByID(gomock.Any(), &domain.MetricID{ID: "queue", Type: domain.Gauge}).
+		Return(&domain.Metric{MetricID: domain.MetricID{ID: "queue", Type: domain.Gauge}, Value: &stored}, nil)
+	mockGet.EXPECT().GetByID(gomock.Any(), &domain.MetricID{ID: "fresh", Type: domain.Gauge}).
+		Return(nil, errors.ErrMetricNotFound)
+	mockGet.EXPECT().GetByID(gomock.Any(), &domain.MetricID{ID: "broken", Type: domain.Gauge}).
+		Return(nil, errors.ErrMetricGetByIDInternal)
+	var flushed []*domain.Metric
+	mockService.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+			flushed = metrics
+			return metrics, nil
+		},
+	)
+	listener.Flush(context.Background())
+
+	queue, fresh := 8.0, 2.0
+	assert.ElementsMatch(t, []*domain.Metric{
+		{MetricID: domain.MetricID{ID: "queue", Type: domain.Gauge}, Value: &queue},
+		{MetricID: domain.MetricID{ID: "fresh", Type: domain.Gauge}, Value: &fresh},
+	}, flushed)
+	assert.Equal(t, []domain.MetricID{{ID: "broken", Type: domain.Gauge}}, listener.aggregator.Unseeded())
+}
@@ -5,10 +5,16 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"io"
 	"net/http"
 )
 
+const (
+	HeaderHashSHA256 = "HashSHA256"
+	HMACMaxBodySize  = 16 << 20
+)
+
 type Config interface {
 	GetKey() string
 }
@@ -16,22 +22,66 @@ type Config interface {
 func HMACMiddleware(cfg Config) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			bodyBytes, err := io.ReadAll(r.Body)
+			key := cfg.GetKey()
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			bodyBytes, err := io.ReadAll(http.MaxBytesReader(w, r.Body, HMACMaxBodySize))
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				http.Error(w, "Body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
 			if err != nil {
 				http.Error(w, "Error reading request body", http.StatusInternalServerError)
 				return
 			}
-			r.Body = io.NopCloser(io.Reader(bytes.NewReader(bodyBytes)))
-			key := cfg.GetKey()
-			hash := computeHMAC(bodyBytes, key)
-			r.Header.Set("HashSHA256", hash)
-			next.ServeHTTP(w, r)
+			r.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+			received := r.Header.Get(HeaderHashSHA256)
+			if received == "" && r.Method != http.MethodGet && r.Method != http.MethodHead {
+				http.Error(w, "Missing HashSHA256", http.StatusBadRequest)
+				return
+			}
+			if received != "" && !ValidateHMAC(bodyBytes, key, received) {
+				http.Error(w, "Invalid HashSHA256", http.StatusBadRequest)
+				return
+			}
+			hw := &hmacResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(hw, r)
+			w.Header().Set(HeaderHashSHA256, ComputeHMAC(hw.body.Bytes(), key))
+			w.WriteHeader(hw.statusCode)
+			w.Write(hw.body.Bytes())
 		})
 	}
 }
 
-func computeHMAC(data []byte, key string) string {
+func ComputeHMAC(data []byte, key string) string {
 	h := hmac.New(sha256.New, []byte(key))
 	h.Write(data)
 	return hex.EncodeToString(h.Sum(nil))
 }
+
+func ValidateHMAC(data []byte, key string, hash string) bool {
+	received, err := hex.DecodeString(hash)
+	if err != nil {
+		return false
+	}
+	h := hmac.New(sha256.New, []byte(key))
+	h.Write(data)
+	return hmac.Equal(received, h.Sum(nil))
+}
+
+type hmacResponseWriter struct {
+	http.ResponseWriter
+	body       bytes.Buffer
+	statusCode int
+}
+
+func (w *hmacResponseWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+}
+
+func (w *hmacResponseWriter) Write(p []byte) (int, error) {
+	return w.body.Write(p)
+}
@@ -0,0 +1,57 @@
+package middlewares
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var MetadataHashSHA256 = strings.ToLower(HeaderHashSHA256)
+
+func HMACUnaryInterceptor(cfg Config) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+	) (any, error) {
+		key := cfg.GetKey()
+		if key == "" {
+			return handler(ctx, req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		received := md.Get(MetadataHashSHA256)
+		if len(received) == 0 || received[0] == "" {
+			return nil, status.Error(codes.InvalidArgument, "Missing HashSHA256")
+		}
+		data, err := MarshalProtoForHMAC(req)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "error reading request")
+		}
+		if !ValidateHMAC(data, key, received[0]) {
+			return nil, status.Error(codes.InvalidArgument, "Invalid HashSHA256")
+		}
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		data, err = MarshalProtoForHMAC(resp)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "error signing response")
+		}
+		if err := grpc.SetHeader(ctx, metadata.Pairs(MetadataHashSHA256, ComputeHMAC(data, key))); err != nil {
+			return nil, status.Error(codes.Internal, "error signing response")
+		}
+		return resp, nil
+	}
+}
+
+func MarshalProtoForHMAC(message any) ([]byte, error) {
+	m, ok := message.(proto.Message)
+	if !ok {
+		return nil, status.Error(codes.Internal, "message is not a protobuf message")
+	}
+	return proto.MarshalOptions{Deterministic: true}.Marshal(m)
+}
@@ -0,0 +1,70 @@
+package middlewares
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type headerCapture struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (s *headerCapture) Method() string { return "/test" }
+
+func (s *headerCapture) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func TestHMACUnaryInterceptor(t *testing.T) {
+	const key = "secret"
+	req := wrapperspb.String("request")
+	resp := wrapperspb.String("response")
+	reqData, err := MarshalProtoForHMAC(req)
+	require.NoError(t, err)
+	respData, err := MarshalProtoForHMAC(resp)
+	require.NoError(t, err)
+	handler := func(ctx context.Context, req any) (any, error) { return resp, nil }
+
+	tests := []struct {
+		name     string
+		key      string
+		hash     string
+		code     codes.Code
+		expected string
+	}{
+		{name: "no key", key: "", hash: "", code: codes.OK, expected: ""},
+		{name: "valid signature", key: key, hash: ComputeHMAC(reqData, key), code: codes.OK, expected: ComputeHMAC(respData, key)},
+		{name: "unsigned request", key: key, hash: "", code: codes.InvalidArgument, expected: ""},
+		{name: "invalid signature", key: key, hash: "deadbeef", code: codes.InvalidArgument, expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &headerCapture{}
+			ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+			if tt.hash != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(MetadataHashSHA256, tt.hash))
+			}
+			interceptor := HMACUnaryInterceptor(&testHMACConfig{key: tt.key})
+			result, err := interceptor(ctx, req, &grpc.UnaryServerInfo{}, handler)
+			assert.Equal(t, tt.code, status.Code(err))
+			if tt.code == codes.OK {
+				assert.Equal(t, resp, result)
+			}
+			if tt.expected == "" {
+				assert.Empty(t, stream.header.Get(MetadataHashSHA256))
+			} else {
+				assert.Equal(t, []string{tt.expected}, stream.header.Get(MetadataHashSHA256))
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package middlewares
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testHMACConfig struct {
+	key string
+}
+
+func (c *testHMACConfig) GetKey() string {
+	return c.key
+}
+
+func TestHMACMiddleware(t *testing.T) {
+	const key = "secret"
+	body := []byte(`[{"id":"PollCount","type":"counter","delta":1}]`)
+	tests := []struct {
+		name           string
+		method         string
+		key            string
+		hash           string
+		expectedStatus int
+		expectNext     bool
+		expectSigned   bool
+	}{
+		{
+			name:           "valid signature",
+			key:            key,
+			hash:           ComputeHMAC(body, key),
+			expectedStatus: http.StatusOK,
+			expectNext:     true,
+			expectSigned:   true,
+		},
+		{
+			name:           "invalid signature",
+			key:            key,
+			hash:           ComputeHMAC(body, "other"),
+			expectedStatus: http.StatusBadRequest,
+			expectNext:     false,
+		},
+		{
+			name:           "malformed signature",
+			key:            key,
+			hash:           "not-hex",
+			expectedStatus: http.StatusBadRequest,
+			expectNext:     false,
+		},
+		{
+			name:           "unsigned request",
+			key:            key,
+			expectedStatus: http.StatusBadRequest,
+			expectNext:     false,
+		},
+		{
+			name:           "unsigned read request",
+			method:         http.MethodGet,
+			key:            key,
+			expectedStatus: http.StatusOK,
+			expectNext:     true,
+			expectSigned:   true,
+		},
+		{
+			name:           "no key configured",
+			hash:           "ignored",
+			expectedStatus: http.StatusOK,
+			expectNext:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				received, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, body, received)
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("ok"))
+			})
+			method := tt.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(method, "/updates/", bytes.NewReader(body))
+			if tt.hash != "" {
+				req.Header.Set(HeaderHashSHA256, tt.hash)
+			}
+			rr := httptest.NewRecorder()
+			HMACMiddleware(&testHMACConfig{key: tt.key})(handler).ServeHTTP(rr, req)
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, tt.expectNext, called)
+			if tt.expectSigned {
+				assert.Equal(t, ComputeHMAC([]byte("ok"), tt.key), rr.Header().Get(HeaderHashSHA256))
+			} else {
+				assert.Empty(t, rr.Header().Get(HeaderHashSHA256))
+			}
+		})
+	}
+}
+
+func TestHMACMiddleware_BodyTooLarge(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler must not be called")
+	})
+	body := bytes.Repeat([]byte("x"), HMACMaxBodySize+1)
+	req := httptest.NewRequest(http.MethodPost, "/updates/", bytes.NewReader(body))
+	req.Header.Set(HeaderHashSHA256, ComputeHMAC(body, "secret"))
+	rr := httptest.NewRecorder()
+	HMACMiddleware(&testHMACConfig{key: "secret"})(handler).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+}
+
+func TestValidateHMAC(t *testing.T) {
+	data := []byte("payload")
+	assert.True(t, ValidateHMAC(data, "key", ComputeHMAC(data, "key")))
+	assert.False(t, ValidateHMAC(data, "key", ComputeHMAC(data, "other")))
+	assert.False(t, ValidateHMAC(data, "key", "zz"))
+}
@@ -40,3 +40,7 @@ func (rw *ResponseWriter) Write(p []byte) (n int, err error) {
 	rw.responseSize += n
 	return n, err
 }
+
+func (rw *ResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,214 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+//go:embed sql/*.sql
+var embedded embed.FS
+
+var (
+	ErrInvalidMigration = errors.New("invalid migration set")
+	ErrSchemaBehind     = errors.New("database schema is behind, run the migrate command")
+	ErrSchemaAhead      = errors.New("database schema is newer than the server")
+)
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+const schemaMigrationsQuery = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	);
+`
+
+const schemaVersionQuery = "SELECT COALESCE(MAX(version), 0) FROM schema_migrations"
+
+const schemaLockQuery = "LOCK TABLE schema_migrations IN EXCLUSIVE MODE"
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []*Migration
+}
+
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := Load(embedded)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func Load(fsys fs.FS) ([]*Migration, error) {
+	files, err := fs.Glob(fsys, "sql/*.sql")
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int]*Migration)
+	for _, file := range files {
+		match := migrationFileName.FindStringSubmatch(file[len("sql/"):])
+		if match == nil {
+			return nil, fmt.Errorf("%w: unexpected file %s", ErrInvalidMigration, file)
+		}
+		version, err := strconv.Atoi(match[1])
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("%w: invalid version in %s", ErrInvalidMigration, file)
+		}
+		data, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return nil, err
+		}
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d has different names", ErrInvalidMigration, version)
+		}
+		if match[3] == "up" {
+			migration.Up = string(data)
+		} else {
+			migration.Down = string(data)
+		}
+	}
+	migrations := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		migrations = append(migrations, migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			return nil, fmt.Errorf("%w: version %d is missing", ErrInvalidMigration, i+1)
+		}
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("%w: version %d needs both up and down", ErrInvalidMigration, migration.Version)
+		}
+	}
+	return migrations, nil
+}
+
+func (m *Migrator) Latest() int {
+	return len(m.migrations)
+}
+
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	if _, err := m.db.ExecContext(ctx, schemaMigrationsQuery); err != nil {
+		return 0, err
+	}
+	var version int
+	if err := m.db.QueryRowContext(ctx, schemaVersionQuery).Scan(&version); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+func (m *Migrator) Check(ctx context.Context) error {
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	switch {
+	case version < m.Latest():
+		return fmt.Errorf("%w: version %d, expected %d", ErrSchemaBehind, version, m.Latest())
+	case version > m.Latest():
+		return fmt.Errorf("%w: version %d, expected %d", ErrSchemaAhead, version, m.Latest())
+	}
+	return nil
+}
+
+func (m *Migrator) Up(ctx context.Context) ([]*Migration, error) {
+	var applied []*Migration
+	for {
+		migration, err := m.step(ctx, func(version int) *Migration {
+			if version < len(m.migrations) {
+				return m.migrations[version]
+			}
+			return nil
+		}, func(tx *sql.Tx, migration *Migration) error {
+			if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx,
+				"INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name)
+			return err
+		})
+		if err != nil || migration == nil {
+			return applied, err
+		}
+		applied = append(applied, migration)
+	}
+}
+
+func (m *Migrator) Down(ctx context.Context, steps int) ([]*Migration, error) {
+	var reverted []*Migration
+	for len(reverted) < steps {
+		migration, err := m.step(ctx, func(version int) *Migration {
+			if version > 0 && version <= len(m.migrations) {
+				return m.migrations[version-1]
+			}
+			return nil
+		}, func(tx *sql.Tx, migration *Migration) error {
+			if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version)
+			return err
+		})
+		if err != nil || migration == nil {
+			return reverted, err
+		}
+		reverted = append(reverted, migration)
+	}
+	return reverted, nil
+}
+
+func (m *Migrator) step(
+	ctx context.Context,
+	next func(version int) *Migration,
+	apply func(tx *sql.Tx, migration *Migration) error,
+) (*Migration, error) {
+	if _, err := m.db.ExecContext(ctx, schemaMigrationsQuery); err != nil {
+		return nil, err
+	}
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, schemaLockQuery); err != nil {
+		return nil, err
+	}
+	var version int
+	if err := tx.QueryRowContext(ctx, schemaVersionQuery).Scan(&version); err != nil {
+		return nil, err
+	}
+	migration := next(version)
+	if migration == nil {
+		return nil, nil
+	}
+	if err := apply(tx, migration); err != nil {
+		return nil, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return migration, nil
+}
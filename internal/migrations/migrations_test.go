@@ -0,0 +1,150 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func TestLoad_Embedded(t *testing.T) {
+	migrations, err := Load(embedded)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	for i, migration := range migrations {
+		assert.Equal(t, i+1, migration.Version)
+		assert.NotEmpty(t, migration.Up)
+		assert.NotEmpty(t, migration.Down)
+	}
+}
+
+func TestLoad_OrdersByVersion(t *testing.T) {
+	migrations, err := Load(fstest.MapFS{
+		"sql/0002_second.up.sql":   {Data: []byte("SELECT 2")},
+		"sql/0002_second.down.sql": {Data: []byte("SELECT -2")},
+		"sql/0001_first.up.sql":    {Data: []byte("SELECT 1")},
+		"sql/0001_first.down.sql":  {Data: []byte("SELECT -1")},
+	})
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, &Migration{Version: 1, Name: "first", Up: "SELECT 1", Down: "SELECT -1"}, migrations[0])
+	assert.Equal(t, &Migration{Version: 2, Name: "second", Up: "SELECT 2", Down: "SELECT -2"}, migrations[1])
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{
+			name: "missing down",
+			fsys: fstest.MapFS{"sql/0001_first.up.sql": {Data: []byte("SELECT 1")}},
+		},
+		{
+			name: "gap in versions",
+			fsys: fstest.MapFS{
+				"sql/0001_first.up.sql":   {Data: []byte("SELECT 1")},
+				"sql/0001_first.down.sql": {Data: []byte("SELECT -1")},
+				"sql/0003_third.up.sql":   {Data: []byte("SELECT 3")},
+				"sql/0003_third.down.sql": {Data: []byte("SELECT -3")},
+			},
+		},
+		{
+			name: "mismatched names",
+			fsys: fstest.MapFS{
+				"sql/0001_first.up.sql":   {Data: []byte("SELECT 1")},
+				"sql/0001_other.down.sql": {Data: []byte("SELECT -1")},
+			},
+		},
+		{
+			name: "unexpected file",
+			fsys: fstest.MapFS{"sql/first.sql": {Data: []byte("SELECT 1")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.fsys)
+			assert.ErrorIs(t, err, ErrInvalidMigration)
+		})
+	}
+}
+
+func runPostgresContainer(ctx context.Context) (testcontainers.Container, *sql.DB, error) {
+	req := testcontainers.ContainerRequest{
+		Image:        "postgres:13",
+		ExposedPorts: []string{"5432/tcp"},
+		Env: map[string]string{
+			"POSTGRES_USER":     "testuser",
+			"POSTGRES_PASSWORD": "testpassword",
+			"POSTGRES_DB":       "testdb",
+		},
+		WaitingFor: wait.ForLog("database system is ready to accept connections").
+			WithOccurrence(2).WithStartupTimeout(time.Minute),
+	}
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	host, err := container.Host(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	port, err := container.MappedPort(ctx, "5432")
+	if err != nil {
+		return nil, nil, err
+	}
+	db, err := sql.Open("pgx", "postgres://testuser:testpassword@"+host+":"+port.Port()+"/testdb?sslmode=disable")
+	if err != nil {
+		return nil, nil, err
+	}
+	return container, db, nil
+}
+
+func TestMigrator_UpDown(t *testing.T) {
+	ctx := context.Background()
+	container, db, err := runPostgresContainer(ctx)
+	require.NoError(t, err)
+	defer container.Terminate(ctx)
+	defer db.Close()
+
+	migrator, err := NewMigrator(db)
+	require.NoError(t, err)
+	assert.ErrorIs(t, migrator.Check(ctx), ErrSchemaBehind)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, migrator.Latest())
+	require.NoError(t, migrator.Check(ctx))
+	_, err = db.ExecContext(ctx, "INSERT INTO metrics (id, type, delta) VALUES ('PollCount', 'counter', 1)")
+	require.NoError(t, err)
+
+	applied, err = migrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	reverted, err := migrator.Down(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, reverted, 1)
+	assert.Equal(t, migrator.Latest(), reverted[0].Version)
+	version, err := migrator.Version(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, migrator.Latest()-1, version)
+	assert.ErrorIs(t, migrator.Check(ctx), ErrSchemaBehind)
+
+	reverted, err = migrator.Down(ctx, migrator.Latest())
+	require.NoError(t, err)
+	assert.Len(t, reverted, migrator.Latest()-1)
+	var exists bool
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT to_regclass('metrics') IS NOT NULL").Scan(&exists))
+	assert.False(t, exists)
+}
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS metrics (
+	id VARCHAR(255) NOT NULL,
+	type VARCHAR(255) NOT NULL,
+	labels TEXT NOT NULL DEFAULT '',
+	delta BIGINT,
+	value DOUBLE PRECISION,
+	PRIMARY KEY (id, type, labels)
+);
+
+DO $$
+BEGIN
+	IF NOT EXISTS (
+		SELECT 1 FROM information_schema.columns
+		WHERE table_name = 'metrics' AND column_name = 'labels'
+	) THEN
+		ALTER TABLE metrics ADD COLUMN labels TEXT NOT NULL DEFAULT '';
+		ALTER TABLE metrics DROP CONSTRAINT metrics_pkey;
+		ALTER TABLE metrics ADD PRIMARY KEY (id, type, labels);
+	END IF;
+END $$;
@@ -0,0 +1,2 @@
+ALTER TABLE metrics ADD COLUMN IF NOT EXISTS histogram JSONB;
+ALTER TABLE metrics ADD COLUMN IF NOT EXISTS summary JSONB;
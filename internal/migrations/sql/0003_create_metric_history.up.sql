@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS metric_history (
+	id VARCHAR(255) NOT NULL,
+	type VARCHAR(255) NOT NULL,
+	labels TEXT NOT NULL DEFAULT '',
+	delta BIGINT,
+	value DOUBLE PRECISION,
+	histogram JSONB,
+	summary JSONB,
+	ts TIMESTAMPTZ NOT NULL
+);
+
+ALTER TABLE metric_history ADD COLUMN IF NOT EXISTS histogram JSONB;
+ALTER TABLE metric_history ADD COLUMN IF NOT EXISTS summary JSONB;
+
+CREATE INDEX IF NOT EXISTS metric_history_id_ts_idx ON metric_history (id, type, labels, ts);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS metric_history_ts_idx;
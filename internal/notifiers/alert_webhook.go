@@ -0,0 +1,47 @@
+package notifiers
+
+import (
+	"context"
+	"fmt"
+	"go-metrics/internal/domain"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const AlertWebhookTimeout = 10 * time.Second
+
+type AlertWebhookPayload struct {
+	Alerts []*domain.Alert `json:"alerts"`
+}
+
+type AlertWebhookNotifier struct {
+	url    string
+	client *resty.Client
+}
+
+func NewAlertWebhookNotifier(url string) *AlertWebhookNotifier {
+	return &AlertWebhookNotifier{
+		url:    url,
+		client: resty.New().SetTimeout(AlertWebhookTimeout),
+	}
+}
+
+func (n *AlertWebhookNotifier) Notify(ctx context.Context, alerts []*domain.Alert) error {
+	if n.url == "" || len(alerts) == 0 {
+		return nil
+	}
+	resp, err := n.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(&AlertWebhookPayload{Alerts: alerts}).
+		Post(n.url)
+	if err != nil {
+		return fmt.Errorf("failed to send alert notification: %w", err)
+	}
+	if resp.StatusCode() < http.StatusOK || resp.StatusCode() >= http.StatusMultipleChoices {
+		return fmt.Errorf("failed to send alert notification, status code: %d", resp.StatusCode())
+	}
+	return nil
+}
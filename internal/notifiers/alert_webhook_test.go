@@ -0,0 +1,52 @@
+package notifiers
+
+import (
+	"context"
+	"encoding/json"
+	"go-metrics/internal/domain"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertWebhookNotifier_Notify(t *testing.T) {
+	var received AlertWebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	value := 6e8
+	alert := &domain.Alert{
+		AlertID: domain.AlertID{Name: "heap", Labels: domain.NewLabels(map[string]string{"host": "a"})},
+		Expr:    "HeapAlloc > 5e8",
+		State:   domain.AlertFiring,
+		Value:   &value,
+	}
+	err := NewAlertWebhookNotifier(server.URL).Notify(context.Background(), []*domain.Alert{alert})
+	require.NoError(t, err)
+	require.Len(t, received.Alerts, 1)
+	assert.Equal(t, alert.AlertID, received.Alerts[0].AlertID)
+	assert.Equal(t, domain.AlertFiring, received.Alerts[0].State)
+	assert.Equal(t, value, *received.Alerts[0].Value)
+}
+
+func TestAlertWebhookNotifier_Notify_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := NewAlertWebhookNotifier(server.URL).Notify(context.Background(), []*domain.Alert{{State: domain.AlertFiring}})
+	assert.Error(t, err)
+}
+
+func TestAlertWebhookNotifier_Notify_Disabled(t *testing.T) {
+	assert.NoError(t, NewAlertWebhookNotifier("").Notify(context.Background(), []*domain.Alert{{State: domain.AlertFiring}}))
+}
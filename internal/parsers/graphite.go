@@ -0,0 +1,57 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidGraphiteLine = errors.New("invalid graphite line")
+
+type GraphiteSample struct {
+	Name      string
+	Labels    map[string]string
+	Value     float64
+	Timestamp time.Time
+}
+
+func ParseGraphiteLine(line string) (*GraphiteSample, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || len(fields) > 3 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidGraphiteLine, line)
+	}
+	path, tags, _ := strings.Cut(fields[0], ";")
+	if path == "" {
+		return nil, fmt.Errorf("%w: missing path in %q", ErrInvalidGraphiteLine, line)
+	}
+	sample := &GraphiteSample{Name: SanitizeMetricID(path)}
+	if tags != "" {
+		sample.Labels = make(map[string]string)
+		for _, tag := range strings.Split(tags, ";") {
+			name, value, found := strings.Cut(tag, "=")
+			name = SanitizeLabelName(name)
+			if !found || name == "" || value == "" {
+				return nil, fmt.Errorf("%w: invalid tag %q", ErrInvalidGraphiteLine, tag)
+			}
+			sample.Labels[name] = value
+		}
+	}
+	value, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, fmt.Errorf("%w: invalid value in %q", ErrInvalidGraphiteLine, line)
+	}
+	sample.Value = value
+	if len(fields) == 3 {
+		ts, err := strconv.ParseFloat(fields[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid timestamp in %q", ErrInvalidGraphiteLine, line)
+		}
+		if ts > 0 {
+			sample.Timestamp = time.Unix(0, int64(ts*float64(time.Second)))
+		}
+	}
+	return sample, nil
+}
@@ -0,0 +1,69 @@
+package parsers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGraphiteLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected *GraphiteSample
+	}{
+		{
+			name:     "plain",
+			line:     "servers.web-1.cpu.load 0.75 1700000000",
+			expected: &GraphiteSample{Name: "servers_web_1_cpu_load", Value: 0.75, Timestamp: time.Unix(1700000000, 0)},
+		},
+		{
+			name:     "without timestamp",
+			line:     "disk.used 42",
+			expected: &GraphiteSample{Name: "disk_used", Value: 42},
+		},
+		{
+			name:     "negative timestamp means now",
+			line:     "disk.used 42 -1",
+			expected: &GraphiteSample{Name: "disk_used", Value: 42},
+		},
+		{
+			name: "tags",
+			line: "disk.used;host=h1;dc.name=eu 1.5 1700000000",
+			expected: &GraphiteSample{
+				Name:      "disk_used",
+				Labels:    map[string]string{"host": "h1", "dc_name": "eu"},
+				Value:     1.5,
+				Timestamp: time.Unix(1700000000, 0),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sample, err := ParseGraphiteLine(tt.line)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, sample)
+		})
+	}
+}
+
+func TestParseGraphiteLine_Invalid(t *testing.T) {
+	for _, line := range []string{
+		"",
+		"disk.used",
+		"disk.used 1 2 3",
+		";host=h1 1",
+		"disk.used;host 1",
+		"disk.used abc",
+		"disk.used NaN",
+		"disk.used 1 yesterday",
+	} {
+		t.Run(line, func(t *testing.T) {
+			sample, err := ParseGraphiteLine(line)
+			assert.Nil(t, sample)
+			assert.ErrorIs(t, err, ErrInvalidGraphiteLine)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type InfluxFieldType string
+
+const (
+	InfluxFloat    InfluxFieldType = "float"
+	InfluxInteger  InfluxFieldType = "integer"
+	InfluxUnsigned InfluxFieldType = "unsigned"
+	InfluxBoolean  InfluxFieldType = "boolean"
+	InfluxString   InfluxFieldType = "string"
+)
+
+var ErrInvalidInfluxLine = errors.New("invalid line protocol")
+
+var influxPrecisions = map[string]time.Duration{
+	"":   time.Nanosecond,
+	"n":  time.Nanosecond,
+	"ns": time.Nanosecond,
+	"u":  time.Microsecond,
+	"us": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+}
+
+var influxKeyUnescaper = strings.NewReplacer(`\,`, ",", `\ `, " ", `\=`, "=")
+
+var influxStringUnescaper = strings.NewReplacer(`\"`, `"`, `\\`, `\`)
+
+type InfluxField struct {
+	Key    string
+	Type   InfluxFieldType
+	Float  float64
+	Int    int64
+	Bool   bool
+	String string
+}
+
+type InfluxPoint struct {
+	Measurement string
+	Tags        map[string]string
+	Fields      []*InfluxField
+	Timestamp   time.Time
+}
+
+func ParseInfluxPrecision(precision string) (time.Duration, error) {
+	unit, ok := influxPrecisions[precision]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown precision %q", ErrInvalidInfluxLine, precision)
+	}
+	return unit, nil
+}
+
+func ParseInfluxLines(data string, precision time.Duration) ([]*InfluxPoint, error) {
+	var points []*InfluxPoint
+	for i, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		point, err := ParseInfluxLine(line, precision)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+func ParseInfluxLine(line string, precision time.Duration) (*InfluxPoint, error) {
+	series, rest, _, err := cutInflux(line, ' ', false)
+	if err != nil {
+		return nil, err
+	}
+	fields, timestamp, _, err := cutInflux(strings.TrimLeft(rest, " "), ' ', true)
+	if err != nil {
+		return nil, err
+	}
+	if fields == "" {
+		return nil, fmt.Errorf("%w: missing fields", ErrInvalidInfluxLine)
+	}
+	point := &InfluxPoint{}
+	if err := parseInfluxSeries(point, series); err != nil {
+		return nil, err
+	}
+	if err := parseInfluxFields(point, fields); err != nil {
+		return nil, err
+	}
+	if timestamp = strings.TrimSpace(timestamp); timestamp != "" {
+		ts, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid timestamp %q", ErrInvalidInfluxLine, timestamp)
+		}
+		point.Timestamp = time.Unix(0, ts*int64(precision))
+	}
+	return point, nil
+}
+
+func parseInfluxSeries(point *InfluxPoint, series string) error {
+	parts, err := splitInflux(series, ',', false)
+	if err != nil {
+		return err
+	}
+	point.Measurement = influxKeyUnescaper.Replace(parts[0])
+	if point.Measurement == "" {
+		return fmt.Errorf("%w: missing measurement", ErrInvalidInfluxLine)
+	}
+	for _, tag := range parts[1:] {
+		key, value, found, err := cutInflux(tag, '=', false)
+		if err != nil {
+			return err
+		}
+		if !found || key == "" || value == "" {
+			return fmt.Errorf("%w: invalid tag %q", ErrInvalidInfluxLine, tag)
+		}
+		if point.Tags == nil {
+			point.Tags = make(map[string]string)
+		}
+		point.Tags[influxKeyUnescaper.Replace(key)] = influxKeyUnescaper.Replace(value)
+	}
+	return nil
+}
+
+func parseInfluxFields(point *InfluxPoint, fields string) error {
+	parts, err := splitInflux(fields, ',', true)
+	if err != nil {
+		return err
+	}
+	for _, part := range parts {
+		key, value, found, err := cutInflux(part, '=', false)
+		if err != nil {
+			return err
+		}
+		if !found || key == "" || value == "" {
+			return fmt.Errorf("%w: invalid field %q", ErrInvalidInfluxLine, part)
+		}
+		field, err := parseInfluxFieldValue(value)
+		if err != nil {
+			return err
+		}
+		field.Key = influxKeyUnescaper.Replace(key)
+		point.Fields = append(point.Fields, field)
+	}
+	return nil
+}
+
+func parseInfluxFieldValue(raw string) (*InfluxField, error) {
+	switch {
+	case raw[0] == '"':
+		if len(raw) < 2 || raw[len(raw)-1] != '"' {
+			return nil, fmt.Errorf("%w: invalid string field %q", ErrInvalidInfluxLine, raw)
+		}
+		return &InfluxField{Type: InfluxString, String: influxStringUnescaper.Replace(raw[1 : len(raw)-1])}, nil
+	case strings.HasSuffix(raw, "i"):
+		value, err := strconv.ParseInt(raw[:len(raw)-1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid integer field %q", ErrInvalidInfluxLine, raw)
+		}
+		return &InfluxField{Type: InfluxInteger, Int: value}, nil
+	case strings.HasSuffix(raw, "u"):
+		value, err := strconv.ParseUint(raw[:len(raw)-1], 10, 64)
+		if err != nil || value > math.MaxInt64 {
+			return nil, fmt.Errorf("%w: invalid unsigned field %q", ErrInvalidInfluxLine, raw)
+		}
+		return &InfluxField{Type: InfluxUnsigned, Int: int64(value)}, nil
+	}
+	switch raw {
+	case "t", "T", "true", "True", "TRUE":
+		return &InfluxField{Type: InfluxBoolean, Bool: true}, nil
+	case "f", "F", "false", "False", "FALSE":
+		return &InfluxField{Type: InfluxBoolean, Bool: false}, nil
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, fmt.Errorf("%w: invalid float field %q", ErrInvalidInfluxLine, raw)
+	}
+	return &InfluxField{Type: InfluxFloat, Float: value}, nil
+}
+
+func splitInflux(s string, sep byte, quotes bool) ([]string, error) {
+	var parts []string
+	for {
+		before, after, found, err := cutInflux(s, sep, quotes)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, before)
+		if !found {
+			return parts, nil
+		}
+		s = after
+	}
+}
+
+func cutInflux(s string, sep byte, quotes bool) (before, after string, found bool, err error) {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\':
+			i++
+		case c == '"' && quotes:
+			quoted = !quoted
+		case c == sep && !quoted:
+			return s[:i], s[i+1:], true, nil
+		}
+	}
+	if quoted {
+		return "", "", false, fmt.Errorf("%w: unterminated string in %q", ErrInvalidInfluxLine, s)
+	}
+	return s, "", false, nil
+}
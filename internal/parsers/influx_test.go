@@ -0,0 +1,81 @@
+package parsers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInfluxLine(t *testing.T) {
+	point, err := ParseInfluxLine(
+		`cpu\ load,host=server\ 1,region=us\,west usage=0.64,cores=8i,total=10u,up=t,note="a \"b\", c=d" 1700000000`,
+		time.Second,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, &InfluxPoint{
+		Measurement: "cpu load",
+		Tags:        map[string]string{"host": "server 1", "region": "us,west"},
+		Fields: []*InfluxField{
+			{Key: "usage", Type: InfluxFloat, Float: 0.64},
+			{Key: "cores", Type: InfluxInteger, Int: 8},
+			{Key: "total", Type: InfluxUnsigned, Int: 10},
+			{Key: "up", Type: InfluxBoolean, Bool: true},
+			{Key: "note", Type: InfluxString, String: `a "b", c=d`},
+		},
+		Timestamp: time.Unix(1700000000, 0),
+	}, point)
+}
+
+func TestParseInfluxLine_WithoutTagsAndTimestamp(t *testing.T) {
+	point, err := ParseInfluxLine("mem used=1e3", time.Nanosecond)
+	require.NoError(t, err)
+	assert.Equal(t, "mem", point.Measurement)
+	assert.Nil(t, point.Tags)
+	assert.Equal(t, []*InfluxField{{Key: "used", Type: InfluxFloat, Float: 1000}}, point.Fields)
+	assert.True(t, point.Timestamp.IsZero())
+}
+
+func TestParseInfluxLine_Invalid(t *testing.T) {
+	for _, line := range []string{
+		"cpu",
+		"cpu ",
+		",host=a value=1",
+		"cpu,host value=1",
+		"cpu,host= value=1",
+		"cpu value",
+		"cpu value=",
+		"cpu value=abc",
+		"cpu value=1x",
+		"cpu value=\"unterminated",
+		"cpu value=18446744073709551615u",
+		"cpu value=1 notatime",
+	} {
+		t.Run(line, func(t *testing.T) {
+			point, err := ParseInfluxLine(line, time.Nanosecond)
+			assert.Nil(t, point)
+			assert.ErrorIs(t, err, ErrInvalidInfluxLine)
+		})
+	}
+}
+
+func TestParseInfluxLines(t *testing.T) {
+	points, err := ParseInfluxLines("# comment\ncpu value=1\n\nmem value=2i\n", time.Nanosecond)
+	require.NoError(t, err)
+	require.Len(t, points, 2)
+	assert.Equal(t, "cpu", points[0].Measurement)
+	assert.Equal(t, "mem", points[1].Measurement)
+
+	_, err = ParseInfluxLines("cpu value=1\ncpu", time.Nanosecond)
+	assert.ErrorIs(t, err, ErrInvalidInfluxLine)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestParseInfluxPrecision(t *testing.T) {
+	unit, err := ParseInfluxPrecision("ms")
+	require.NoError(t, err)
+	assert.Equal(t, time.Millisecond, unit)
+	_, err = ParseInfluxPrecision("weeks")
+	assert.ErrorIs(t, err, ErrInvalidInfluxLine)
+}
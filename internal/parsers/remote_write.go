@@ -0,0 +1,138 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/klauspost/compress/snappy"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const MaxRemoteWriteDecodedBytes = 32 << 20
+
+const (
+	remoteWriteTimeSeriesField = 1
+	timeSeriesLabelField       = 1
+	timeSeriesSampleField      = 2
+	labelNameField             = 1
+	labelValueField            = 2
+	sampleValueField           = 1
+	sampleTimestampField       = 2
+)
+
+var ErrInvalidRemoteWrite = errors.New("invalid remote write request")
+
+type RemoteWriteSample struct {
+	Value     float64
+	Timestamp int64
+}
+
+type RemoteWriteSeries struct {
+	Labels  map[string]string
+	Samples []*RemoteWriteSample
+}
+
+func ParseRemoteWriteRequest(compressed []byte) ([]*RemoteWriteSeries, error) {
+	size, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRemoteWrite, err)
+	}
+	if size > MaxRemoteWriteDecodedBytes {
+		return nil, fmt.Errorf("%w: decoded size %d exceeds limit", ErrInvalidRemoteWrite, size)
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRemoteWrite, err)
+	}
+	var series []*RemoteWriteSeries
+	err = walkProtoMessage(data, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if num != remoteWriteTimeSeriesField || typ != protowire.BytesType {
+			return nil
+		}
+		s, err := parseRemoteWriteSeries(value)
+		if err != nil {
+			return err
+		}
+		series = append(series, s)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRemoteWrite, err)
+	}
+	return series, nil
+}
+
+func parseRemoteWriteSeries(data []byte) (*RemoteWriteSeries, error) {
+	series := &RemoteWriteSeries{Labels: make(map[string]string)}
+	err := walkProtoMessage(data, func(num protowire.Number, typ protowire.Type, value []byte) error {
+		if typ != protowire.BytesType {
+			return nil
+		}
+		switch num {
+		case timeSeriesLabelField:
+			var name, labelValue string
+			err := walkProtoMessage(value, func(num protowire.Number, typ protowire.Type, value []byte) error {
+				if typ != protowire.BytesType {
+					return nil
+				}
+				switch num {
+				case labelNameField:
+					name = string(value)
+				case labelValueField:
+					labelValue = string(value)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			series.Labels[name] = labelValue
+		case timeSeriesSampleField:
+			sample := &RemoteWriteSample{}
+			err := walkProtoMessage(value, func(num protowire.Number, typ protowire.Type, value []byte) error {
+				switch {
+				case num == sampleValueField && typ == protowire.Fixed64Type:
+					bits, _ := protowire.ConsumeFixed64(value)
+					sample.Value = math.Float64frombits(bits)
+				case num == sampleTimestampField && typ == protowire.VarintType:
+					v, _ := protowire.ConsumeVarint(value)
+					sample.Timestamp = int64(v)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			series.Samples = append(series.Samples, sample)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
+
+func walkProtoMessage(data []byte, visit func(num protowire.Number, typ protowire.Type, value []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		data = data[n:]
+		m := protowire.ConsumeFieldValue(num, typ, data)
+		if m < 0 {
+			return protowire.ParseError(m)
+		}
+		value := data[:m]
+		if typ == protowire.BytesType {
+			value, _ = protowire.ConsumeBytes(value)
+		}
+		if err := visit(num, typ, value); err != nil {
+			return err
+		}
+		data = data[m:]
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package parsers
+
+import (
+	"math"
+	"testing"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func appendRemoteWriteSeries(b []byte, labels [][2]string, samples []*RemoteWriteSample) []byte {
+	var series []byte
+	for _, label := range labels {
+		var l []byte
+		l = protowire.AppendTag(l, labelNameField, protowire.BytesType)
+		l = protowire.AppendString(l, label[0])
+		l = protowire.AppendTag(l, labelValueField, protowire.BytesType)
+		l = protowire.AppendString(l, label[1])
+		series = protowire.AppendTag(series, timeSeriesLabelField, protowire.BytesType)
+		series = protowire.AppendBytes(series, l)
+	}
+	for _, sample := range samples {
+		var s []byte
+		s = protowire.AppendTag(s, sampleValueField, protowire.Fixed64Type)
+		s = protowire.AppendFixed64(s, math.Float64bits(sample.Value))
+		s = protowire.AppendTag(s, sampleTimestampField, protowire.VarintType)
+		s = protowire.AppendVarint(s, uint64(sample.Timestamp))
+		series = protowire.AppendTag(series, timeSeriesSampleField, protowire.BytesType)
+		series = protowire.AppendBytes(series, s)
+	}
+	b = protowire.AppendTag(b, remoteWriteTimeSeriesField, protowire.BytesType)
+	return protowire.AppendBytes(b, series)
+}
+
+func TestParseRemoteWriteRequest(t *testing.T) {
+	var data []byte
+	data = appendRemoteWriteSeries(data,
+		[][2]string{{"__name__", "up"}, {"job", "node"}},
+		[]*RemoteWriteSample{{Value: 1, Timestamp: 1700000000000}, {Value: 0, Timestamp: 1700000015000}},
+	)
+	data = appendRemoteWriteSeries(data, [][2]string{{"__name__", "empty"}}, nil)
+	data = protowire.AppendTag(data, 3, protowire.BytesType)
+	data = protowire.AppendString(data, "metadata is ignored")
+
+	series, err := ParseRemoteWriteRequest(snappy.Encode(nil, data))
+	require.NoError(t, err)
+	assert.Equal(t, []*RemoteWriteSeries{
+		{
+			Labels:  map[string]string{"__name__": "up", "job": "node"},
+			Samples: []*RemoteWriteSample{{Value: 1, Timestamp: 1700000000000}, {Value: 0, Timestamp: 1700000015000}},
+		},
+		{Labels: map[string]string{"__name__": "empty"}},
+	}, series)
+}
+
+func TestParseRemoteWriteRequest_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		body []byte
+	}{
+		{name: "not snappy", body: []byte("plain text")},
+		{name: "truncated protobuf", body: snappy.Encode(nil, []byte{0x0a, 0x05, 0x0a})},
+		{name: "decoded size over limit", body: protowire.AppendVarint(nil, MaxRemoteWriteDecodedBytes+1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRemoteWriteRequest(tt.body)
+			assert.ErrorIs(t, err, ErrInvalidRemoteWrite)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package parsers
+
+import "regexp"
+
+var invalidNameChars = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+func SanitizeMetricID(name string) string {
+	return invalidNameChars.ReplaceAllString(name, "_")
+}
+
+func SanitizeLabelName(name string) string {
+	name = invalidNameChars.ReplaceAllString(name, "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
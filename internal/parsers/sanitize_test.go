@@ -0,0 +1,19 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitizeMetricID(t *testing.T) {
+	assert.Equal(t, "api_requests_count", SanitizeMetricID("api.requests.count"))
+	assert.Equal(t, "a_b", SanitizeMetricID("a..-b"))
+	assert.Equal(t, "Heap_Alloc1", SanitizeMetricID("Heap_Alloc1"))
+}
+
+func TestSanitizeLabelName(t *testing.T) {
+	assert.Equal(t, "host_name", SanitizeLabelName("host.name"))
+	assert.Equal(t, "_1st", SanitizeLabelName("1st"))
+	assert.Equal(t, "", SanitizeLabelName(""))
+}
@@ -0,0 +1,107 @@
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type StatsDType string
+
+const (
+	StatsDCounter      StatsDType = "c"
+	StatsDGauge        StatsDType = "g"
+	StatsDTimer        StatsDType = "ms"
+	StatsDHistogram    StatsDType = "h"
+	StatsDDistribution StatsDType = "d"
+	StatsDSet          StatsDType = "s"
+)
+
+var ErrInvalidStatsDLine = errors.New("invalid statsd line")
+
+type StatsDSample struct {
+	Name       string
+	Labels     map[string]string
+	Type       StatsDType
+	Value      float64
+	Member     string
+	Relative   bool
+	SampleRate float64
+}
+
+func ParseStatsDPacket(packet []byte) ([]*StatsDSample, error) {
+	var samples []*StatsDSample
+	var errs []error
+	for _, line := range strings.Split(string(packet), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		sample, err := ParseStatsDLine(line)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	return samples, errors.Join(errs...)
+}
+
+func ParseStatsDLine(line string) (*StatsDSample, error) {
+	name, rest, found := strings.Cut(line, ":")
+	if !found || name == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidStatsDLine, line)
+	}
+	fields := strings.Split(rest, "|")
+	if len(fields) < 2 || fields[0] == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidStatsDLine, line)
+	}
+	sample := &StatsDSample{
+		Name:       SanitizeMetricID(name),
+		Type:       StatsDType(fields[1]),
+		SampleRate: 1,
+	}
+	for _, field := range fields[2:] {
+		switch {
+		case strings.HasPrefix(field, "@"):
+			rate, err := strconv.ParseFloat(field[1:], 64)
+			if err != nil || rate <= 0 || rate > 1 {
+				return nil, fmt.Errorf("%w: invalid sample rate in %q", ErrInvalidStatsDLine, line)
+			}
+			sample.SampleRate = rate
+		case strings.HasPrefix(field, "#"):
+			sample.Labels = parseStatsDTags(field[1:])
+		}
+	}
+	switch sample.Type {
+	case StatsDSet:
+		sample.Member = fields[0]
+		return sample, nil
+	case StatsDGauge:
+		sample.Relative = fields[0][0] == '+' || fields[0][0] == '-'
+	case StatsDCounter, StatsDTimer, StatsDHistogram, StatsDDistribution:
+	default:
+		return nil, fmt.Errorf("%w: unknown type in %q", ErrInvalidStatsDLine, line)
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, fmt.Errorf("%w: invalid value in %q", ErrInvalidStatsDLine, line)
+	}
+	sample.Value = value
+	return sample, nil
+}
+
+func parseStatsDTags(s string) map[string]string {
+	labels := make(map[string]string)
+	for _, tag := range strings.Split(s, ",") {
+		name, value, _ := strings.Cut(tag, ":")
+		name = SanitizeLabelName(name)
+		if name == "" {
+			continue
+		}
+		labels[name] = value
+	}
+	return labels
+}
@@ -0,0 +1,90 @@
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatsDLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		expected *StatsDSample
+	}{
+		{
+			name:     "counter",
+			line:     "api.requests:1|c",
+			expected: &StatsDSample{Name: "api_requests", Type: StatsDCounter, Value: 1, SampleRate: 1},
+		},
+		{
+			name:     "counter with sample rate",
+			line:     "hits:2|c|@0.1",
+			expected: &StatsDSample{Name: "hits", Type: StatsDCounter, Value: 2, SampleRate: 0.1},
+		},
+		{
+			name:     "gauge",
+			line:     "load:3.2|g",
+			expected: &StatsDSample{Name: "load", Type: StatsDGauge, Value: 3.2, SampleRate: 1},
+		},
+		{
+			name:     "relative gauge",
+			line:     "queue:-4|g",
+			expected: &StatsDSample{Name: "queue", Type: StatsDGauge, Value: -4, Relative: true, SampleRate: 1},
+		},
+		{
+			name:     "timer",
+			line:     "db.query:320|ms|@0.5",
+			expected: &StatsDSample{Name: "db_query", Type: StatsDTimer, Value: 320, SampleRate: 0.5},
+		},
+		{
+			name:     "set",
+			line:     "users:alice|s",
+			expected: &StatsDSample{Name: "users", Type: StatsDSet, Member: "alice", SampleRate: 1},
+		},
+		{
+			name: "tags",
+			line: "hits:1|c|#env:prod,host.name:h1",
+			expected: &StatsDSample{
+				Name: "hits", Type: StatsDCounter, Value: 1, SampleRate: 1,
+				Labels: map[string]string{"env": "prod", "host_name": "h1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sample, err := ParseStatsDLine(tt.line)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, sample)
+		})
+	}
+}
+
+func TestParseStatsDLine_Invalid(t *testing.T) {
+	for _, line := range []string{
+		"hits",
+		":1|c",
+		"hits:1",
+		"hits:|c",
+		"hits:abc|c",
+		"hits:1|x",
+		"hits:1|c|@0",
+		"hits:1|c|@2",
+		"hits:NaN|g",
+	} {
+		t.Run(line, func(t *testing.T) {
+			sample, err := ParseStatsDLine(line)
+			assert.Nil(t, sample)
+			assert.ErrorIs(t, err, ErrInvalidStatsDLine)
+		})
+	}
+}
+
+func TestParseStatsDPacket(t *testing.T) {
+	samples, err := ParseStatsDPacket([]byte("a:1|c\n\nbad\nb:2|g\n"))
+	assert.ErrorIs(t, err, ErrInvalidStatsDLine)
+	require.Len(t, samples, 2)
+	assert.Equal(t, "a", samples[0].Name)
+	assert.Equal(t, "b", samples[1].Name)
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"sort"
+	"sync"
+)
+
+type AlertMemoryRepository struct {
+	data map[domain.AlertID]*domain.Alert
+	mu   sync.Mutex
+}
+
+func NewAlertMemoryRepository() *AlertMemoryRepository {
+	return &AlertMemoryRepository{
+		data: make(map[domain.AlertID]*domain.Alert),
+	}
+}
+
+func (repo *AlertMemoryRepository) Save(ctx context.Context, alerts []*domain.Alert) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	for _, alert := range alerts {
+		repo.data[alert.AlertID] = alert.Clone()
+	}
+	return nil
+}
+
+func (repo *AlertMemoryRepository) Find(ctx context.Context) ([]*domain.Alert, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	alerts := make([]*domain.Alert, 0, len(repo.data))
+	for _, alert := range repo.data {
+		alerts = append(alerts, alert.Clone())
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Name != alerts[j].Name {
+			return alerts[i].Name < alerts[j].Name
+		}
+		return alerts[i].Labels < alerts[j].Labels
+	})
+	return alerts, nil
+}
@@ -0,0 +1,33 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertMemoryRepository_SaveAndFind(t *testing.T) {
+	repo := NewAlertMemoryRepository()
+	value := 10.0
+	heap := &domain.Alert{AlertID: domain.AlertID{Name: "heap"}, State: domain.AlertPending, Value: &value}
+	cpu := &domain.Alert{AlertID: domain.AlertID{Name: "cpu"}, State: domain.AlertFiring}
+	require.NoError(t, repo.Save(context.Background(), []*domain.Alert{heap, cpu}))
+	value = 20
+
+	alerts, err := repo.Find(context.Background())
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, "cpu", alerts[0].Name)
+	assert.Equal(t, "heap", alerts[1].Name)
+	assert.Equal(t, 10.0, *alerts[1].Value)
+
+	heap.State = domain.AlertFiring
+	require.NoError(t, repo.Save(context.Background(), []*domain.Alert{heap}))
+	alerts, err = repo.Find(context.Background())
+	require.NoError(t, err)
+	require.Len(t, alerts, 2)
+	assert.Equal(t, domain.AlertFiring, alerts[1].State)
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"go-metrics/internal/domain"
+	"os"
+)
+
+type AlertRuleFileRepository struct {
+	path string
+}
+
+func NewAlertRuleFileRepository(path string) *AlertRuleFileRepository {
+	return &AlertRuleFileRepository{path: path}
+}
+
+func (repo *AlertRuleFileRepository) Find(ctx context.Context) ([]*domain.AlertRule, error) {
+	if repo.path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(repo.path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []domain.AlertRule
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	rules := make([]*domain.AlertRule, 0, len(entries))
+	for _, entry := range entries {
+		rule, err := domain.NewAlertRule(entry.Name, entry.Expr)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
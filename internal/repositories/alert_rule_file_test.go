@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertRuleFileRepository_Find(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"name": "heap", "expr": "HeapAlloc > 5e8 for 2m"},
+		{"name": "stalled", "expr": "rate(PollCount) == 0 for 1m"}
+	]`), 0666))
+	rules, err := NewAlertRuleFileRepository(path).Find(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "heap", rules[0].Name)
+	assert.Equal(t, 5e8, rules[0].Threshold)
+	assert.Equal(t, 2*time.Minute, rules[0].For)
+	assert.Equal(t, domain.AlertFunctionRate, rules[1].Function)
+	assert.Equal(t, "PollCount", rules[1].Metric)
+}
+
+func TestAlertRuleFileRepository_Find_InvalidRule(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"name": "bad", "expr": "HeapAlloc ~ 1"}]`), 0666))
+	rules, err := NewAlertRuleFileRepository(path).Find(context.Background())
+	assert.Nil(t, rules)
+	assert.Equal(t, domain.ErrInvalidAlertRule, err)
+}
+
+func TestAlertRuleFileRepository_Find_NoPath(t *testing.T) {
+	rules, err := NewAlertRuleFileRepository("").Find(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, rules)
+}
+
+func TestAlertRuleFileRepository_Find_MissingFile(t *testing.T) {
+	_, err := NewAlertRuleFileRepository(filepath.Join(t.TempDir(), "missing.json")).Find(context.Background())
+	assert.Error(t, err)
+}
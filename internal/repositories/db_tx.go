@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"go-metrics/internal/unitofworks"
+)
+
+type dbExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func dbExecutorFromContext(ctx context.Context, db *sql.DB) dbExecutor {
+	if tx := unitofworks.TxFromContext(ctx); tx != nil {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/unitofworks"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBRepositories_ConcurrentCounterUpdates(t *testing.T) {
+	ctx := context.Background()
+	postgresContainer, db, err := runPostgresContainer2(ctx)
+	require.NoError(t, err)
+	defer postgresContainer.Terminate(ctx)
+
+	findRepo := NewMetricDBFindRepository(db)
+	saveRepo := NewMetricDBSaveRepository(db)
+	uow := unitofworks.NewDBUnitOfWork(db)
+	id := domain.MetricID{ID: "PollCount", Type: domain.Counter}
+
+	const updates = 50
+	var wg sync.WaitGroup
+	errs := make(chan error, updates)
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- uow.Do(ctx, func(ctx context.Context) error {
+				existing, err := findRepo.Find(ctx, []*domain.MetricID{&id})
+				if err != nil {
+					return err
+				}
+				delta := int64(1)
+				if metric, ok := existing[id]; ok {
+					delta += *metric.Delta
+				}
+				return saveRepo.Save(ctx, []*domain.Metric{{MetricID: id, Delta: &delta}})
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	result, err := findRepo.Find(ctx, []*domain.MetricID{&id})
+	require.NoError(t, err)
+	require.Contains(t, result, id)
+	assert.Equal(t, int64(updates), *result[id].Delta)
+}
+
+func TestBuildMetricLockKeys(t *testing.T) {
+	keys := buildMetricLockKeys([]*domain.MetricID{
+		{ID: "metric-1", Type: domain.Counter},
+		{ID: "metric-1", Type: domain.Counter, Labels: domain.NewLabels(map[string]string{"host": "h1"})},
+	})
+	assert.Equal(t, []string{"metric-1\x00counter\x00", "metric-1\x00counter\x00host=\"h1\""}, keys)
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"go-metrics/internal/domain"
+	"os"
+)
+
+type DerivedMetricRuleFileRepository struct {
+	path string
+}
+
+func NewDerivedMetricRuleFileRepository(path string) *DerivedMetricRuleFileRepository {
+	return &DerivedMetricRuleFileRepository{path: path}
+}
+
+func (repo *DerivedMetricRuleFileRepository) Find(ctx context.Context) ([]*domain.DerivedMetricRule, error) {
+	if repo.path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(repo.path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []domain.DerivedMetricRule
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+	rules := make([]*domain.DerivedMetricRule, 0, len(entries))
+	for _, entry := range entries {
+		rule, err := domain.NewDerivedMetricRule(entry.Name, entry.Expr)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDerivedMetricRuleFileRepository_Find(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "derived.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"name": "HeapUtilization", "expr": "HeapAlloc / HeapSys"},
+		{"expr": "AllocRate = rate(TotalAlloc[1m])"}
+	]`), 0666))
+	rules, err := NewDerivedMetricRuleFileRepository(path).Find(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.Equal(t, "HeapUtilization", rules[0].Name)
+	assert.Equal(t, []string{"HeapAlloc", "HeapSys"}, rules[0].References())
+	assert.Equal(t, "AllocRate", rules[1].Name)
+	assert.Equal(t, "rate(TotalAlloc[1m])", rules[1].Expr)
+}
+
+func TestDerivedMetricRuleFileRepository_Find_InvalidRule(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "derived.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"name": "bad", "expr": "HeapAlloc /"}]`), 0666))
+	rules, err := NewDerivedMetricRuleFileRepository(path).Find(context.Background())
+	assert.Nil(t, rules)
+	assert.ErrorIs(t, err, domain.ErrInvalidDerivedMetric)
+}
+
+func TestDerivedMetricRuleFileRepository_Find_NoPath(t *testing.T) {
+	rules, err := NewDerivedMetricRuleFileRepository("").Find(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, rules)
+}
@@ -0,0 +1,140 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"go-metrics/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bulkMetrics(n int, delta int64) []*domain.Metric {
+	metrics := make([]*domain.Metric, 0, n)
+	for i := 0; i < n; i++ {
+		d := delta + int64(i)
+		metrics = append(metrics, &domain.Metric{
+			MetricID: domain.MetricID{ID: fmt.Sprintf("metric%d", i), Type: domain.Counter},
+			Delta:    &d,
+		})
+	}
+	return metrics
+}
+
+func TestDedupeMetrics_LastWins(t *testing.T) {
+	first := int64(1)
+	second := int64(2)
+	gauge := 3.5
+	metrics := []*domain.Metric{
+		{MetricID: domain.MetricID{ID: "a", Type: domain.Counter}, Delta: &first},
+		{MetricID: domain.MetricID{ID: "b", Type: domain.Gauge}, Value: &gauge},
+		{MetricID: domain.MetricID{ID: "a", Type: domain.Counter}, Delta: &second},
+	}
+	result := dedupeMetrics(metrics)
+	require.Len(t, result, 2)
+	assert.Equal(t, metrics[2], result[0])
+	assert.Equal(t, metrics[1], result[1])
+}
+
+func TestNewMetricDBColumns(t *testing.T) {
+	delta := int64(5)
+	value := 1.5
+	metrics := []*domain.Metric{
+		{MetricID: domain.MetricID{ID: "a", Type: domain.Counter}, Delta: &delta},
+		{
+			MetricID: domain.MetricID{ID: "b", Type: domain.Summary, Labels: domain.NewLabels(map[string]string{"host": "h1"})},
+			Summary:  &domain.SummaryValue{Count: 1, Sum: value},
+		},
+		{MetricID: domain.MetricID{ID: "c", Type: domain.Gauge}, Value: &value},
+	}
+	columns, err := newMetricDBColumns(metrics)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, columns.ids)
+	assert.Equal(t, []string{"counter", "summary", "gauge"}, columns.types)
+	assert.Equal(t, []string{"", `host="h1"`, ""}, columns.labels)
+	assert.Equal(t, []*int64{&delta, nil, nil}, columns.deltas)
+	assert.Equal(t, []*float64{nil, nil, &value}, columns.values)
+	assert.Equal(t, []*string{nil, nil, nil}, columns.histograms)
+	require.NotNil(t, columns.summaries[1])
+	assert.Nil(t, columns.summaries[0])
+	assert.Len(t, columns.args(), 7)
+}
+
+func TestDBSave_BulkRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	postgresContainer, db, err := runPostgresContainer(ctx)
+	require.NoError(t, err)
+	defer postgresContainer.Terminate(ctx)
+
+	saveRepo := NewMetricDBSaveRepository(db)
+	findRepo := NewMetricDBFindRepository(db)
+	metrics := bulkMetrics(40000, 1)
+	require.NoError(t, saveRepo.Save(ctx, metrics))
+	require.NoError(t, saveRepo.Save(ctx, bulkMetrics(40000, 100)))
+
+	filters := make([]*domain.MetricID, 0, len(metrics))
+	for _, metric := range metrics {
+		filters = append(filters, &metric.MetricID)
+	}
+	result, err := findRepo.Find(ctx, filters)
+	require.NoError(t, err)
+	assert.Len(t, result, len(metrics))
+	assert.Equal(t, int64(100), *result[metrics[0].MetricID].Delta)
+	assert.Equal(t, int64(40099), *result[metrics[39999].MetricID].Delta)
+}
+
+func BenchmarkNewMetricDBColumns_10k(b *testing.B) {
+	metrics := bulkMetrics(10000, 1)
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := newMetricDBColumns(metrics); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMetricDBSaveRepository_Save_10k(b *testing.B) {
+	ctx := context.Background()
+	postgresContainer, db, err := runPostgresContainer(ctx)
+	if err != nil {
+		b.Skip("postgres container is unavailable:", err)
+	}
+	defer postgresContainer.Terminate(ctx)
+	repository := NewMetricDBSaveRepository(db)
+	metrics := bulkMetrics(10000, 1)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		if err := repository.Save(ctx, metrics); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(len(metrics)*b.N)/b.Elapsed().Seconds(), "metrics/s")
+}
+
+func BenchmarkMetricDBFindRepository_Find_10k(b *testing.B) {
+	ctx := context.Background()
+	postgresContainer, db, err := runPostgresContainer(ctx)
+	if err != nil {
+		b.Skip("postgres container is unavailable:", err)
+	}
+	defer postgresContainer.Terminate(ctx)
+	metrics := bulkMetrics(10000, 1)
+	if err := NewMetricDBSaveRepository(db).Save(ctx, metrics); err != nil {
+		b.Fatal(err)
+	}
+	repository := NewMetricDBFindRepository(db)
+	filters := make([]*domain.MetricID, 0, len(metrics))
+	for _, metric := range metrics {
+		filters = append(filters, &metric.MetricID)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		if _, err := repository.Find(ctx, filters); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(len(filters)*b.N)/b.Elapsed().Seconds(), "metrics/s")
+}
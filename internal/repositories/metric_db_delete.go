@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"go-metrics/internal/domain"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+type MetricDBDeleteRepository struct {
+	db *sql.DB
+}
+
+func NewMetricDBDeleteRepository(db *sql.DB) *MetricDBDeleteRepository {
+	return &MetricDBDeleteRepository{db: db}
+}
+
+var metricDeleteQuery = `
+	DELETE FROM metrics m
+	USING unnest($1::text[], $2::text[], $3::text[]) AS f(id, type, labels)
+	WHERE m.id = f.id AND m.type = f.type AND m.labels = f.labels;
+`
+
+func (repo *MetricDBDeleteRepository) Delete(ctx context.Context, ids []*domain.MetricID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := dbExecutorFromContext(ctx, repo.db).ExecContext(ctx, metricDeleteQuery, metricIDArgs(ids)...)
+	return err
+}
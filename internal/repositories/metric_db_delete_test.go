@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBDelete_Metrics(t *testing.T) {
+	ctx := context.Background()
+	postgresContainer, db, err := runPostgresContainer(ctx)
+	require.NoError(t, err)
+	defer postgresContainer.Terminate(ctx)
+
+	saveRepo := NewMetricDBSaveRepository(db)
+	findRepo := NewMetricDBFindRepository(db)
+	deleteRepo := NewMetricDBDeleteRepository(db)
+	labeled := domain.MetricID{ID: "CPU", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "h1"})}
+	plain := domain.MetricID{ID: "CPU", Type: domain.Gauge}
+	value := 1.5
+	require.NoError(t, saveRepo.Save(ctx, []*domain.Metric{
+		{MetricID: labeled, Value: &value},
+		{MetricID: plain, Value: &value},
+	}))
+
+	require.NoError(t, deleteRepo.Delete(ctx, []*domain.MetricID{&labeled, {ID: "missing", Type: domain.Gauge}}))
+	result, err := findRepo.Find(ctx, nil)
+	require.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Contains(t, result, plain)
+}
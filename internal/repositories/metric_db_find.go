@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"go-metrics/internal/domain"
+	"go-metrics/internal/unitofworks"
 	"strings"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
@@ -18,42 +19,158 @@ func NewMetricDBFindRepository(db *sql.DB) *MetricDBFindRepository {
 	return &MetricDBFindRepository{db: db}
 }
 
-var baseMetricFindQuery = "SELECT id, type, delta, value FROM metrics"
+var metricLockQuery = `
+	SELECT pg_advisory_xact_lock(h) FROM (
+		SELECT hashtextextended(k, 0) AS h FROM unnest($1::text[]) AS k ORDER BY h
+	) AS keys;
+`
+
+var baseMetricFindQuery = "SELECT id, type, labels, delta, value, histogram, summary, updated_at FROM metrics"
+
+var metricFindFilterJoin = " JOIN unnest($1::text[], $2::text[], $3::text[]) AS f(id, type, labels) USING (id, type, labels)"
 
 func buildMetricFindQuery(filters []*domain.MetricID) (string, []any) {
-	var sb strings.Builder
-	sb.WriteString(baseMetricFindQuery)
-	args := make([]any, 0, len(filters)*2)
-	if len(filters) > 0 {
-		sb.WriteString(" WHERE ")
-		for i, filter := range filters {
-			if i > 0 {
-				sb.WriteString(" OR ")
-			}
-			sb.WriteString(fmt.Sprintf("(id = $%d AND type = $%d)", i*2+1, i*2+2))
-			args = append(args, filter.ID, filter.Type)
-		}
+	if len(filters) == 0 {
+		return baseMetricFindQuery, []any{}
 	}
-	return sb.String(), args
+	return baseMetricFindQuery + metricFindFilterJoin, metricIDArgs(filters)
+}
+
+func metricIDArgs(filters []*domain.MetricID) []any {
+	ids := make([]string, 0, len(filters))
+	types := make([]string, 0, len(filters))
+	labels := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		ids = append(ids, filter.ID)
+		types = append(types, string(filter.Type))
+		labels = append(labels, string(filter.Labels))
+	}
+	return []any{ids, types, labels}
+}
+
+func buildMetricLockKeys(filters []*domain.MetricID) []string {
+	keys := make([]string, 0, len(filters))
+	for _, filter := range filters {
+		keys = append(keys, filter.ID+"\x00"+string(filter.Type)+"\x00"+string(filter.Labels))
+	}
+	return keys
 }
 
 func (repo *MetricDBFindRepository) Find(ctx context.Context, filters []*domain.MetricID) (map[domain.MetricID]*domain.Metric, error) {
 	result := make(map[domain.MetricID]*domain.Metric)
+	executor := dbExecutorFromContext(ctx, repo.db)
+	if tx := unitofworks.TxFromContext(ctx); tx != nil && len(filters) > 0 {
+		if _, err := tx.ExecContext(ctx, metricLockQuery, buildMetricLockKeys(filters)); err != nil {
+			return nil, err
+		}
+	}
 	query, args := buildMetricFindQuery(filters)
-	rows, err := repo.db.QueryContext(ctx, query, args...)
+	rows, err := executor.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var metric domain.Metric
-		if err := rows.Scan(&metric.ID, &metric.Type, &metric.Delta, &metric.Value); err != nil {
+		metric, err := scanMetricRow(rows)
+		if err != nil {
 			return nil, err
 		}
-		result[domain.MetricID{ID: metric.ID, Type: metric.Type}] = &metric
+		result[metric.MetricID] = metric
 	}
-	if rows.Err() != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 	return result, nil
 }
+
+var metricLabelValuePattern = `(?:^|,)%s="((?:[^"\\]|\\.)*)"`
+
+var metricLabelUnescapeQuery = `COALESCE((SELECT string_agg(COALESCE(CASE m[1] WHEN 'n' THEN E'\n' ELSE m[1] END, m[2]), '' ORDER BY n)` +
+	` FROM regexp_matches(%s, '\\(.)|([^\\]+)', 'g') WITH ORDINALITY AS t(m, n)), '')`
+
+var metricLikeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func buildMetricPageQuery(query *domain.MetricQuery) (string, []any, error) {
+	var sb strings.Builder
+	var args []any
+	arg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	sb.WriteString(baseMetricFindQuery)
+	sb.WriteString(" WHERE TRUE")
+	if query.Type != "" {
+		sb.WriteString(" AND type = " + arg(string(query.Type)))
+	}
+	if query.Prefix != "" {
+		sb.WriteString(" AND id LIKE " + arg(metricLikeEscaper.Replace(query.Prefix)+"%") + ` ESCAPE '\'`)
+	}
+	if query.Regexp != nil {
+		pattern, err := domain.POSIXRegexp(query.Regexp.String())
+		if err != nil {
+			return "", nil, err
+		}
+		sb.WriteString(" AND id ~ " + arg(pattern))
+	}
+	for _, matcher := range query.Labels {
+		value := "COALESCE(substring(labels from " + arg(fmt.Sprintf(metricLabelValuePattern, matcher.Name)) + "::text), '')"
+		switch matcher.Operator {
+		case domain.LabelMatchEqual:
+			sb.WriteString(" AND " + value + " = " + arg(matcher.EscapedValue()))
+		case domain.LabelMatchNotEqual:
+			sb.WriteString(" AND " + value + " <> " + arg(matcher.EscapedValue()))
+		case domain.LabelMatchRegexp:
+			sb.WriteString(" AND " + fmt.Sprintf(metricLabelUnescapeQuery, value) + " ~ " + arg(matcher.POSIXValue()))
+		case domain.LabelMatchNotRegexp:
+			sb.WriteString(" AND " + fmt.Sprintf(metricLabelUnescapeQuery, value) + " !~ " + arg(matcher.POSIXValue()))
+		}
+	}
+	columns := []string{`id COLLATE "C"`, `type COLLATE "C"`, `labels COLLATE "C"`}
+	if query.SortBy == domain.MetricSortByType {
+		columns[0], columns[1] = columns[1], columns[0]
+	}
+	direction, comparison := "ASC", ">"
+	if query.Desc {
+		direction, comparison = "DESC", "<"
+	}
+	if query.After != nil {
+		key := query.SortKey(*query.After)
+		sb.WriteString(fmt.Sprintf(" AND (%s) %s (%s, %s, %s)",
+			strings.Join(columns, ", "), comparison, arg(key[0]), arg(key[1]), arg(key[2])))
+	}
+	sb.WriteString(" ORDER BY ")
+	for i, column := range columns {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(column + " " + direction)
+	}
+	if query.Limit > 0 {
+		sb.WriteString(" LIMIT " + arg(query.Limit+1))
+	}
+	return sb.String(), args, nil
+}
+
+func (repo *MetricDBFindRepository) FindPage(ctx context.Context, query *domain.MetricQuery) (*domain.MetricPage, error) {
+	sqlQuery, args, err := buildMetricPageQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := dbExecutorFromContext(ctx, repo.db).QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	metrics := make([]*domain.Metric, 0)
+	for rows.Next() {
+		metric, err := scanMetricRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, metric)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return domain.NewMetricPage(metrics, query.Limit), nil
+}
@@ -3,7 +3,9 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"go-metrics/internal/domain"
+	"regexp"
 	"time"
 
 	"testing"
@@ -19,7 +21,7 @@ import (
 func TestBuildMetricFindQuery_EmptyFilters(t *testing.T) {
 	filters := []*domain.MetricID{}
 	query, args := buildMetricFindQuery(filters)
-	expectedQuery := "SELECT id, type, delta, value FROM metrics"
+	expectedQuery := "SELECT id, type, labels, delta, value, histogram, summary, updated_at FROM metrics"
 	expectedArgs := []any{}
 	assert.Equal(t, expectedQuery, query)
 	assert.Equal(t, expectedArgs, args)
@@ -30,8 +32,8 @@ func TestBuildMetricFindQuery_OneFilter(t *testing.T) {
 		{ID: "metric-1", Type: domain.Counter},
 	}
 	query, args := buildMetricFindQuery(filters)
-	expectedQuery := "SELECT id, type, delta, value FROM metrics WHERE (id = $1 AND type = $2)"
-	expectedArgs := []any{"metric-1", domain.Counter}
+	expectedQuery := "SELECT id, type, labels, delta, value, histogram, summary, updated_at FROM metrics JOIN unnest($1::text[], $2::text[], $3::text[]) AS f(id, type, labels) USING (id, type, labels)"
+	expectedArgs := []any{[]string{"metric-1"}, []string{"counter"}, []string{""}}
 	assert.Equal(t, expectedQuery, query)
 	assert.Equal(t, expectedArgs, args)
 }
@@ -39,11 +41,11 @@ func TestBuildMetricFindQuery_OneFilter(t *testing.T) {
 func TestBuildMetricFindQuery_MultipleFilters(t *testing.T) {
 	filters := []*domain.MetricID{
 		{ID: "metric-1", Type: domain.Counter},
-		{ID: "metric-2", Type: domain.Gauge},
+		{ID: "metric-2", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "h1"})},
 	}
 	query, args := buildMetricFindQuery(filters)
-	expectedQuery := "SELECT id, type, delta, value FROM metrics WHERE (id = $1 AND type = $2) OR (id = $3 AND type = $4)"
-	expectedArgs := []any{"metric-1", domain.Counter, "metric-2", domain.Gauge}
+	expectedQuery := "SELECT id, type, labels, delta, value, histogram, summary, updated_at FROM metrics JOIN unnest($1::text[], $2::text[], $3::text[]) AS f(id, type, labels) USING (id, type, labels)"
+	expectedArgs := []any{[]string{"metric-1", "metric-2"}, []string{"counter", "gauge"}, []string{"", `host="h1"`}}
 	assert.Equal(t, expectedQuery, query)
 	assert.Equal(t, expectedArgs, args)
 }
@@ -110,9 +112,12 @@ func runPostgresContainer2(ctx context.Context) (testcontainers.Container, *sql.
 	CREATE TABLE IF NOT EXISTS metrics (
 		id TEXT NOT NULL,
 		type TEXT NOT NULL,
+		labels TEXT NOT NULL DEFAULT '',
 		delta INT,
 		value FLOAT,
-		PRIMARY KEY (id, type)
+		histogram JSONB,
+		summary JSONB,
+		PRIMARY KEY (id, type, labels)
 	);
 	`)
 	if err != nil {
@@ -199,3 +204,56 @@ func TestFind_EmptyResults(t *testing.T) {
 	// Validate the results
 	assert.Len(t, result, 0)
 }
+
+func TestBuildMetricPageQuery_Defaults(t *testing.T) {
+	query, args, err := buildMetricPageQuery(&domain.MetricQuery{})
+	require.NoError(t, err)
+	expectedQuery := `SELECT id, type, labels, delta, value, histogram, summary, updated_at FROM metrics WHERE TRUE` +
+		` ORDER BY id COLLATE "C" ASC, type COLLATE "C" ASC, labels COLLATE "C" ASC`
+	assert.Equal(t, expectedQuery, query)
+	assert.Empty(t, args)
+}
+
+func TestBuildMetricPageQuery_AllFilters(t *testing.T) {
+	host, err := domain.NewLabelMatcher("host", domain.LabelMatchEqual, `h"1`)
+	require.NoError(t, err)
+	dc, err := domain.NewLabelMatcher("dc", domain.LabelMatchNotRegexp, "eu-.*")
+	require.NoError(t, err)
+	query, args, err := buildMetricPageQuery(&domain.MetricQuery{
+		Type:   domain.Gauge,
+		Prefix: "Heap_",
+		Regexp: regexp.MustCompile(domain.AnchorRegexp("Heap.*")),
+		Labels: []*domain.LabelMatcher{host, dc},
+		SortBy: domain.MetricSortByType,
+		Desc:   true,
+		After:  &domain.MetricID{ID: "HeapAlloc", Type: domain.Gauge},
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	expectedQuery := `SELECT id, type, labels, delta, value, histogram, summary, updated_at FROM metrics WHERE TRUE` +
+		` AND type = $1` +
+		` AND id LIKE $2 ESCAPE '\'` +
+		` AND id ~ $3` +
+		` AND COALESCE(substring(labels from $4::text), '') = $5` +
+		` AND ` + fmt.Sprintf(metricLabelUnescapeQuery, `COALESCE(substring(labels from $6::text), '')`) + ` !~ $7` +
+		` AND (type COLLATE "C", id COLLATE "C", labels COLLATE "C") < ($8, $9, $10)` +
+		` ORDER BY type COLLATE "C" DESC, id COLLATE "C" DESC, labels COLLATE "C" DESC` +
+		` LIMIT $11`
+	assert.Equal(t, expectedQuery, query)
+	assert.Equal(t, []any{
+		"gauge",
+		`Heap\_%`,
+		`^Heap[^\n]*$`,
+		`(?:^|,)host="((?:[^"\\]|\\.)*)"`,
+		`h\"1`,
+		`(?:^|,)dc="((?:[^"\\]|\\.)*)"`,
+		`^eu-[^\n]*$`,
+		"gauge", "HeapAlloc", "",
+		11,
+	}, args)
+}
+
+func TestBuildMetricPageQuery_UnsupportedRegexp(t *testing.T) {
+	_, _, err := buildMetricPageQuery(&domain.MetricQuery{Regexp: regexp.MustCompile(`\bHeap`)})
+	assert.Equal(t, domain.ErrUnsupportedRegexp, err)
+}
@@ -0,0 +1,105 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"go-metrics/internal/domain"
+	"time"
+)
+
+func marshalMetricJSON(metric *domain.Metric) (histogram *string, summary *string, err error) {
+	if metric.Histogram != nil {
+		data, err := json.Marshal(metric.Histogram)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := string(data)
+		histogram = &value
+	}
+	if metric.Summary != nil {
+		data, err := json.Marshal(metric.Summary)
+		if err != nil {
+			return nil, nil, err
+		}
+		value := string(data)
+		summary = &value
+	}
+	return histogram, summary, nil
+}
+
+type metricDBColumns struct {
+	ids        []string
+	types      []string
+	labels     []string
+	deltas     []*int64
+	values     []*float64
+	histograms []*string
+	summaries  []*string
+	updatedAts []*time.Time
+}
+
+func newMetricDBColumns(metrics []*domain.Metric) (*metricDBColumns, error) {
+	columns := &metricDBColumns{
+		ids:        make([]string, 0, len(metrics)),
+		types:      make([]string, 0, len(metrics)),
+		labels:     make([]string, 0, len(metrics)),
+		deltas:     make([]*int64, 0, len(metrics)),
+		values:     make([]*float64, 0, len(metrics)),
+		histograms: make([]*string, 0, len(metrics)),
+		summaries:  make([]*string, 0, len(metrics)),
+		updatedAts: make([]*time.Time, 0, len(metrics)),
+	}
+	for _, metric := range metrics {
+		histogram, summary, err := marshalMetricJSON(metric)
+		if err != nil {
+			return nil, err
+		}
+		columns.ids = append(columns.ids, metric.ID)
+		columns.types = append(columns.types, string(metric.Type))
+		columns.labels = append(columns.labels, string(metric.Labels))
+		columns.deltas = append(columns.deltas, metric.Delta)
+		columns.values = append(columns.values, metric.Value)
+		columns.histograms = append(columns.histograms, histogram)
+		columns.summaries = append(columns.summaries, summary)
+		var updatedAt *time.Time
+		if !metric.UpdatedAt.IsZero() {
+			updatedAt = &metric.UpdatedAt
+		}
+		columns.updatedAts = append(columns.updatedAts, updatedAt)
+	}
+	return columns, nil
+}
+
+func (c *metricDBColumns) args() []any {
+	return []any{c.ids, c.types, c.labels, c.deltas, c.values, c.histograms, c.summaries, c.updatedAts}
+}
+
+func scanMetricRow(rows *sql.Rows) (*domain.Metric, error) {
+	var metric domain.Metric
+	var histogram, summary []byte
+	var updatedAt sql.NullTime
+	if err := rows.Scan(&metric.ID, &metric.Type, &metric.Labels, &metric.Delta, &metric.Value, &histogram, &summary, &updatedAt); err != nil {
+		return nil, err
+	}
+	if err := unmarshalMetricJSON(&metric, histogram, summary); err != nil {
+		return nil, err
+	}
+	metric.UpdatedAt = updatedAt.Time
+	return &metric, nil
+}
+
+func unmarshalMetricJSON(metric *domain.Metric, histogram []byte, summary []byte) error {
+	if histogram != nil {
+		metric.Histogram = &domain.HistogramValue{}
+		if err := json.Unmarshal(histogram, metric.Histogram); err != nil {
+			return err
+		}
+	}
+	if summary != nil {
+		metric.Summary = &domain.SummaryValue{}
+		if err := json.Unmarshal(summary, metric.Summary); err != nil {
+			return err
+		}
+	}
+	return nil
+}
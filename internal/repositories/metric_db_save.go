@@ -17,23 +17,37 @@ func NewMetricDBSaveRepository(db *sql.DB) *MetricDBSaveRepository {
 }
 
 var metricSaveQuery = `
-	INSERT INTO metrics (id, type, delta, value) 
-	VALUES ($1, $2, $3, $4) 
-	ON CONFLICT (id, type) DO UPDATE 
-	SET delta = EXCLUDED.delta, value = EXCLUDED.value;
+	INSERT INTO metrics (id, type, labels, delta, value, histogram, summary, updated_at)
+	SELECT id, type, labels, delta, value, histogram::jsonb, summary::jsonb, updated_at
+	FROM unnest($1::text[], $2::text[], $3::text[], $4::bigint[], $5::float8[], $6::text[], $7::text[], $8::timestamptz[])
+		AS m(id, type, labels, delta, value, histogram, summary, updated_at)
+	ON CONFLICT (id, type, labels) DO UPDATE
+	SET delta = EXCLUDED.delta, value = EXCLUDED.value,
+		histogram = EXCLUDED.histogram, summary = EXCLUDED.summary, updated_at = EXCLUDED.updated_at;
 `
 
+func dedupeMetrics(metrics []*domain.Metric) []*domain.Metric {
+	positions := make(map[domain.MetricID]int, len(metrics))
+	result := make([]*domain.Metric, 0, len(metrics))
+	for _, metric := range metrics {
+		if i, exists := positions[metric.MetricID]; exists {
+			result[i] = metric
+			continue
+		}
+		positions[metric.MetricID] = len(result)
+		result = append(result, metric)
+	}
+	return result
+}
+
 func (repo *MetricDBSaveRepository) Save(ctx context.Context, metrics []*domain.Metric) error {
-	stmt, err := repo.db.PrepareContext(ctx, metricSaveQuery)
+	if len(metrics) == 0 {
+		return nil
+	}
+	columns, err := newMetricDBColumns(dedupeMetrics(metrics))
 	if err != nil {
 		return err
 	}
-	defer stmt.Close()
-	for _, metric := range metrics {
-		_, err := stmt.ExecContext(ctx, metric.ID, metric.Type, metric.Delta, metric.Value)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
+	_, err = dbExecutorFromContext(ctx, repo.db).ExecContext(ctx, metricSaveQuery, columns.args()...)
+	return err
 }
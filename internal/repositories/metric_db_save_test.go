@@ -76,9 +76,12 @@ func runPostgresContainer(ctx context.Context) (testcontainers.Container, *sql.D
 	CREATE TABLE IF NOT EXISTS metrics (
 		id TEXT NOT NULL,
 		type TEXT NOT NULL,
+		labels TEXT NOT NULL DEFAULT '',
 		delta INT,
 		value FLOAT,
-		PRIMARY KEY (id, type)
+		histogram JSONB,
+		summary JSONB,
+		PRIMARY KEY (id, type, labels)
 	);
 	`)
 	if err != nil {
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+)
+
+type MetricFileDeleteRepository struct {
+	storage *MetricFileStorage
+}
+
+func NewMetricFileDeleteRepository(storage *MetricFileStorage) *MetricFileDeleteRepository {
+	return &MetricFileDeleteRepository{storage: storage}
+}
+
+func (repo *MetricFileDeleteRepository) Delete(ctx context.Context, ids []*domain.MetricID) error {
+	return repo.storage.Delete(ids)
+}
@@ -1,30 +1,27 @@
 package repositories
 
 import (
-	"bufio"
-	"bytes"
 	"context"
-	"encoding/json"
 	"go-metrics/internal/domain"
-	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+func newTestMetricFileFindRepository(t *testing.T, metrics ...*domain.Metric) *MetricFileFindRepository {
+	storage, err := NewMetricFileStorage(filepath.Join(t.TempDir(), "metrics.json"), 0, true, true)
+	require.NoError(t, err)
+	t.Cleanup(func() { storage.Close() })
+	require.NoError(t, storage.Append(metrics))
+	return NewMetricFileFindRepository(storage)
+}
+
 func TestMetricFileFindRepository_Find_WithFilters(t *testing.T) {
 	metric1 := &domain.Metric{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}}
 	metric2 := &domain.Metric{MetricID: domain.MetricID{ID: "2", Type: domain.Gauge}}
-	var buf bytes.Buffer
-	encoder := json.NewEncoder(&buf)
-	encoder.Encode(metric1)
-	encoder.Encode(metric2)
-	tmpFile, err := os.CreateTemp("", "metrics_test_*.json")
-	assert.NoError(t, err)
-	defer os.Remove(tmpFile.Name())
-	tmpFile.Write(buf.Bytes())
-	scanner := bufio.NewScanner(tmpFile)
-	repo := NewMetricFileFindRepository(tmpFile, scanner)
+	repo := newTestMetricFileFindRepository(t, metric1, metric2)
 	filters := []*domain.MetricID{
 		&metric1.MetricID,
 	}
@@ -38,16 +35,7 @@ func TestMetricFileFindRepository_Find_WithFilters(t *testing.T) {
 func TestMetricFileFindRepository_Find_WithoutFilters(t *testing.T) {
 	metric1 := &domain.Metric{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}}
 	metric2 := &domain.Metric{MetricID: domain.MetricID{ID: "2", Type: domain.Gauge}}
-	var buf bytes.Buffer
-	encoder := json.NewEncoder(&buf)
-	encoder.Encode(metric1)
-	encoder.Encode(metric2)
-	tmpFile, err := os.CreateTemp("", "metrics_test_*.json")
-	assert.NoError(t, err)
-	defer os.Remove(tmpFile.Name())
-	tmpFile.Write(buf.Bytes())
-	scanner := bufio.NewScanner(tmpFile)
-	repo := NewMetricFileFindRepository(tmpFile, scanner)
+	repo := newTestMetricFileFindRepository(t, metric1, metric2)
 	result, err := repo.Find(context.Background(), nil)
 	assert.NoError(t, err)
 	assert.Len(t, result, 2)
@@ -56,12 +44,18 @@ func TestMetricFileFindRepository_Find_WithoutFilters(t *testing.T) {
 }
 
 func TestMetricFileFindRepository_Find_EmptyFile(t *testing.T) {
-	tmpFile, err := os.CreateTemp("", "metrics_test_*.json")
-	assert.NoError(t, err)
-	defer os.Remove(tmpFile.Name())
-	scanner := bufio.NewScanner(tmpFile)
-	repo := NewMetricFileFindRepository(tmpFile, scanner)
+	repo := newTestMetricFileFindRepository(t)
 	result, err := repo.Find(context.Background(), nil)
 	assert.NoError(t, err)
 	assert.Len(t, result, 0)
 }
+
+func TestMetricFileFindRepository_Find_WithLabels(t *testing.T) {
+	h1 := &domain.Metric{MetricID: domain.MetricID{ID: "CPU", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "h1"})}}
+	h2 := &domain.Metric{MetricID: domain.MetricID{ID: "CPU", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "h2"})}}
+	repo := newTestMetricFileFindRepository(t, h1, h2)
+	result, err := repo.Find(context.Background(), []*domain.MetricID{&h2.MetricID})
+	assert.NoError(t, err)
+	assert.Len(t, result, 1)
+	assert.Contains(t, result, h2.MetricID)
+}
@@ -2,34 +2,30 @@ package repositories
 
 import (
 	"context"
-	"encoding/json"
 	"go-metrics/internal/domain"
-	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMetricFileSaveRepository_Save_Success(t *testing.T) {
 	metric1 := &domain.Metric{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}}
 	metric2 := &domain.Metric{MetricID: domain.MetricID{ID: "2", Type: domain.Gauge}}
-	tmpFile, err := os.CreateTemp("", "metrics_test_*.json")
-	assert.NoError(t, err)
-	defer os.Remove(tmpFile.Name())
-	repo := NewMetricFileSaveRepository(tmpFile)
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	storage, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	repo := NewMetricFileSaveRepository(storage)
 	err = repo.Save(context.Background(), []*domain.Metric{metric1, metric2})
 	assert.NoError(t, err)
-	tmpFile.Seek(0, 0)
-	var savedMetrics []*domain.Metric
-	decoder := json.NewDecoder(tmpFile)
-	for {
-		var m domain.Metric
-		if err := decoder.Decode(&m); err != nil {
-			break
-		}
-		savedMetrics = append(savedMetrics, &m)
-	}
+	require.NoError(t, storage.Close())
+
+	reopened, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	defer reopened.Close()
+	savedMetrics := reopened.Find(nil)
 	assert.Len(t, savedMetrics, 2)
-	assert.Equal(t, metric1, savedMetrics[0])
-	assert.Equal(t, metric2, savedMetrics[1])
+	assert.Equal(t, metric1, savedMetrics[metric1.MetricID])
+	assert.Equal(t, metric2, savedMetrics[metric2.MetricID])
 }
@@ -0,0 +1,326 @@
+package repositories
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-metrics/internal/domain"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+const metricFileWALExt = ".wal"
+
+var errMetricFileRecordCorrupted = errors.New("metric file record is corrupted")
+
+type metricFileRecord struct {
+	domain.Metric
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+type MetricFileStorage struct {
+	path        string
+	walPath     string
+	maxWALBytes int64
+	syncWrites  bool
+	wal         *os.File
+	walSize     int64
+	index       map[domain.MetricID]*domain.Metric
+	mu          sync.RWMutex
+}
+
+func NewMetricFileStorage(path string, maxWALBytes int64, syncWrites bool, restore bool) (*MetricFileStorage, error) {
+	storage := &MetricFileStorage{
+		path:        path,
+		walPath:     path + metricFileWALExt,
+		maxWALBytes: maxWALBytes,
+		syncWrites:  syncWrites,
+		index:       make(map[domain.MetricID]*domain.Metric),
+	}
+	var walSize int64
+	if restore {
+		if _, err := storage.load(storage.path); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		size, err := storage.load(storage.walPath)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		walSize = size
+	}
+	wal, err := os.OpenFile(storage.walPath, os.O_CREATE|os.O_RDWR, 0666)
+	if err != nil {
+		return nil, err
+	}
+	if err := wal.Truncate(walSize); err != nil {
+		wal.Close()
+		return nil, err
+	}
+	if _, err := wal.Seek(walSize, io.SeekStart); err != nil {
+		wal.Close()
+		return nil, err
+	}
+	storage.wal = wal
+	storage.walSize = walSize
+	if !restore {
+		if err := storage.compact(); err != nil {
+			wal.Close()
+			return nil, err
+		}
+	}
+	return storage, nil
+}
+
+func (s *MetricFileStorage) Append(metrics []*domain.Metric) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	for _, metric := range metrics {
+		if err := encodeMetricFileRecord(&buf, &metricFileRecord{Metric: *metric}); err != nil {
+			return err
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.write(buf.Bytes()); err != nil {
+		return err
+	}
+	for _, metric := range metrics {
+		s.index[metric.MetricID] = metric
+	}
+	return s.compactIfNeeded()
+}
+
+func (s *MetricFileStorage) Delete(ids []*domain.MetricID) error {
+	var buf bytes.Buffer
+	for _, id := range ids {
+		if id == nil {
+			continue
+		}
+		record := &metricFileRecord{Metric: domain.Metric{MetricID: *id}, Deleted: true}
+		if err := encodeMetricFileRecord(&buf, record); err != nil {
+			return err
+		}
+	}
+	if buf.Len() == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.write(buf.Bytes()); err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if id != nil {
+			delete(s.index, *id)
+		}
+	}
+	return s.compactIfNeeded()
+}
+
+func (s *MetricFileStorage) Find(filters []*domain.MetricID) map[domain.MetricID]*domain.Metric {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	result := make(map[domain.MetricID]*domain.Metric)
+	if len(filters) == 0 {
+		for id, metric := range s.index {
+			result[id] = metric
+		}
+		return result
+	}
+	for _, filter := range filters {
+		if filter == nil {
+			continue
+		}
+		if metric, found := s.index[*filter]; found {
+			result[*filter] = metric
+		}
+	}
+	return result
+}
+
+func (s *MetricFileStorage) Snapshot(metrics []*domain.Metric) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.index = make(map[domain.MetricID]*domain.Metric, len(metrics))
+	for _, metric := range metrics {
+		s.index[metric.MetricID] = metric
+	}
+	return s.compact()
+}
+
+func (s *MetricFileStorage) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.compact()
+}
+
+func (s *MetricFileStorage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.wal.Sync(); err != nil {
+		s.wal.Close()
+		return err
+	}
+	return s.wal.Close()
+}
+
+func (s *MetricFileStorage) write(data []byte) error {
+	if _, err := s.wal.Write(data); err != nil {
+		s.rollback()
+		return err
+	}
+	if s.syncWrites {
+		if err := s.wal.Sync(); err != nil {
+			s.rollback()
+			return err
+		}
+	}
+	s.walSize += int64(len(data))
+	return nil
+}
+
+func (s *MetricFileStorage) compactIfNeeded() error {
+	if s.maxWALBytes > 0 && s.walSize > s.maxWALBytes {
+		return s.compact()
+	}
+	return nil
+}
+
+func (s *MetricFileStorage) compact() error {
+	metrics := make([]*domain.Metric, 0, len(s.index))
+	for _, metric := range s.index {
+		metrics = append(metrics, metric)
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		return metricIDLess(metrics[i].MetricID, metrics[j].MetricID)
+	})
+	dir := filepath.Dir(s.path)
+	tmp, err := os.CreateTemp(dir, filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	writer := bufio.NewWriter(tmp)
+	for _, metric := range metrics {
+		if err = encodeMetricFileRecord(writer, &metricFileRecord{Metric: *metric}); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return err
+	}
+	if err := syncDir(dir); err != nil {
+		return err
+	}
+	if err := s.wal.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := s.wal.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	s.walSize = 0
+	return s.wal.Sync()
+}
+
+func (s *MetricFileStorage) rollback() {
+	s.wal.Truncate(s.walSize)
+	s.wal.Seek(s.walSize, io.SeekStart)
+}
+
+func (s *MetricFileStorage) load(path string) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			return offset, nil
+		}
+		if err != nil {
+			return offset, err
+		}
+		record, err := decodeMetricFileRecord(line)
+		if err != nil {
+			return offset, nil
+		}
+		if record.Deleted {
+			delete(s.index, record.MetricID)
+		} else {
+			s.index[record.MetricID] = &record.Metric
+		}
+		offset += int64(len(line))
+	}
+}
+
+func encodeMetricFileRecord(w io.Writer, record *metricFileRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%08x %s\n", crc32.ChecksumIEEE(data), data)
+	return err
+}
+
+func decodeMetricFileRecord(line []byte) (*metricFileRecord, error) {
+	line = bytes.TrimSuffix(line, []byte("\n"))
+	data := line
+	if len(line) > 9 && line[8] == ' ' {
+		checksum, err := strconv.ParseUint(string(line[:8]), 16, 32)
+		if err != nil {
+			return nil, errMetricFileRecordCorrupted
+		}
+		data = line[9:]
+		if crc32.ChecksumIEEE(data) != uint32(checksum) {
+			return nil, errMetricFileRecordCorrupted
+		}
+	}
+	var record metricFileRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, errMetricFileRecordCorrupted
+	}
+	return &record, nil
+}
+
+func metricIDLess(a, b domain.MetricID) bool {
+	if a.ID != b.ID {
+		return a.ID < b.ID
+	}
+	if a.Type != b.Type {
+		return a.Type < b.Type
+	}
+	return a.Labels < b.Labels
+}
+
+func syncDir(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return file.Sync()
+}
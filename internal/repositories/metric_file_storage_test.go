@@ -0,0 +1,229 @@
+package repositories
+
+import (
+	"encoding/json"
+	"go-metrics/internal/domain"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fileCounter(id string, delta int64) *domain.Metric {
+	return &domain.Metric{MetricID: domain.MetricID{ID: id, Type: domain.Counter}, Delta: &delta}
+}
+
+func TestMetricFileStorage_ReplaysWALAfterRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	storage, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	require.NoError(t, storage.Append([]*domain.Metric{fileCounter("a", 1), fileCounter("b", 2)}))
+	require.NoError(t, storage.Append([]*domain.Metric{fileCounter("a", 3)}))
+	require.NoError(t, storage.Close())
+
+	reopened, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	defer reopened.Close()
+	result := reopened.Find(nil)
+	assert.Len(t, result, 2)
+	assert.Equal(t, int64(3), *result[fileCounter("a", 0).MetricID].Delta)
+	assert.Equal(t, int64(2), *result[fileCounter("b", 0).MetricID].Delta)
+}
+
+func TestMetricFileStorage_CompactWritesSnapshotAndResetsWAL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	storage, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	for i := int64(1); i <= 10; i++ {
+		require.NoError(t, storage.Append([]*domain.Metric{fileCounter("a", i)}))
+	}
+	require.NoError(t, storage.Compact())
+
+	info, err := os.Stat(path + metricFileWALExt)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), info.Size())
+	require.NoError(t, storage.Append([]*domain.Metric{fileCounter("b", 1)}))
+	require.NoError(t, storage.Close())
+
+	reopened, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	defer reopened.Close()
+	result := reopened.Find(nil)
+	assert.Len(t, result, 2)
+	assert.Equal(t, int64(10), *result[fileCounter("a", 0).MetricID].Delta)
+}
+
+func TestMetricFileStorage_CompactsWhenWALExceedsLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	storage, err := NewMetricFileStorage(path, 128, true, true)
+	require.NoError(t, err)
+	defer storage.Close()
+	for i := int64(1); i <= 20; i++ {
+		require.NoError(t, storage.Append([]*domain.Metric{fileCounter("a", i)}))
+	}
+	info, err := os.Stat(path + metricFileWALExt)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, info.Size(), int64(128))
+	_, err = os.Stat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), *storage.Find(nil)[fileCounter("a", 0).MetricID].Delta)
+}
+
+func TestMetricFileStorage_RecoversFromTruncatedRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	storage, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	require.NoError(t, storage.Append([]*domain.Metric{fileCounter("a", 1)}))
+	require.NoError(t, storage.Append([]*domain.Metric{fileCounter("b", 2)}))
+	require.NoError(t, storage.Close())
+
+	walPath := path + metricFileWALExt
+	data, err := os.ReadFile(walPath)
+	require.NoError(t, err)
+	first := len(data) / 2
+	for data[first-1] != '\n' {
+		first--
+	}
+	require.NoError(t, os.WriteFile(walPath, data[:len(data)-5], 0666))
+
+	reopened, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	result := reopened.Find(nil)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(1), *result[fileCounter("a", 0).MetricID].Delta)
+	info, err := os.Stat(walPath)
+	require.NoError(t, err)
+	assert.Equal(t, int64(first), info.Size())
+
+	require.NoError(t, reopened.Append([]*domain.Metric{fileCounter("c", 3)}))
+	require.NoError(t, reopened.Close())
+	again, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	defer again.Close()
+	assert.Len(t, again.Find(nil), 2)
+}
+
+func TestMetricFileStorage_StopsAtChecksumMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	storage, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	require.NoError(t, storage.Append([]*domain.Metric{fileCounter("a", 1)}))
+	require.NoError(t, storage.Append([]*domain.Metric{fileCounter("b", 2)}))
+	require.NoError(t, storage.Close())
+
+	walPath := path + metricFileWALExt
+	data, err := os.ReadFile(walPath)
+	require.NoError(t, err)
+	data[len(data)-3] ^= 0xff
+	require.NoError(t, os.WriteFile(walPath, data, 0666))
+
+	reopened, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	defer reopened.Close()
+	result := reopened.Find(nil)
+	assert.Len(t, result, 1)
+	assert.Contains(t, result, fileCounter("a", 0).MetricID)
+}
+
+func TestMetricFileStorage_ReadsLegacyJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	encoder := json.NewEncoder(file)
+	require.NoError(t, encoder.Encode(fileCounter("a", 1)))
+	require.NoError(t, encoder.Encode(fileCounter("a", 5)))
+	require.NoError(t, file.Close())
+
+	storage, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	defer storage.Close()
+	result := storage.Find(nil)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(5), *result[fileCounter("a", 0).MetricID].Delta)
+}
+
+func TestMetricFileStorage_WithoutSyncWritesKeepsRecordsInWAL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	storage, err := NewMetricFileStorage(path, 0, false, true)
+	require.NoError(t, err)
+	require.NoError(t, storage.Append([]*domain.Metric{fileCounter("a", 1)}))
+	require.NoError(t, storage.Close())
+
+	reopened, err := NewMetricFileStorage(path, 0, false, true)
+	require.NoError(t, err)
+	defer reopened.Close()
+	assert.Equal(t, int64(1), *reopened.Find(nil)[fileCounter("a", 0).MetricID].Delta)
+}
+
+func TestMetricFileStorage_SnapshotReplacesContents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	storage, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	require.NoError(t, storage.Append([]*domain.Metric{fileCounter("a", 1), fileCounter("b", 2)}))
+	require.NoError(t, storage.Snapshot([]*domain.Metric{fileCounter("b", 5), fileCounter("c", 3)}))
+	require.NoError(t, storage.Close())
+
+	reopened, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	defer reopened.Close()
+	result := reopened.Find(nil)
+	assert.Len(t, result, 2)
+	assert.NotContains(t, result, fileCounter("a", 0).MetricID)
+	assert.Equal(t, int64(5), *result[fileCounter("b", 0).MetricID].Delta)
+	assert.Equal(t, int64(3), *result[fileCounter("c", 0).MetricID].Delta)
+}
+
+func TestMetricFileStorage_DeleteWritesTombstones(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	storage, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	require.NoError(t, storage.Append([]*domain.Metric{fileCounter("a", 1), fileCounter("b", 2)}))
+	require.NoError(t, storage.Delete([]*domain.MetricID{&fileCounter("a", 0).MetricID}))
+	assert.Len(t, storage.Find(nil), 1)
+	require.NoError(t, storage.Close())
+
+	reopened, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	result := reopened.Find(nil)
+	assert.Len(t, result, 1)
+	assert.Contains(t, result, fileCounter("b", 0).MetricID)
+	require.NoError(t, reopened.Append([]*domain.Metric{fileCounter("a", 5)}))
+	require.NoError(t, reopened.Delete([]*domain.MetricID{&fileCounter("b", 0).MetricID}))
+	require.NoError(t, reopened.Compact())
+	require.NoError(t, reopened.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), `"deleted"`)
+	compacted, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	defer compacted.Close()
+	result = compacted.Find(nil)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(5), *result[fileCounter("a", 0).MetricID].Delta)
+}
+
+func TestMetricFileStorage_WithoutRestoreStartsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	storage, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	require.NoError(t, storage.Snapshot([]*domain.Metric{fileCounter("a", 1)}))
+	require.NoError(t, storage.Append([]*domain.Metric{fileCounter("b", 2)}))
+	require.NoError(t, storage.Close())
+
+	fresh, err := NewMetricFileStorage(path, 0, true, false)
+	require.NoError(t, err)
+	assert.Empty(t, fresh.Find(nil))
+	require.NoError(t, fresh.Append([]*domain.Metric{fileCounter("c", 3)}))
+	require.NoError(t, fresh.Compact())
+	require.NoError(t, fresh.Close())
+
+	reopened, err := NewMetricFileStorage(path, 0, true, true)
+	require.NoError(t, err)
+	defer reopened.Close()
+	result := reopened.Find(nil)
+	assert.Len(t, result, 1)
+	assert.Equal(t, int64(3), *result[fileCounter("c", 0).MetricID].Delta)
+}
@@ -0,0 +1,141 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"go-metrics/internal/domain"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+type MetricHistoryDBRepository struct {
+	db        *sql.DB
+	retention time.Duration
+}
+
+func NewMetricHistoryDBRepository(db *sql.DB, retention time.Duration) *MetricHistoryDBRepository {
+	return &MetricHistoryDBRepository{db: db, retention: retention}
+}
+
+var metricHistorySaveQuery = `
+	INSERT INTO metric_history (id, type, labels, delta, value, histogram, summary, ts)
+	SELECT id, type, labels, delta, value, histogram::jsonb, summary::jsonb, ts
+	FROM unnest($1::text[], $2::text[], $3::text[], $4::bigint[], $5::float8[], $6::text[], $7::text[], $8::timestamptz[])
+		AS m(id, type, labels, delta, value, histogram, summary, ts);
+`
+
+var metricHistoryFindBatchQuery = `
+	SELECT id, type, labels, delta, value, histogram, summary, ts FROM metric_history
+	JOIN unnest($1::text[], $2::text[], $3::text[]) AS f(id, type, labels) USING (id, type, labels)
+	WHERE ts >= $4 AND ts <= $5
+	ORDER BY ts;
+`
+
+var metricHistoryPruneQuery = "DELETE FROM metric_history WHERE ts < $1"
+
+var metricHistoryDeleteQuery = `
+	DELETE FROM metric_history h
+	USING unnest($1::text[], $2::text[], $3::text[]) AS f(id, type, labels)
+	WHERE h.id = f.id AND h.type = f.type AND h.labels = f.labels;
+`
+
+var metricHistoryFindQuery = `
+	SELECT id, type, labels, delta, value, histogram, summary, ts FROM metric_history
+	WHERE id = $1 AND type = $2 AND labels = $3 AND ts >= $4 AND ts <= $5
+	ORDER BY ts;
+`
+
+func (repo *MetricHistoryDBRepository) Save(ctx context.Context, metrics []*domain.Metric) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+	now := time.Now()
+	samples := make([]*domain.Metric, 0, len(metrics))
+	for _, metric := range metrics {
+		sample := newMetricSample(metric, now)
+		sample.UpdatedAt = sample.Timestamp
+		samples = append(samples, &sample.Metric)
+	}
+	columns, err := newMetricDBColumns(samples)
+	if err != nil {
+		return err
+	}
+	_, err = dbExecutorFromContext(ctx, repo.db).ExecContext(ctx, metricHistorySaveQuery, columns.args()...)
+	return err
+}
+
+func (repo *MetricHistoryDBRepository) Find(
+	ctx context.Context, id *domain.MetricID, from time.Time, to time.Time,
+) ([]*domain.MetricSample, error) {
+	rows, err := dbExecutorFromContext(ctx, repo.db).QueryContext(ctx, metricHistoryFindQuery, id.ID, id.Type, string(id.Labels), from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanMetricSamples(rows)
+}
+
+func (repo *MetricHistoryDBRepository) FindBatch(
+	ctx context.Context, ids []*domain.MetricID, from time.Time, to time.Time,
+) (map[domain.MetricID][]*domain.MetricSample, error) {
+	result := make(map[domain.MetricID][]*domain.MetricSample, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+	rows, err := dbExecutorFromContext(ctx, repo.db).QueryContext(ctx, metricHistoryFindBatchQuery, append(metricIDArgs(ids), from, to)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	samples, err := scanMetricSamples(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, sample := range samples {
+		result[sample.MetricID] = append(result[sample.MetricID], sample)
+	}
+	return result, nil
+}
+
+func (repo *MetricHistoryDBRepository) Delete(ctx context.Context, ids []*domain.MetricID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := dbExecutorFromContext(ctx, repo.db).ExecContext(ctx, metricHistoryDeleteQuery, metricIDArgs(ids)...)
+	return err
+}
+
+func (repo *MetricHistoryDBRepository) Prune(ctx context.Context, now time.Time) (int, error) {
+	if repo.retention <= 0 {
+		return 0, nil
+	}
+	result, err := dbExecutorFromContext(ctx, repo.db).ExecContext(ctx, metricHistoryPruneQuery, now.Add(-repo.retention))
+	if err != nil {
+		return 0, err
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(removed), nil
+}
+
+func scanMetricSamples(rows *sql.Rows) ([]*domain.MetricSample, error) {
+	result := make([]*domain.MetricSample, 0)
+	for rows.Next() {
+		var sample domain.MetricSample
+		var histogram, summary []byte
+		if err := rows.Scan(&sample.ID, &sample.Type, &sample.Labels, &sample.Delta, &sample.Value, &histogram, &summary, &sample.Timestamp); err != nil {
+			return nil, err
+		}
+		if err := unmarshalMetricJSON(&sample.Metric, histogram, summary); err != nil {
+			return nil, err
+		}
+		result = append(result, &sample)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
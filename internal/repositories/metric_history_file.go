@@ -0,0 +1,215 @@
+package repositories
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"go-metrics/internal/domain"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type MetricHistoryFileRepository struct {
+	path        string
+	retention   time.Duration
+	lastCompact time.Time
+	data        map[domain.MetricID][]*domain.MetricSample
+	mu          sync.Mutex
+}
+
+func NewMetricHistoryFileRepository(path string, retention time.Duration) *MetricHistoryFileRepository {
+	return &MetricHistoryFileRepository{
+		path:        path,
+		retention:   retention,
+		lastCompact: time.Now(),
+	}
+}
+
+func (repo *MetricHistoryFileRepository) Save(ctx context.Context, metrics []*domain.Metric) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if err := repo.load(); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(repo.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	samples := make([]*domain.MetricSample, 0, len(metrics))
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, metric := range metrics {
+		sample := newMetricSample(metric, now)
+		if err := encoder.Encode(sample); err != nil {
+			file.Close()
+			return err
+		}
+		samples = append(samples, sample)
+	}
+	if err := writer.Flush(); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	for _, sample := range samples {
+		repo.data[sample.MetricID] = insertMetricSample(repo.data[sample.MetricID], sample)
+	}
+	return nil
+}
+
+func (repo *MetricHistoryFileRepository) Find(
+	ctx context.Context, id *domain.MetricID, from time.Time, to time.Time,
+) ([]*domain.MetricSample, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if err := repo.load(); err != nil {
+		return nil, err
+	}
+	return filterMetricSamples(repo.data[*id], repo.from(from), to), nil
+}
+
+func (repo *MetricHistoryFileRepository) FindBatch(
+	ctx context.Context, ids []*domain.MetricID, from time.Time, to time.Time,
+) (map[domain.MetricID][]*domain.MetricSample, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if err := repo.load(); err != nil {
+		return nil, err
+	}
+	from = repo.from(from)
+	result := make(map[domain.MetricID][]*domain.MetricSample, len(ids))
+	for _, id := range ids {
+		if samples := filterMetricSamples(repo.data[*id], from, to); len(samples) > 0 {
+			result[*id] = samples
+		}
+	}
+	return result, nil
+}
+
+func (repo *MetricHistoryFileRepository) Delete(ctx context.Context, ids []*domain.MetricID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if err := repo.load(); err != nil {
+		return err
+	}
+	removed := false
+	for _, id := range ids {
+		if _, exists := repo.data[*id]; exists {
+			delete(repo.data, *id)
+			removed = true
+		}
+	}
+	if !removed {
+		return nil
+	}
+	return repo.rewrite()
+}
+
+func (repo *MetricHistoryFileRepository) Prune(ctx context.Context, now time.Time) (int, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if err := repo.load(); err != nil {
+		return 0, err
+	}
+	cutoff := retentionCutoff(now, repo.retention)
+	removed := 0
+	for id, samples := range repo.data {
+		samples = pruneMetricSamples(samples, cutoff)
+		if len(samples) == 0 {
+			delete(repo.data, id)
+			removed++
+			continue
+		}
+		repo.data[id] = samples
+	}
+	if repo.retention > 0 && now.Sub(repo.lastCompact) >= repo.retention {
+		if err := repo.rewrite(); err != nil {
+			return removed, err
+		}
+		repo.lastCompact = now
+	}
+	return removed, nil
+}
+
+func (repo *MetricHistoryFileRepository) from(from time.Time) time.Time {
+	if cutoff := retentionCutoff(time.Now(), repo.retention); from.Before(cutoff) {
+		return cutoff
+	}
+	return from
+}
+
+func (repo *MetricHistoryFileRepository) load() error {
+	if repo.data != nil {
+		return nil
+	}
+	data := make(map[domain.MetricID][]*domain.MetricSample)
+	file, err := os.Open(repo.path)
+	if os.IsNotExist(err) {
+		repo.data = data
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var sample domain.MetricSample
+			if json.Unmarshal(line, &sample) == nil {
+				data[sample.MetricID] = insertMetricSample(data[sample.MetricID], &sample)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+	repo.data = data
+	return nil
+}
+
+func (repo *MetricHistoryFileRepository) rewrite() error {
+	tmp, err := os.CreateTemp(filepath.Dir(repo.path), filepath.Base(repo.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	for _, samples := range repo.data {
+		for _, sample := range samples {
+			if err = encoder.Encode(sample); err != nil {
+				break
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), repo.path)
+}
@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"go-metrics/internal/domain"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricHistoryFileRepository_SaveAndFind(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	repo := NewMetricHistoryFileRepository(path, time.Hour)
+	id := domain.MetricID{ID: "CPU", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "h1"})}
+	v1, v2 := 1.5, 2.5
+	require.NoError(t, repo.Save(context.Background(), []*domain.Metric{{MetricID: id, Value: &v1}}))
+	require.NoError(t, repo.Save(context.Background(), []*domain.Metric{{MetricID: id, Value: &v2}}))
+	samples, err := repo.Find(context.Background(), &id, time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	assert.Equal(t, id, samples[0].MetricID)
+	assert.Equal(t, 1.5, *samples[0].Value)
+	assert.Equal(t, 2.5, *samples[1].Value)
+}
+
+func TestMetricHistoryFileRepository_Find_MissingFile(t *testing.T) {
+	repo := NewMetricHistoryFileRepository(filepath.Join(t.TempDir(), "missing.json"), time.Hour)
+	samples, err := repo.Find(context.Background(), &domain.MetricID{ID: "CPU", Type: domain.Gauge}, time.Time{}, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, samples)
+}
+
+func TestMetricHistoryFileRepository_Prune(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	id := domain.MetricID{ID: "HeapAlloc", Type: domain.Gauge}
+	old := 1.0
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, json.NewEncoder(file).Encode(&domain.MetricSample{
+		Metric:    domain.Metric{MetricID: id, Value: &old},
+		Timestamp: time.Now().Add(-2 * time.Hour),
+	}))
+	require.NoError(t, file.Close())
+	repo := NewMetricHistoryFileRepository(path, time.Hour)
+	repo.lastCompact = time.Now().Add(-2 * time.Hour)
+	value := 2.0
+	require.NoError(t, repo.Save(context.Background(), []*domain.Metric{{MetricID: id, Value: &value}}))
+	removed, err := repo.Prune(context.Background(), time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, removed)
+	file, err = os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines++
+	}
+	assert.Equal(t, 1, lines)
+}
+
+func TestMetricHistoryFileRepository_FindBatch(t *testing.T) {
+	repo := NewMetricHistoryFileRepository(filepath.Join(t.TempDir(), "history.json"), time.Hour)
+	cpu := domain.MetricID{ID: "CPU", Type: domain.Gauge}
+	mem := domain.MetricID{ID: "Mem", Type: domain.Gauge}
+	v1, v2 := 1.0, 2.0
+	require.NoError(t, repo.Save(context.Background(), []*domain.Metric{{MetricID: cpu, Value: &v1}, {MetricID: mem, Value: &v2}}))
+	batch, err := repo.FindBatch(context.Background(), []*domain.MetricID{&cpu, &mem}, time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, batch[cpu], 1)
+	require.Len(t, batch[mem], 1)
+	assert.Equal(t, 1.0, *batch[cpu][0].Value)
+	assert.Equal(t, 2.0, *batch[mem][0].Value)
+}
+
+func TestMetricHistoryFileRepository_Delete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	repo := NewMetricHistoryFileRepository(path, time.Hour)
+	cpu := domain.MetricID{ID: "CPU", Type: domain.Gauge}
+	mem := domain.MetricID{ID: "Mem", Type: domain.Gauge}
+	v := 1.0
+	require.NoError(t, repo.Save(context.Background(), []*domain.Metric{{MetricID: cpu, Value: &v}, {MetricID: mem, Value: &v}}))
+	require.NoError(t, repo.Delete(context.Background(), []*domain.MetricID{&cpu}))
+	samples, err := repo.FindBatch(context.Background(), []*domain.MetricID{&cpu, &mem}, time.Time{}, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, samples[cpu])
+	assert.Len(t, samples[mem], 1)
+}
+
+func TestMetricHistoryFileRepository_FindLongLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	id := domain.MetricID{ID: "Latency", Type: domain.Histogram}
+	buckets := make([]float64, 10000)
+	counts := make([]int64, len(buckets)+1)
+	for i := range buckets {
+		buckets[i] = float64(i + 1)
+	}
+	repo := NewMetricHistoryFileRepository(path, time.Hour)
+	require.NoError(t, repo.Save(context.Background(), []*domain.Metric{{
+		MetricID:  id,
+		Histogram: &domain.HistogramValue{Buckets: buckets, Counts: counts},
+	}}))
+
+	reopened := NewMetricHistoryFileRepository(path, time.Hour)
+	samples, err := reopened.Find(context.Background(), &id, time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, samples, 1)
+	assert.Len(t, samples[0].Histogram.Buckets, len(buckets))
+}
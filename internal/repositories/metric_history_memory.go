@@ -0,0 +1,144 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"sort"
+	"sync"
+	"time"
+)
+
+type MetricHistoryMemoryRepository struct {
+	data      map[domain.MetricID][]*domain.MetricSample
+	retention time.Duration
+	mu        sync.Mutex
+}
+
+func NewMetricHistoryMemoryRepository(retention time.Duration) *MetricHistoryMemoryRepository {
+	return &MetricHistoryMemoryRepository{
+		data:      make(map[domain.MetricID][]*domain.MetricSample),
+		retention: retention,
+	}
+}
+
+func (repo *MetricHistoryMemoryRepository) Save(ctx context.Context, metrics []*domain.Metric) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	now := time.Now()
+	for _, metric := range metrics {
+		samples := insertMetricSample(repo.data[metric.MetricID], newMetricSample(metric, now))
+		repo.data[metric.MetricID] = pruneMetricSamples(samples, retentionCutoff(now, repo.retention))
+	}
+	return nil
+}
+
+func (repo *MetricHistoryMemoryRepository) Find(
+	ctx context.Context, id *domain.MetricID, from time.Time, to time.Time,
+) ([]*domain.MetricSample, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	return filterMetricSamples(repo.data[*id], from, to), nil
+}
+
+func (repo *MetricHistoryMemoryRepository) FindBatch(
+	ctx context.Context, ids []*domain.MetricID, from time.Time, to time.Time,
+) (map[domain.MetricID][]*domain.MetricSample, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	result := make(map[domain.MetricID][]*domain.MetricSample, len(ids))
+	for _, id := range ids {
+		if samples := filterMetricSamples(repo.data[*id], from, to); len(samples) > 0 {
+			result[*id] = samples
+		}
+	}
+	return result, nil
+}
+
+func (repo *MetricHistoryMemoryRepository) Delete(ctx context.Context, ids []*domain.MetricID) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	for _, id := range ids {
+		delete(repo.data, *id)
+	}
+	return nil
+}
+
+func (repo *MetricHistoryMemoryRepository) Prune(ctx context.Context, now time.Time) (int, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	cutoff := retentionCutoff(now, repo.retention)
+	removed := 0
+	for id, samples := range repo.data {
+		samples = pruneMetricSamples(samples, cutoff)
+		if len(samples) == 0 {
+			delete(repo.data, id)
+			removed++
+			continue
+		}
+		repo.data[id] = samples
+	}
+	return removed, nil
+}
+
+func newMetricSample(metric *domain.Metric, now time.Time) *domain.MetricSample {
+	timestamp := metric.UpdatedAt
+	if timestamp.IsZero() {
+		timestamp = now
+	}
+	sample := &domain.MetricSample{
+		Metric: domain.Metric{
+			MetricID:  metric.MetricID,
+			Histogram: metric.Histogram.Clone(),
+			Summary:   metric.Summary.Sketch(),
+		},
+		Timestamp: timestamp,
+	}
+	if metric.Delta != nil {
+		delta := *metric.Delta
+		sample.Delta = &delta
+	}
+	if metric.Value != nil {
+		value := *metric.Value
+		sample.Value = &value
+	}
+	return sample
+}
+
+func retentionCutoff(now time.Time, retention time.Duration) time.Time {
+	if retention <= 0 {
+		return time.Time{}
+	}
+	return now.Add(-retention)
+}
+
+func insertMetricSample(samples []*domain.MetricSample, sample *domain.MetricSample) []*domain.MetricSample {
+	i := sort.Search(len(samples), func(i int) bool {
+		return samples[i].Timestamp.After(sample.Timestamp)
+	})
+	samples = append(samples, nil)
+	copy(samples[i+1:], samples[i:])
+	samples[i] = sample
+	return samples
+}
+
+func pruneMetricSamples(samples []*domain.MetricSample, before time.Time) []*domain.MetricSample {
+	i := 0
+	for i < len(samples) && samples[i].Timestamp.Before(before) {
+		i++
+	}
+	return samples[i:]
+}
+
+func filterMetricSamples(samples []*domain.MetricSample, from time.Time, to time.Time) []*domain.MetricSample {
+	result := make([]*domain.MetricSample, 0)
+	for _, sample := range samples {
+		if sample.Timestamp.Before(from) || sample.Timestamp.After(to) {
+			continue
+		}
+		result = append(result, sample)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Timestamp.Before(result[j].Timestamp)
+	})
+	return result
+}
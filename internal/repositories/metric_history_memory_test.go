@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricHistoryMemoryRepository_SaveAndFind(t *testing.T) {
+	repo := NewMetricHistoryMemoryRepository(time.Hour)
+	id := domain.MetricID{ID: "HeapAlloc", Type: domain.Gauge}
+	other := domain.MetricID{ID: "HeapSys", Type: domain.Gauge}
+	v1, v2 := 1.0, 2.0
+	metric := &domain.Metric{MetricID: id, Value: &v1}
+	require.NoError(t, repo.Save(context.Background(), []*domain.Metric{metric}))
+	metric.Value = &v2
+	require.NoError(t, repo.Save(context.Background(), []*domain.Metric{metric, {MetricID: other, Value: &v1}}))
+	samples, err := repo.Find(context.Background(), &id, time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, samples, 2)
+	assert.Equal(t, 1.0, *samples[0].Value)
+	assert.Equal(t, 2.0, *samples[1].Value)
+	assert.False(t, samples[1].Timestamp.Before(samples[0].Timestamp))
+}
+
+func TestMetricHistoryMemoryRepository_Find_Range(t *testing.T) {
+	repo := NewMetricHistoryMemoryRepository(0)
+	id := domain.MetricID{ID: "PollCount", Type: domain.Counter}
+	delta := int64(5)
+	require.NoError(t, repo.Save(context.Background(), []*domain.Metric{{MetricID: id, Delta: &delta}}))
+	samples, err := repo.Find(context.Background(), &id, time.Now().Add(time.Minute), time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, samples)
+}
+
+func TestMetricHistoryMemoryRepository_Retention(t *testing.T) {
+	repo := NewMetricHistoryMemoryRepository(time.Minute)
+	id := domain.MetricID{ID: "HeapAlloc", Type: domain.Gauge}
+	old := 1.0
+	repo.data[id] = []*domain.MetricSample{
+		{Metric: domain.Metric{MetricID: id, Value: &old}, Timestamp: time.Now().Add(-time.Hour)},
+	}
+	value := 2.0
+	require.NoError(t, repo.Save(context.Background(), []*domain.Metric{{MetricID: id, Value: &value}}))
+	samples, err := repo.Find(context.Background(), &id, time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, samples, 1)
+	assert.Equal(t, 2.0, *samples[0].Value)
+}
+
+func TestMetricHistoryMemoryRepository_Prune(t *testing.T) {
+	repo := NewMetricHistoryMemoryRepository(time.Minute)
+	now := time.Now()
+	stale := domain.MetricID{ID: "Stale", Type: domain.Gauge}
+	live := domain.MetricID{ID: "Live", Type: domain.Gauge}
+	repo.data[stale] = []*domain.MetricSample{{Metric: domain.Metric{MetricID: stale}, Timestamp: now.Add(-time.Hour)}}
+	repo.data[live] = []*domain.MetricSample{
+		{Metric: domain.Metric{MetricID: live}, Timestamp: now.Add(-time.Hour)},
+		{Metric: domain.Metric{MetricID: live}, Timestamp: now},
+	}
+	removed, err := repo.Prune(context.Background(), now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.NotContains(t, repo.data, stale)
+	assert.Len(t, repo.data[live], 1)
+}
+
+func TestMetricHistoryMemoryRepository_SummarySketch(t *testing.T) {
+	repo := NewMetricHistoryMemoryRepository(0)
+	id := domain.MetricID{ID: "Latency", Type: domain.Summary}
+	summary := domain.NewSummary([]float64{0.5})
+	for _, value := range []float64{1, 2, 3} {
+		summary.Observe(value)
+	}
+	require.NoError(t, repo.Save(context.Background(), []*domain.Metric{{MetricID: id, Summary: summary}}))
+	samples, err := repo.Find(context.Background(), &id, time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, samples, 1)
+	assert.Empty(t, samples[0].Summary.Observations)
+	assert.Equal(t, []float64{2}, samples[0].Summary.Values)
+	assert.Equal(t, int64(3), samples[0].Summary.Count)
+	assert.Equal(t, 6.0, samples[0].Summary.Sum)
+	assert.Equal(t, samples[0].Summary.Values, samples[0].Summary.Report().Values)
+}
+
+func TestMetricHistoryMemoryRepository_FindBatch(t *testing.T) {
+	repo := NewMetricHistoryMemoryRepository(time.Hour)
+	now := time.Now()
+	cpu := domain.MetricID{ID: "CPU", Type: domain.Gauge}
+	mem := domain.MetricID{ID: "Mem", Type: domain.Gauge}
+	v1, v2 := 1.0, 2.0
+	require.NoError(t, repo.Save(context.Background(), []*domain.Metric{
+		{MetricID: cpu, Value: &v2, UpdatedAt: now.Add(-time.Second)},
+		{MetricID: mem, Value: &v1},
+	}))
+	require.NoError(t, repo.Save(context.Background(), []*domain.Metric{
+		{MetricID: cpu, Value: &v1, UpdatedAt: now.Add(-time.Minute)},
+	}))
+	missing := domain.MetricID{ID: "Missing", Type: domain.Gauge}
+	batch, err := repo.FindBatch(context.Background(), []*domain.MetricID{&cpu, &missing}, time.Time{}, now)
+	require.NoError(t, err)
+	require.Len(t, batch, 1)
+	require.Len(t, batch[cpu], 2)
+	assert.Equal(t, 1.0, *batch[cpu][0].Value)
+	assert.Equal(t, 2.0, *batch[cpu][1].Value)
+	assert.Equal(t, now.Add(-time.Minute), batch[cpu][0].Timestamp)
+}
+
+func TestMetricHistoryMemoryRepository_Delete(t *testing.T) {
+	repo := NewMetricHistoryMemoryRepository(0)
+	cpu := domain.MetricID{ID: "CPU", Type: domain.Gauge}
+	mem := domain.MetricID{ID: "Mem", Type: domain.Gauge}
+	v := 1.0
+	require.NoError(t, repo.Save(context.Background(), []*domain.Metric{{MetricID: cpu, Value: &v}, {MetricID: mem, Value: &v}}))
+	require.NoError(t, repo.Delete(context.Background(), []*domain.MetricID{&cpu}))
+	samples, err := repo.FindBatch(context.Background(), []*domain.MetricID{&cpu, &mem}, time.Time{}, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, samples[cpu])
+	assert.Len(t, samples[mem], 1)
+}
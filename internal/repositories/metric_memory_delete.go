@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+)
+
+type MetricMemoryDeleteRepository struct {
+	storage *MetricMemoryStorage
+}
+
+func NewMetricMemoryDeleteRepository(
+	storage *MetricMemoryStorage,
+) *MetricMemoryDeleteRepository {
+	return &MetricMemoryDeleteRepository{
+		storage: storage,
+	}
+}
+
+func (repo *MetricMemoryDeleteRepository) Delete(
+	ctx context.Context, ids []*domain.MetricID,
+) error {
+	repo.storage.mu.Lock()
+	defer repo.storage.mu.Unlock()
+	for _, id := range ids {
+		if id != nil {
+			delete(repo.storage.data, *id)
+		}
+	}
+	return nil
+}
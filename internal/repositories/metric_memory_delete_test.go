@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricMemoryDeleteRepository_Delete(t *testing.T) {
+	metric1 := &domain.Metric{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}}
+	metric2 := &domain.Metric{MetricID: domain.MetricID{ID: "2", Type: domain.Gauge}}
+	data := map[domain.MetricID]*domain.Metric{
+		metric1.MetricID: metric1,
+		metric2.MetricID: metric2,
+	}
+	repo := NewMetricMemoryDeleteRepository(NewMetricMemoryStorage(data))
+	err := repo.Delete(context.Background(), []*domain.MetricID{&metric1.MetricID, {ID: "3", Type: domain.Gauge}, nil})
+	assert.NoError(t, err)
+	assert.Len(t, data, 1)
+	assert.Contains(t, data, metric2.MetricID)
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+)
+
+type MetricMemoryFileDeleteRepository struct {
+	memory *MetricMemoryDeleteRepository
+	file   *MetricFileDeleteRepository
+}
+
+func NewMetricMemoryFileDeleteRepository(
+	memory *MetricMemoryDeleteRepository, file *MetricFileDeleteRepository,
+) *MetricMemoryFileDeleteRepository {
+	return &MetricMemoryFileDeleteRepository{
+		memory: memory,
+		file:   file,
+	}
+}
+
+func (repo *MetricMemoryFileDeleteRepository) Delete(ctx context.Context, ids []*domain.MetricID) error {
+	if err := repo.file.Delete(ctx, ids); err != nil {
+		return err
+	}
+	return repo.memory.Delete(ctx, ids)
+}
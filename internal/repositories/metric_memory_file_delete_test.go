@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricMemoryFileDeleteRepository_Delete(t *testing.T) {
+	storage, err := NewMetricFileStorage(filepath.Join(t.TempDir(), "metrics.json"), 0, true, true)
+	require.NoError(t, err)
+	defer storage.Close()
+	metric := fileCounter("a", 1)
+	require.NoError(t, storage.Append([]*domain.Metric{metric}))
+	data := map[domain.MetricID]*domain.Metric{metric.MetricID: metric}
+	repo := NewMetricMemoryFileDeleteRepository(
+		NewMetricMemoryDeleteRepository(NewMetricMemoryStorage(data)),
+		NewMetricFileDeleteRepository(storage),
+	)
+	require.NoError(t, repo.Delete(context.Background(), []*domain.MetricID{&metric.MetricID}))
+	assert.Empty(t, data)
+	assert.Empty(t, storage.Find(nil))
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+)
+
+type MetricMemoryFileSaveRepository struct {
+	memory *MetricMemorySaveRepository
+	file   *MetricFileSaveRepository
+}
+
+func NewMetricMemoryFileSaveRepository(
+	memory *MetricMemorySaveRepository, file *MetricFileSaveRepository,
+) *MetricMemoryFileSaveRepository {
+	return &MetricMemoryFileSaveRepository{
+		memory: memory,
+		file:   file,
+	}
+}
+
+func (repo *MetricMemoryFileSaveRepository) Save(ctx context.Context, metrics []*domain.Metric) error {
+	if err := repo.file.Save(ctx, metrics); err != nil {
+		return err
+	}
+	return repo.memory.Save(ctx, metrics)
+}
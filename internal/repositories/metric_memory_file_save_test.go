@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricMemoryFileSaveRepository_Save(t *testing.T) {
+	storage, err := NewMetricFileStorage(filepath.Join(t.TempDir(), "metrics.json"), 0, true, true)
+	require.NoError(t, err)
+	defer storage.Close()
+	data := make(map[domain.MetricID]*domain.Metric)
+	repo := NewMetricMemoryFileSaveRepository(
+		NewMetricMemorySaveRepository(NewMetricMemoryStorage(data)),
+		NewMetricFileSaveRepository(storage),
+	)
+	metric := fileCounter("a", 1)
+	require.NoError(t, repo.Save(context.Background(), []*domain.Metric{metric}))
+	assert.Equal(t, metric, data[metric.MetricID])
+	assert.Equal(t, metric, storage.Find(nil)[metric.MetricID])
+}
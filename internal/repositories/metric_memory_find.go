@@ -3,34 +3,42 @@ package repositories
 import (
 	"context"
 	"go-metrics/internal/domain"
-	"sync"
 )
 
 type MetricMemoryFindRepository struct {
-	data map[domain.MetricID]*domain.Metric
-	mu   sync.Mutex
+	storage *MetricMemoryStorage
 }
 
-func NewMetricMemoryFindRepository(data map[domain.MetricID]*domain.Metric) *MetricMemoryFindRepository {
-	return &MetricMemoryFindRepository{data: data}
+func NewMetricMemoryFindRepository(storage *MetricMemoryStorage) *MetricMemoryFindRepository {
+	return &MetricMemoryFindRepository{storage: storage}
 }
 
 func (repo *MetricMemoryFindRepository) Find(ctx context.Context, filters []*domain.MetricID) (map[domain.MetricID]*domain.Metric, error) {
-	repo.mu.Lock()
-	defer repo.mu.Unlock()
+	repo.storage.mu.RLock()
+	defer repo.storage.mu.RUnlock()
 	result := make(map[domain.MetricID]*domain.Metric)
 	if len(filters) == 0 {
-		for key, value := range repo.data {
+		for key, value := range repo.storage.data {
 			result[key] = value
 		}
 		return result, nil
 	}
 	for _, filter := range filters {
 		if filter != nil {
-			if metric, found := repo.data[*filter]; found {
+			if metric, found := repo.storage.data[*filter]; found {
 				result[*filter] = metric
 			}
 		}
 	}
 	return result, nil
 }
+
+func (repo *MetricMemoryFindRepository) FindPage(ctx context.Context, query *domain.MetricQuery) (*domain.MetricPage, error) {
+	repo.storage.mu.RLock()
+	defer repo.storage.mu.RUnlock()
+	metrics := make([]*domain.Metric, 0, len(repo.storage.data))
+	for _, metric := range repo.storage.data {
+		metrics = append(metrics, metric)
+	}
+	return query.Page(metrics), nil
+}
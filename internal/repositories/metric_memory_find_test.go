@@ -2,7 +2,9 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"go-metrics/internal/domain"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,10 +13,10 @@ import (
 func TestMetricMemoryFindRepository_Find_AllMetrics(t *testing.T) {
 	metric1 := &domain.Metric{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}}
 	metric2 := &domain.Metric{MetricID: domain.MetricID{ID: "2", Type: domain.Gauge}}
-	repo := NewMetricMemoryFindRepository(map[domain.MetricID]*domain.Metric{
+	repo := NewMetricMemoryFindRepository(NewMetricMemoryStorage(map[domain.MetricID]*domain.Metric{
 		metric1.MetricID: metric1,
 		metric2.MetricID: metric2,
-	})
+	}))
 
 	result, err := repo.Find(context.Background(), nil)
 	assert.NoError(t, err)
@@ -26,10 +28,10 @@ func TestMetricMemoryFindRepository_Find_AllMetrics(t *testing.T) {
 func TestMetricMemoryFindRepository_Find_ByFilters(t *testing.T) {
 	metric1 := &domain.Metric{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}}
 	metric2 := &domain.Metric{MetricID: domain.MetricID{ID: "2", Type: domain.Gauge}}
-	repo := NewMetricMemoryFindRepository(map[domain.MetricID]*domain.Metric{
+	repo := NewMetricMemoryFindRepository(NewMetricMemoryStorage(map[domain.MetricID]*domain.Metric{
 		metric1.MetricID: metric1,
 		metric2.MetricID: metric2,
-	})
+	}))
 
 	filters := []*domain.MetricID{
 		&metric1.MetricID,
@@ -46,10 +48,10 @@ func TestMetricMemoryFindRepository_Find_ByFilters(t *testing.T) {
 func TestMetricMemoryFindRepository_Find_EmptyFilter(t *testing.T) {
 	metric1 := &domain.Metric{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}}
 	metric2 := &domain.Metric{MetricID: domain.MetricID{ID: "2", Type: domain.Gauge}}
-	repo := NewMetricMemoryFindRepository(map[domain.MetricID]*domain.Metric{
+	repo := NewMetricMemoryFindRepository(NewMetricMemoryStorage(map[domain.MetricID]*domain.Metric{
 		metric1.MetricID: metric1,
 		metric2.MetricID: metric2,
-	})
+	}))
 
 	filters := []*domain.MetricID{}
 	result, err := repo.Find(context.Background(), filters)
@@ -58,3 +60,48 @@ func TestMetricMemoryFindRepository_Find_EmptyFilter(t *testing.T) {
 	assert.Contains(t, result, metric1.MetricID)
 	assert.Contains(t, result, metric2.MetricID)
 }
+
+func TestMetricMemoryFindRepository_FindPage(t *testing.T) {
+	metric1 := &domain.Metric{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}}
+	metric2 := &domain.Metric{MetricID: domain.MetricID{ID: "2", Type: domain.Gauge}}
+	metric3 := &domain.Metric{MetricID: domain.MetricID{ID: "3", Type: domain.Gauge}}
+	repo := NewMetricMemoryFindRepository(NewMetricMemoryStorage(map[domain.MetricID]*domain.Metric{
+		metric1.MetricID: metric1,
+		metric2.MetricID: metric2,
+		metric3.MetricID: metric3,
+	}))
+
+	page, err := repo.FindPage(context.Background(), &domain.MetricQuery{Type: domain.Gauge, Limit: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Metric{metric2}, page.Metrics)
+	assert.Equal(t, &metric2.MetricID, page.Next)
+
+	page, err = repo.FindPage(context.Background(), &domain.MetricQuery{Type: domain.Gauge, Limit: 1, After: page.Next})
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Metric{metric3}, page.Metrics)
+	assert.Nil(t, page.Next)
+}
+
+func TestMetricMemoryRepositories_ShareStorageLock(t *testing.T) {
+	storage := NewMetricMemoryStorage(make(map[domain.MetricID]*domain.Metric))
+	saveRepo := NewMetricMemorySaveRepository(storage)
+	findRepo := NewMetricMemoryFindRepository(storage)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			metric := &domain.Metric{MetricID: domain.MetricID{ID: fmt.Sprintf("m%d", i), Type: domain.Gauge}}
+			assert.NoError(t, saveRepo.Save(context.Background(), []*domain.Metric{metric}))
+		}(i)
+		go func() {
+			defer wg.Done()
+			_, err := findRepo.FindPage(context.Background(), &domain.MetricQuery{})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	result, err := findRepo.Find(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Len(t, result, 50)
+}
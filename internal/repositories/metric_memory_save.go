@@ -3,29 +3,27 @@ package repositories
 import (
 	"context"
 	"go-metrics/internal/domain"
-	"sync"
 )
 
 type MetricMemorySaveRepository struct {
-	data map[domain.MetricID]*domain.Metric
-	mu   sync.Mutex
+	storage *MetricMemoryStorage
 }
 
 func NewMetricMemorySaveRepository(
-	data map[domain.MetricID]*domain.Metric,
+	storage *MetricMemoryStorage,
 ) *MetricMemorySaveRepository {
 	return &MetricMemorySaveRepository{
-		data: data,
+		storage: storage,
 	}
 }
 
 func (repo *MetricMemorySaveRepository) Save(
 	ctx context.Context, metrics []*domain.Metric,
 ) error {
-	repo.mu.Lock()
-	defer repo.mu.Unlock()
+	repo.storage.mu.Lock()
+	defer repo.storage.mu.Unlock()
 	for _, metric := range metrics {
-		repo.data[domain.MetricID{ID: metric.ID, Type: metric.Type}] = metric
+		repo.storage.data[metric.MetricID] = metric
 	}
 	return nil
 }
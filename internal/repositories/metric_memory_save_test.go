@@ -11,11 +11,22 @@ import (
 func TestMetricMemorySaveRepository_Save(t *testing.T) {
 	metric1 := &domain.Metric{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}}
 	metric2 := &domain.Metric{MetricID: domain.MetricID{ID: "2", Type: domain.Gauge}}
-	repo := NewMetricMemorySaveRepository(make(map[domain.MetricID]*domain.Metric))
+	repo := NewMetricMemorySaveRepository(NewMetricMemoryStorage(make(map[domain.MetricID]*domain.Metric)))
 	err := repo.Save(context.Background(), []*domain.Metric{metric1, metric2})
 	assert.NoError(t, err)
-	assert.Contains(t, repo.data, domain.MetricID{ID: metric1.ID, Type: metric1.Type})
-	assert.Contains(t, repo.data, domain.MetricID{ID: metric2.ID, Type: metric2.Type})
-	assert.Equal(t, metric1, repo.data[domain.MetricID{ID: metric1.ID, Type: metric1.Type}])
-	assert.Equal(t, metric2, repo.data[domain.MetricID{ID: metric2.ID, Type: metric2.Type}])
+	assert.Contains(t, repo.storage.data, domain.MetricID{ID: metric1.ID, Type: metric1.Type})
+	assert.Contains(t, repo.storage.data, domain.MetricID{ID: metric2.ID, Type: metric2.Type})
+	assert.Equal(t, metric1, repo.storage.data[domain.MetricID{ID: metric1.ID, Type: metric1.Type}])
+	assert.Equal(t, metric2, repo.storage.data[domain.MetricID{ID: metric2.ID, Type: metric2.Type}])
+}
+
+func TestMetricMemorySaveRepository_Save_LabelsAreIdentity(t *testing.T) {
+	h1 := domain.MetricID{ID: "CPU", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "h1"})}
+	h2 := domain.MetricID{ID: "CPU", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "h2"})}
+	repo := NewMetricMemorySaveRepository(NewMetricMemoryStorage(make(map[domain.MetricID]*domain.Metric)))
+	err := repo.Save(context.Background(), []*domain.Metric{{MetricID: h1}, {MetricID: h2}})
+	assert.NoError(t, err)
+	assert.Len(t, repo.storage.data, 2)
+	assert.Contains(t, repo.storage.data, h1)
+	assert.Contains(t, repo.storage.data, h2)
 }
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"go-metrics/internal/domain"
+	"sync"
+)
+
+type MetricMemoryStorage struct {
+	data map[domain.MetricID]*domain.Metric
+	mu   sync.RWMutex
+}
+
+func NewMetricMemoryStorage(data map[domain.MetricID]*domain.Metric) *MetricMemoryStorage {
+	return &MetricMemoryStorage{data: data}
+}
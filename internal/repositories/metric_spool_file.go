@@ -0,0 +1,197 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-metrics/internal/domain"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const metricSpoolExt = ".json"
+
+type MetricSpoolBatch struct {
+	ID        string
+	Metrics   []domain.Metric
+	CreatedAt time.Time
+}
+
+type MetricSpoolFileRepository struct {
+	dir        string
+	maxBatches int
+	maxBytes   int64
+	maxAge     time.Duration
+	seq        uint64
+	dropped    int64
+	mu         sync.Mutex
+}
+
+type metricSpoolEntry struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+func NewMetricSpoolFileRepository(
+	dir string, maxBatches int, maxBytes int64, maxAge time.Duration,
+) (*MetricSpoolFileRepository, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	repo := &MetricSpoolFileRepository{
+		dir:        dir,
+		maxBatches: maxBatches,
+		maxBytes:   maxBytes,
+		maxAge:     maxAge,
+	}
+	entries, err := repo.list()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) > 0 {
+		last := strings.TrimSuffix(entries[len(entries)-1].name, metricSpoolExt)
+		repo.seq, _ = strconv.ParseUint(last, 10, 64)
+	}
+	return repo, nil
+}
+
+func (repo *MetricSpoolFileRepository) Push(ctx context.Context, metrics []domain.Metric) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	data, err := json.Marshal(metrics)
+	if err != nil {
+		return err
+	}
+	repo.seq++
+	name := fmt.Sprintf("%020d%s", repo.seq, metricSpoolExt)
+	tmp := filepath.Join(repo.dir, name+".tmp")
+	if err := os.WriteFile(tmp, data, 0666); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(repo.dir, name)); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return repo.evict(time.Now())
+}
+
+func (repo *MetricSpoolFileRepository) Front(ctx context.Context) (*MetricSpoolBatch, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	if err := repo.evict(time.Now()); err != nil {
+		return nil, err
+	}
+	entries, err := repo.list()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		path := filepath.Join(repo.dir, entry.name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var metrics []domain.Metric
+		if err := json.Unmarshal(data, &metrics); err != nil {
+			if err := os.Remove(path); err != nil {
+				return nil, err
+			}
+			repo.dropped++
+			continue
+		}
+		return &MetricSpoolBatch{
+			ID:        entry.name,
+			Metrics:   metrics,
+			CreatedAt: entry.modTime,
+		}, nil
+	}
+	return nil, nil
+}
+
+func (repo *MetricSpoolFileRepository) Remove(ctx context.Context, id string) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	err := os.Remove(filepath.Join(repo.dir, filepath.Base(id)))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (repo *MetricSpoolFileRepository) Len() int {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	entries, err := repo.list()
+	if err != nil {
+		return 0
+	}
+	return len(entries)
+}
+
+func (repo *MetricSpoolFileRepository) Dropped() int64 {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	return repo.dropped
+}
+
+func (repo *MetricSpoolFileRepository) evict(now time.Time) error {
+	entries, err := repo.list()
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, entry := range entries {
+		total += entry.size
+	}
+	for len(entries) > 0 {
+		oldest := entries[0]
+		expired := repo.maxAge > 0 && now.Sub(oldest.modTime) > repo.maxAge
+		overCount := repo.maxBatches > 0 && len(entries) > repo.maxBatches
+		overSize := repo.maxBytes > 0 && total > repo.maxBytes
+		if !expired && !overCount && !overSize {
+			break
+		}
+		if err := os.Remove(filepath.Join(repo.dir, oldest.name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		repo.dropped++
+		total -= oldest.size
+		entries = entries[1:]
+	}
+	return nil
+}
+
+func (repo *MetricSpoolFileRepository) list() ([]metricSpoolEntry, error) {
+	dirEntries, err := os.ReadDir(repo.dir)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]metricSpoolEntry, 0, len(dirEntries))
+	for _, dirEntry := range dirEntries {
+		if dirEntry.IsDir() || filepath.Ext(dirEntry.Name()) != metricSpoolExt {
+			continue
+		}
+		info, err := dirEntry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		entries = append(entries, metricSpoolEntry{
+			name:    dirEntry.Name(),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].name < entries[j].name
+	})
+	return entries, nil
+}
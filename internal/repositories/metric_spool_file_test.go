@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func spoolBatch(delta int64) []domain.Metric {
+	return []domain.Metric{{MetricID: domain.MetricID{ID: "PollCount", Type: domain.Counter}, Delta: &delta}}
+}
+
+func TestMetricSpoolFileRepository_FIFO(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewMetricSpoolFileRepository(t.TempDir(), 0, 0, 0)
+	require.NoError(t, err)
+
+	batch, err := repo.Front(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, batch)
+
+	for i := int64(1); i <= 3; i++ {
+		require.NoError(t, repo.Push(ctx, spoolBatch(i)))
+	}
+	assert.Equal(t, 3, repo.Len())
+
+	for i := int64(1); i <= 3; i++ {
+		batch, err := repo.Front(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, batch)
+		assert.Equal(t, i, *batch.Metrics[0].Delta)
+		require.NoError(t, repo.Remove(ctx, batch.ID))
+	}
+	assert.Equal(t, 0, repo.Len())
+	assert.Equal(t, int64(0), repo.Dropped())
+}
+
+func TestMetricSpoolFileRepository_SurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	repo, err := NewMetricSpoolFileRepository(dir, 0, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, repo.Push(ctx, spoolBatch(1)))
+	require.NoError(t, repo.Push(ctx, spoolBatch(2)))
+
+	reopened, err := NewMetricSpoolFileRepository(dir, 0, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, reopened.Push(ctx, spoolBatch(3)))
+	assert.Equal(t, 3, reopened.Len())
+	for i := int64(1); i <= 3; i++ {
+		batch, err := reopened.Front(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, i, *batch.Metrics[0].Delta)
+		require.NoError(t, reopened.Remove(ctx, batch.ID))
+	}
+}
+
+func TestMetricSpoolFileRepository_EvictByCount(t *testing.T) {
+	ctx := context.Background()
+	repo, err := NewMetricSpoolFileRepository(t.TempDir(), 2, 0, 0)
+	require.NoError(t, err)
+	for i := int64(1); i <= 4; i++ {
+		require.NoError(t, repo.Push(ctx, spoolBatch(i)))
+	}
+	assert.Equal(t, 2, repo.Len())
+	assert.Equal(t, int64(2), repo.Dropped())
+	batch, err := repo.Front(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), *batch.Metrics[0].Delta)
+}
+
+func TestMetricSpoolFileRepository_EvictBySize(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	repo, err := NewMetricSpoolFileRepository(dir, 0, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, repo.Push(ctx, spoolBatch(1)))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	info, err := entries[0].Info()
+	require.NoError(t, err)
+
+	repo.maxBytes = 2*info.Size() + 1
+	require.NoError(t, repo.Push(ctx, spoolBatch(2)))
+	require.NoError(t, repo.Push(ctx, spoolBatch(3)))
+	assert.Equal(t, 2, repo.Len())
+	assert.Equal(t, int64(1), repo.Dropped())
+}
+
+func TestMetricSpoolFileRepository_EvictByAge(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	repo, err := NewMetricSpoolFileRepository(dir, 0, 0, time.Minute)
+	require.NoError(t, err)
+	require.NoError(t, repo.Push(ctx, spoolBatch(1)))
+	require.NoError(t, repo.Push(ctx, spoolBatch(2)))
+	batch, err := repo.Front(ctx)
+	require.NoError(t, err)
+	old := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, batch.ID), old, old))
+
+	batch, err = repo.Front(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), *batch.Metrics[0].Delta)
+	assert.Equal(t, 1, repo.Len())
+	assert.Equal(t, int64(1), repo.Dropped())
+}
+
+func TestMetricSpoolFileRepository_SkipsCorruptedBatch(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	repo, err := NewMetricSpoolFileRepository(dir, 0, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, repo.Push(ctx, spoolBatch(1)))
+	require.NoError(t, repo.Push(ctx, spoolBatch(2)))
+	batch, err := repo.Front(ctx)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, batch.ID), []byte("{broken"), 0666))
+
+	batch, err = repo.Front(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), *batch.Metrics[0].Delta)
+	assert.Equal(t, int64(1), repo.Dropped())
+}
@@ -19,19 +19,52 @@ func NewMetricRouter(
 	h4 http.HandlerFunc,
 	h5 http.HandlerFunc,
 	h6 http.HandlerFunc,
+	h7 http.HandlerFunc,
+	h8 http.HandlerFunc,
+	h9 http.HandlerFunc,
+	h10 http.HandlerFunc,
+	h11 http.HandlerFunc,
+	h12 http.HandlerFunc,
+	h13 http.HandlerFunc,
+	h14 http.HandlerFunc,
+	h15 http.HandlerFunc,
+	h16 http.HandlerFunc,
+	h17 http.HandlerFunc,
+	h18 http.HandlerFunc,
 ) *chi.Mux {
 	r := chi.NewRouter()
 
 	r.Use(middlewares.LoggingMiddleware)
-	r.Use(middlewares.GzipMiddleware)
-	r.Use(middlewares.HMACMiddleware(config))
-
-	r.Post("/update/{type}/{name}/{value}", h1)
-	r.Post("/update/", h2)
-	r.Post("/updates/", h3)
-	r.Get("/value/{type}/{name}", h4)
-	r.Post("/value/", h5)
-	r.Get("/", h6)
+	r.Get("/stream", h17)
+
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.GzipMiddleware)
+		r.Use(middlewares.HMACMiddleware(config))
+
+		r.Post("/update/{type}/{name}/{value}", h1)
+		r.Post("/update/", h2)
+		r.Post("/updates/", h3)
+		r.Get("/value/{type}/{name}", h4)
+		r.Post("/value/", h5)
+		r.Get("/", h6)
+		r.Get("/metrics", h7)
+		r.Get("/history/{type}/{name}", h8)
+		r.Get("/alerts", h9)
+		r.Get("/api/v1/metrics", h10)
+		r.Delete("/value/{type}/{name}", h11)
+		r.Post("/delete/", h12)
+		r.Post("/reset/{type}/{name}", h13)
+		r.Get("/static/*", h18)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(middlewares.GzipMiddleware)
+
+		r.Post("/write", h14)
+		r.Post("/api/v2/write", h14)
+		r.Post("/v1/metrics", h15)
+		r.Post("/api/v1/write", h16)
+	})
 	return r
 
 }
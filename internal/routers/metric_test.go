@@ -0,0 +1,44 @@
+package routers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testRouterConfig struct{}
+
+func (testRouterConfig) GetKey() string {
+	return "secret"
+}
+
+func TestNewMetricRouter_SignaturesOnlyOnAgentRoutes(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+	router := NewMetricRouter(testRouterConfig{},
+		ok, ok, ok, ok, ok, ok, ok, ok, ok, ok, ok, ok, ok, ok, ok, ok, ok, ok,
+	)
+	tests := []struct {
+		path           string
+		expectedStatus int
+	}{
+		{path: "/updates/", expectedStatus: http.StatusBadRequest},
+		{path: "/delete/", expectedStatus: http.StatusBadRequest},
+		{path: "/write", expectedStatus: http.StatusNoContent},
+		{path: "/api/v2/write", expectedStatus: http.StatusNoContent},
+		{path: "/v1/metrics", expectedStatus: http.StatusNoContent},
+		{path: "/api/v1/write", expectedStatus: http.StatusNoContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader("body"))
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"sync"
+	"time"
+)
+
+type AlertEvaluateRuleRepository interface {
+	Find(ctx context.Context) ([]*domain.AlertRule, error)
+}
+
+type AlertEvaluateMetricService interface {
+	List(ctx context.Context) ([]*domain.Metric, error)
+}
+
+type AlertEvaluateSaveRepository interface {
+	Save(ctx context.Context, alerts []*domain.Alert) error
+}
+
+type AlertEvaluateFindRepository interface {
+	Find(ctx context.Context) ([]*domain.Alert, error)
+}
+
+type AlertEvaluateNotifier interface {
+	Notify(ctx context.Context, alerts []*domain.Alert) error
+}
+
+type alertRateSample struct {
+	value     float64
+	timestamp time.Time
+}
+
+type AlertEvaluateService struct {
+	r       AlertEvaluateRuleRepository
+	m       AlertEvaluateMetricService
+	s       AlertEvaluateSaveRepository
+	f       AlertEvaluateFindRepository
+	n       AlertEvaluateNotifier
+	samples map[domain.MetricID]alertRateSample
+	mu      sync.Mutex
+}
+
+func NewAlertEvaluateService(
+	r AlertEvaluateRuleRepository,
+	m AlertEvaluateMetricService,
+	s AlertEvaluateSaveRepository,
+	f AlertEvaluateFindRepository,
+	n AlertEvaluateNotifier,
+) *AlertEvaluateService {
+	return &AlertEvaluateService{
+		r:       r,
+		m:       m,
+		s:       s,
+		f:       f,
+		n:       n,
+		samples: make(map[domain.MetricID]alertRateSample),
+	}
+}
+
+func (s *AlertEvaluateService) Evaluate(ctx context.Context, now time.Time) error {
+	unsent, err := s.evaluate(ctx, now)
+	if err != nil {
+		return err
+	}
+	if err := s.n.Notify(ctx, unsent); err != nil {
+		return err
+	}
+	return s.acknowledge(ctx, unsent)
+}
+
+func (s *AlertEvaluateService) evaluate(ctx context.Context, now time.Time) ([]*domain.Alert, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rules, err := s.r.Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := s.m.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := s.f.Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	alerts := make(map[domain.AlertID]*domain.Alert, len(existing))
+	for _, alert := range existing {
+		alerts[alert.AlertID] = alert
+	}
+	rates := s.rates(metrics, now)
+	evaluated := make(map[domain.AlertID]bool)
+	var updated, changed []*domain.Alert
+	for _, rule := range rules {
+		for _, metric := range metrics {
+			if metric.ID != rule.Metric {
+				continue
+			}
+			value, ok := alertMetricValue(metric)
+			if rule.Function == domain.AlertFunctionRate {
+				value, ok = rates[metric.MetricID]
+			}
+			if !ok {
+				continue
+			}
+			id := domain.AlertID{Name: rule.Name, Labels: metric.Labels}
+			alert, exists := alerts[id]
+			if !exists {
+				alert = &domain.Alert{AlertID: id, State: domain.AlertInactive}
+			}
+			alert.Expr = rule.Expr
+			alert.Value = &value
+			if alert.Transition(rule, rule.Matches(value), now) {
+				changed = append(changed, alert)
+			}
+			evaluated[id] = true
+			updated = append(updated, alert)
+		}
+	}
+	ruleByName := make(map[string]*domain.AlertRule, len(rules))
+	for _, rule := range rules {
+		ruleByName[rule.Name] = rule
+	}
+	for _, alert := range existing {
+		if evaluated[alert.AlertID] {
+			continue
+		}
+		rule, ok := ruleByName[alert.Name]
+		if !ok {
+			rule = &domain.AlertRule{}
+		}
+		if alert.Transition(rule, false, now) {
+			changed = append(changed, alert)
+			updated = append(updated, alert)
+		}
+	}
+	if err := s.s.Save(ctx, updated); err != nil {
+		return nil, err
+	}
+	unsent := changed
+	for _, alert := range existing {
+		if alert.Unsent && !containsAlert(changed, alert) {
+			unsent = append(unsent, alert)
+		}
+	}
+	return unsent, nil
+}
+
+func (s *AlertEvaluateService) acknowledge(ctx context.Context, sent []*domain.Alert) error {
+	if len(sent) == 0 {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, err := s.f.Find(ctx)
+	if err != nil {
+		return err
+	}
+	bySentID := make(map[domain.AlertID]*domain.Alert, len(sent))
+	for _, alert := range sent {
+		bySentID[alert.AlertID] = alert
+	}
+	var acknowledged []*domain.Alert
+	for _, alert := range stored {
+		if previous, ok := bySentID[alert.AlertID]; ok && alert.Unsent && alert.SameState(previous) {
+			alert.Unsent = false
+			acknowledged = append(acknowledged, alert)
+		}
+	}
+	if len(acknowledged) == 0 {
+		return nil
+	}
+	return s.s.Save(ctx, acknowledged)
+}
+
+func containsAlert(alerts []*domain.Alert, target *domain.Alert) bool {
+	for _, alert := range alerts {
+		if alert.AlertID == target.AlertID {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *AlertEvaluateService) rates(metrics []*domain.Metric, now time.Time) map[domain.MetricID]float64 {
+	rates := make(map[domain.MetricID]float64)
+	for _, metric := range metrics {
+		value, ok := alertMetricValue(metric)
+		if !ok {
+			continue
+		}
+		previous, exists := s.samples[metric.MetricID]
+		if exists && now.After(previous.timestamp) {
+			rates[metric.MetricID] = (value - previous.value) / now.Sub(previous.timestamp).Seconds()
+		}
+		s.samples[metric.MetricID] = alertRateSample{value: value, timestamp: now}
+	}
+	return rates
+}
+
+func alertMetricValue(metric *domain.Metric) (float64, bool) {
+	switch {
+	case metric.Type == domain.Gauge && metric.Value != nil:
+		return *metric.Value, true
+	case metric.Type == domain.Counter && metric.Delta != nil:
+		return float64(*metric.Delta), true
+	default:
+		return 0, false
+	}
+}
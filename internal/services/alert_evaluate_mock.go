@@ -0,0 +1,201 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/alert_evaluate.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAlertEvaluateRuleRepository is a mock of AlertEvaluateRuleRepository interface.
+type MockAlertEvaluateRuleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertEvaluateRuleRepositoryMockRecorder
+}
+
+// MockAlertEvaluateRuleRepositoryMockRecorder is the mock recorder for MockAlertEvaluateRuleRepository.
+type MockAlertEvaluateRuleRepositoryMockRecorder struct {
+	mock *MockAlertEvaluateRuleRepository
+}
+
+// NewMockAlertEvaluateRuleRepository creates a new mock instance.
+func NewMockAlertEvaluateRuleRepository(ctrl *gomock.Controller) *MockAlertEvaluateRuleRepository {
+	mock := &MockAlertEvaluateRuleRepository{ctrl: ctrl}
+	mock.recorder = &MockAlertEvaluateRuleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertEvaluateRuleRepository) EXPECT() *MockAlertEvaluateRuleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockAlertEvaluateRuleRepository) Find(ctx context.Context) ([]*domain.AlertRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx)
+	ret0, _ := ret[0].([]*domain.AlertRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockAlertEvaluateRuleRepositoryMockRecorder) Find(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockAlertEvaluateRuleRepository)(nil).Find), ctx)
+}
+
+// MockAlertEvaluateMetricService is a mock of AlertEvaluateMetricService interface.
+type MockAlertEvaluateMetricService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertEvaluateMetricServiceMockRecorder
+}
+
+// MockAlertEvaluateMetricServiceMockRecorder is the mock recorder for MockAlertEvaluateMetricService.
+type MockAlertEvaluateMetricServiceMockRecorder struct {
+	mock *MockAlertEvaluateMetricService
+}
+
+// NewMockAlertEvaluateMetricService creates a new mock instance.
+func NewMockAlertEvaluateMetricService(ctrl *gomock.Controller) *MockAlertEvaluateMetricService {
+	mock := &MockAlertEvaluateMetricService{ctrl: ctrl}
+	mock.recorder = &MockAlertEvaluateMetricServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertEvaluateMetricService) EXPECT() *MockAlertEvaluateMetricServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockAlertEvaluateMetricService) List(ctx context.Context) ([]*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAlertEvaluateMetricServiceMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAlertEvaluateMetricService)(nil).List), ctx)
+}
+
+// MockAlertEvaluateSaveRepository is a mock of AlertEvaluateSaveRepository interface.
+type MockAlertEvaluateSaveRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertEvaluateSaveRepositoryMockRecorder
+}
+
+// MockAlertEvaluateSaveRepositoryMockRecorder is the mock recorder for MockAlertEvaluateSaveRepository.
+type MockAlertEvaluateSaveRepositoryMockRecorder struct {
+	mock *MockAlertEvaluateSaveRepository
+}
+
+// NewMockAlertEvaluateSaveRepository creates a new mock instance.
+func NewMockAlertEvaluateSaveRepository(ctrl *gomock.Controller) *MockAlertEvaluateSaveRepository {
+	mock := &MockAlertEvaluateSaveRepository{ctrl: ctrl}
+	mock.recorder = &MockAlertEvaluateSaveRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertEvaluateSaveRepository) EXPECT() *MockAlertEvaluateSaveRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Save mocks base method.
+func (m *MockAlertEvaluateSaveRepository) Save(ctx context.Context, alerts []*domain.Alert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, alerts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAlertEvaluateSaveRepositoryMockRecorder) Save(ctx, alerts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAlertEvaluateSaveRepository)(nil).Save), ctx, alerts)
+}
+
+// MockAlertEvaluateFindRepository is a mock of AlertEvaluateFindRepository interface.
+type MockAlertEvaluateFindRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertEvaluateFindRepositoryMockRecorder
+}
+
+// MockAlertEvaluateFindRepositoryMockRecorder is the mock recorder for MockAlertEvaluateFindRepository.
+type MockAlertEvaluateFindRepositoryMockRecorder struct {
+	mock *MockAlertEvaluateFindRepository
+}
+
+// NewMockAlertEvaluateFindRepository creates a new mock instance.
+func NewMockAlertEvaluateFindRepository(ctrl *gomock.Controller) *MockAlertEvaluateFindRepository {
+	mock := &MockAlertEvaluateFindRepository{ctrl: ctrl}
+	mock.recorder = &MockAlertEvaluateFindRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertEvaluateFindRepository) EXPECT() *MockAlertEvaluateFindRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockAlertEvaluateFindRepository) Find(ctx context.Context) ([]*domain.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx)
+	ret0, _ := ret[0].([]*domain.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockAlertEvaluateFindRepositoryMockRecorder) Find(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockAlertEvaluateFindRepository)(nil).Find), ctx)
+}
+
+// MockAlertEvaluateNotifier is a mock of AlertEvaluateNotifier interface.
+type MockAlertEvaluateNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertEvaluateNotifierMockRecorder
+}
+
+// MockAlertEvaluateNotifierMockRecorder is the mock recorder for MockAlertEvaluateNotifier.
+type MockAlertEvaluateNotifierMockRecorder struct {
+	mock *MockAlertEvaluateNotifier
+}
+
+// NewMockAlertEvaluateNotifier creates a new mock instance.
+func NewMockAlertEvaluateNotifier(ctrl *gomock.Controller) *MockAlertEvaluateNotifier {
+	mock := &MockAlertEvaluateNotifier{ctrl: ctrl}
+	mock.recorder = &MockAlertEvaluateNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertEvaluateNotifier) EXPECT() *MockAlertEvaluateNotifierMockRecorder {
+	return m.recorder
+}
+
+// Notify mocks base method.
+func (m *MockAlertEvaluateNotifier) Notify(ctx context.Context, alerts []*domain.Alert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, alerts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockAlertEvaluateNotifierMockRecorder) Notify(ctx, alerts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockAlertEvaluateNotifier)(nil).Notify), ctx, alerts)
+}
@@ -0,0 +1,225 @@
+package services_test
+
+import (
+	"context"
+	e "errors"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/services"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type alertEvaluateMocks struct {
+	rules    *services.MockAlertEvaluateRuleRepository
+	metrics  *services.MockAlertEvaluateMetricService
+	save     *services.MockAlertEvaluateSaveRepository
+	find     *services.MockAlertEvaluateFindRepository
+	notifier *services.MockAlertEvaluateNotifier
+}
+
+func newAlertEvaluateService(ctrl *gomock.Controller) (*services.AlertEvaluateService, *alertEvaluateMocks) {
+	mocks := &alertEvaluateMocks{
+		rules:    services.NewMockAlertEvaluateRuleRepository(ctrl),
+		metrics:  services.NewMockAlertEvaluateMetricService(ctrl),
+		save:     services.NewMockAlertEvaluateSaveRepository(ctrl),
+		find:     services.NewMockAlertEvaluateFindRepository(ctrl),
+		notifier: services.NewMockAlertEvaluateNotifier(ctrl),
+	}
+	service := services.NewAlertEvaluateService(mocks.rules, mocks.metrics, mocks.save, mocks.find, mocks.notifier)
+	return service, mocks
+}
+
+func mustAlertRule(t *testing.T, name string, expr string) *domain.AlertRule {
+	rule, err := domain.NewAlertRule(name, expr)
+	require.NoError(t, err)
+	return rule
+}
+
+func TestEvaluate_ThresholdBecomesPending(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, mocks := newAlertEvaluateService(ctrl)
+	now := time.Unix(1000, 0)
+	value := 6e8
+	mocks.rules.EXPECT().Find(gomock.Any()).Return([]*domain.AlertRule{mustAlertRule(t, "heap", "HeapAlloc > 5e8 for 2m")}, nil)
+	mocks.metrics.EXPECT().List(gomock.Any()).Return([]*domain.Metric{
+		{MetricID: domain.MetricID{ID: "HeapAlloc", Type: domain.Gauge}, Value: &value},
+		{MetricID: domain.MetricID{ID: "HeapSys", Type: domain.Gauge}, Value: &value},
+	}, nil)
+	var saved, notified, acknowledged []*domain.Alert
+	mocks.find.EXPECT().Find(gomock.Any()).Return(nil, nil)
+	mocks.find.EXPECT().Find(gomock.Any()).DoAndReturn(func(_ context.Context) ([]*domain.Alert, error) {
+		return []*domain.Alert{saved[0].Clone()}, nil
+	})
+	gomock.InOrder(
+		mocks.save.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, alerts []*domain.Alert) error {
+			saved = alerts
+			return nil
+		}),
+		mocks.notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, alerts []*domain.Alert) error {
+			notified = alerts
+			return nil
+		}),
+		mocks.save.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, alerts []*domain.Alert) error {
+			acknowledged = alerts
+			return nil
+		}),
+	)
+
+	require.NoError(t, service.Evaluate(context.Background(), now))
+	require.Len(t, saved, 1)
+	assert.Equal(t, "heap", saved[0].Name)
+	assert.Equal(t, domain.AlertPending, saved[0].State)
+	assert.Equal(t, value, *saved[0].Value)
+	assert.Equal(t, now, *saved[0].ActiveAt)
+	assert.Equal(t, saved, notified)
+	require.Len(t, acknowledged, 1)
+	assert.Equal(t, domain.AlertPending, acknowledged[0].State)
+	assert.False(t, acknowledged[0].Unsent)
+}
+
+func TestEvaluate_PendingBecomesFiring(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, mocks := newAlertEvaluateService(ctrl)
+	activeAt := time.Unix(1000, 0)
+	value := 6e8
+	mocks.rules.EXPECT().Find(gomock.Any()).Return([]*domain.AlertRule{mustAlertRule(t, "heap", "HeapAlloc > 5e8 for 2m")}, nil)
+	mocks.metrics.EXPECT().List(gomock.Any()).Return([]*domain.Metric{
+		{MetricID: domain.MetricID{ID: "HeapAlloc", Type: domain.Gauge}, Value: &value},
+	}, nil)
+	mocks.find.EXPECT().Find(gomock.Any()).Return([]*domain.Alert{
+		{AlertID: domain.AlertID{Name: "heap"}, State: domain.AlertPending, ActiveAt: &activeAt},
+	}, nil)
+	mocks.find.EXPECT().Find(gomock.Any()).Return(nil, nil)
+	mocks.save.EXPECT().Save(gomock.Any(), gomock.Len(1)).Return(nil)
+	var notified []*domain.Alert
+	mocks.notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, alerts []*domain.Alert) error {
+		notified = alerts
+		return nil
+	})
+
+	require.NoError(t, service.Evaluate(context.Background(), activeAt.Add(2*time.Minute)))
+	require.Len(t, notified, 1)
+	assert.Equal(t, domain.AlertFiring, notified[0].State)
+}
+
+func TestEvaluate_RateNeedsTwoSamples(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, mocks := newAlertEvaluateService(ctrl)
+	start := time.Unix(1000, 0)
+	delta := int64(10)
+	mocks.rules.EXPECT().Find(gomock.Any()).Return([]*domain.AlertRule{mustAlertRule(t, "stalled", "rate(PollCount) == 0")}, nil).Times(2)
+	mocks.metrics.EXPECT().List(gomock.Any()).Return([]*domain.Metric{
+		{MetricID: domain.MetricID{ID: "PollCount", Type: domain.Counter}, Delta: &delta},
+	}, nil).Times(2)
+	mocks.find.EXPECT().Find(gomock.Any()).Return(nil, nil).Times(3)
+	var saved [][]*domain.Alert
+	mocks.save.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, alerts []*domain.Alert) error {
+		saved = append(saved, alerts)
+		return nil
+	}).Times(2)
+	mocks.notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	require.NoError(t, service.Evaluate(context.Background(), start))
+	require.NoError(t, service.Evaluate(context.Background(), start.Add(time.Minute)))
+	assert.Empty(t, saved[0])
+	require.Len(t, saved[1], 1)
+	assert.Equal(t, domain.AlertFiring, saved[1][0].State)
+	assert.Equal(t, 0.0, *saved[1][0].Value)
+}
+
+func TestEvaluate_FiringBecomesResolved(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, mocks := newAlertEvaluateService(ctrl)
+	now := time.Unix(1000, 0)
+	value := 1.0
+	mocks.rules.EXPECT().Find(gomock.Any()).Return([]*domain.AlertRule{mustAlertRule(t, "heap", "HeapAlloc > 5e8")}, nil)
+	mocks.metrics.EXPECT().List(gomock.Any()).Return([]*domain.Metric{
+		{MetricID: domain.MetricID{ID: "HeapAlloc", Type: domain.Gauge}, Value: &value},
+	}, nil)
+	mocks.find.EXPECT().Find(gomock.Any()).Return([]*domain.Alert{
+		{AlertID: domain.AlertID{Name: "heap"}, State: domain.AlertFiring},
+		{AlertID: domain.AlertID{Name: "removed"}, State: domain.AlertFiring},
+	}, nil)
+	mocks.find.EXPECT().Find(gomock.Any()).Return(nil, nil)
+	var notified []*domain.Alert
+	mocks.save.EXPECT().Save(gomock.Any(), gomock.Len(2)).Return(nil)
+	mocks.notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, alerts []*domain.Alert) error {
+		notified = alerts
+		return nil
+	})
+
+	require.NoError(t, service.Evaluate(context.Background(), now))
+	require.Len(t, notified, 2)
+	for _, alert := range notified {
+		assert.Equal(t, domain.AlertResolved, alert.State)
+		assert.Equal(t, now, *alert.ResolvedAt)
+	}
+}
+
+func TestEvaluate_RuleError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, mocks := newAlertEvaluateService(ctrl)
+	mocks.rules.EXPECT().Find(gomock.Any()).Return(nil, e.New("rules error"))
+
+	err := service.Evaluate(context.Background(), time.Now())
+	assert.EqualError(t, err, "rules error")
+}
+
+func TestEvaluate_NotifyError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, mocks := newAlertEvaluateService(ctrl)
+	value := 6e8
+	mocks.rules.EXPECT().Find(gomock.Any()).Return([]*domain.AlertRule{mustAlertRule(t, "heap", "HeapAlloc > 5e8")}, nil)
+	mocks.metrics.EXPECT().List(gomock.Any()).Return([]*domain.Metric{
+		{MetricID: domain.MetricID{ID: "HeapAlloc", Type: domain.Gauge}, Value: &value},
+	}, nil)
+	mocks.find.EXPECT().Find(gomock.Any()).Return(nil, nil)
+	mocks.save.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	mocks.notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).Return(e.New("webhook error"))
+
+	err := service.Evaluate(context.Background(), time.Now())
+	assert.EqualError(t, err, "webhook error")
+}
+
+func TestEvaluate_RetriesUnsentAlerts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service, mocks := newAlertEvaluateService(ctrl)
+	now := time.Unix(1000, 0)
+	value := 6e8
+	mocks.rules.EXPECT().Find(gomock.Any()).Return([]*domain.AlertRule{mustAlertRule(t, "heap", "HeapAlloc > 5e8")}, nil)
+	mocks.metrics.EXPECT().List(gomock.Any()).Return([]*domain.Metric{
+		{MetricID: domain.MetricID{ID: "HeapAlloc", Type: domain.Gauge}, Value: &value},
+	}, nil)
+	firing := &domain.Alert{
+		AlertID: domain.AlertID{Name: "heap"}, State: domain.AlertFiring, ActiveAt: &now, FiredAt: &now, Unsent: true,
+	}
+	mocks.find.EXPECT().Find(gomock.Any()).Return([]*domain.Alert{firing}, nil)
+	mocks.find.EXPECT().Find(gomock.Any()).Return([]*domain.Alert{firing.Clone()}, nil)
+	mocks.save.EXPECT().Save(gomock.Any(), gomock.Len(1)).Return(nil)
+	var notified, acknowledged []*domain.Alert
+	mocks.notifier.EXPECT().Notify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, alerts []*domain.Alert) error {
+		notified = alerts
+		return nil
+	})
+	mocks.save.EXPECT().Save(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, alerts []*domain.Alert) error {
+		acknowledged = alerts
+		return nil
+	})
+
+	require.NoError(t, service.Evaluate(context.Background(), now.Add(time.Minute)))
+	require.Len(t, notified, 1)
+	assert.Equal(t, domain.AlertFiring, notified[0].State)
+	require.Len(t, acknowledged, 1)
+	assert.False(t, acknowledged[0].Unsent)
+}
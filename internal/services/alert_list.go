@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+)
+
+type AlertListFindRepository interface {
+	Find(ctx context.Context) ([]*domain.Alert, error)
+}
+
+type AlertListService struct {
+	f AlertListFindRepository
+}
+
+func NewAlertListService(
+	f AlertListFindRepository,
+) *AlertListService {
+	return &AlertListService{
+		f: f,
+	}
+}
+
+func (s *AlertListService) List(ctx context.Context) ([]*domain.Alert, error) {
+	alerts, err := s.f.Find(ctx)
+	if err != nil {
+		return nil, errors.ErrAlertListInternal
+	}
+	return alerts, nil
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/alert_list.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAlertListFindRepository is a mock of AlertListFindRepository interface.
+type MockAlertListFindRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertListFindRepositoryMockRecorder
+}
+
+// MockAlertListFindRepositoryMockRecorder is the mock recorder for MockAlertListFindRepository.
+type MockAlertListFindRepositoryMockRecorder struct {
+	mock *MockAlertListFindRepository
+}
+
+// NewMockAlertListFindRepository creates a new mock instance.
+func NewMockAlertListFindRepository(ctrl *gomock.Controller) *MockAlertListFindRepository {
+	mock := &MockAlertListFindRepository{ctrl: ctrl}
+	mock.recorder = &MockAlertListFindRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertListFindRepository) EXPECT() *MockAlertListFindRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockAlertListFindRepository) Find(ctx context.Context) ([]*domain.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx)
+	ret0, _ := ret[0].([]*domain.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockAlertListFindRepositoryMockRecorder) Find(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockAlertListFindRepository)(nil).Find), ctx)
+}
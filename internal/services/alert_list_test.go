@@ -0,0 +1,37 @@
+package services_test
+
+import (
+	"context"
+	e "errors"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/services"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertList_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFindRepo := services.NewMockAlertListFindRepository(ctrl)
+	alerts := []*domain.Alert{{AlertID: domain.AlertID{Name: "heap"}, State: domain.AlertFiring}}
+	mockFindRepo.EXPECT().Find(gomock.Any()).Return(alerts, nil).Times(1)
+	service := services.NewAlertListService(mockFindRepo)
+	result, err := service.List(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, alerts, result)
+}
+
+func TestAlertList_FindError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFindRepo := services.NewMockAlertListFindRepository(ctrl)
+	mockFindRepo.EXPECT().Find(gomock.Any()).Return(nil, e.New("find error")).Times(1)
+	service := services.NewAlertListService(mockFindRepo)
+	result, err := service.List(context.Background())
+	assert.Nil(t, result)
+	assert.Equal(t, errors.ErrAlertListInternal, err)
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"go-metrics/internal/domain"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type DerivedMetricRuleRepository interface {
+	Find(ctx context.Context) ([]*domain.DerivedMetricRule, error)
+}
+
+type DerivedMetricListService interface {
+	List(ctx context.Context) ([]*domain.Metric, error)
+}
+
+type DerivedMetricHistoryService interface {
+	History(ctx context.Context, id *domain.MetricID, from time.Time, to time.Time) ([]*domain.MetricSample, error)
+}
+
+type DerivedMetricUpdateService interface {
+	Update(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error)
+}
+
+type DerivedMetricEvaluateService struct {
+	r       DerivedMetricRuleRepository
+	m       DerivedMetricListService
+	h       DerivedMetricHistoryService
+	u       DerivedMetricUpdateService
+	rules   []*domain.DerivedMetricRule
+	written map[string]time.Time
+	mu      sync.Mutex
+}
+
+func NewDerivedMetricEvaluateService(
+	r DerivedMetricRuleRepository,
+	m DerivedMetricListService,
+	h DerivedMetricHistoryService,
+	u DerivedMetricUpdateService,
+) *DerivedMetricEvaluateService {
+	return &DerivedMetricEvaluateService{
+		r:       r,
+		m:       m,
+		h:       h,
+		u:       u,
+		written: make(map[string]time.Time),
+	}
+}
+
+func (s *DerivedMetricEvaluateService) Load(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	rules, err := s.r.Find(ctx)
+	if err != nil {
+		return 0, err
+	}
+	rules, err = domain.SortDerivedMetricRules(rules)
+	if err != nil {
+		return 0, err
+	}
+	metrics, err := s.m.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if conflicts := derivedMetricConflicts(rules, metrics, nil); len(conflicts) > 0 {
+		names := make([]string, 0, len(conflicts))
+		for name := range conflicts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return 0, fmt.Errorf("%w: %s", domain.ErrDerivedMetricConflict, strings.Join(names, ", "))
+	}
+	known := make(map[string]bool, len(metrics)+len(rules))
+	for id := range derivedMetricSources(metrics) {
+		known[id] = true
+	}
+	for _, rule := range rules {
+		known[rule.Name] = true
+	}
+	for _, rule := range rules {
+		for _, ref := range rule.References() {
+			if !known[ref] {
+				return 0, fmt.Errorf("%w: %s uses %q", domain.ErrUnknownDerivedMetricSource, rule.Name, ref)
+			}
+		}
+	}
+	s.rules = rules
+	return len(rules), nil
+}
+
+func (s *DerivedMetricEvaluateService) Evaluate(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.rules) == 0 {
+		return nil
+	}
+	metrics, err := s.m.List(ctx)
+	if err != nil {
+		return err
+	}
+	conflicts := derivedMetricConflicts(s.rules, metrics, s.written)
+	env := &derivedMetricEnv{
+		ctx:     ctx,
+		h:       s.h,
+		now:     now,
+		sources: derivedMetricSources(metrics),
+	}
+	updatedAt := now.Truncate(time.Microsecond)
+	derived := make([]*domain.Metric, 0, len(s.rules))
+	var conflicted []string
+	for _, rule := range s.rules {
+		if conflicts[rule.Name] {
+			delete(env.sources, rule.Name)
+			conflicted = append(conflicted, rule.Name)
+			continue
+		}
+		value, ok := rule.Eval(env)
+		if !ok || math.IsNaN(value) || math.IsInf(value, 0) {
+			delete(env.sources, rule.Name)
+			continue
+		}
+		id := domain.MetricID{ID: rule.Name, Type: domain.Gauge}
+		env.sources[rule.Name] = &domain.Metric{MetricID: id, Value: &value}
+		derived = append(derived, &domain.Metric{MetricID: id, Value: &value, UpdatedAt: updatedAt})
+	}
+	if env.err != nil {
+		return env.err
+	}
+	if len(derived) > 0 {
+		updated, err := s.u.Update(ctx, derived)
+		if err != nil {
+			return err
+		}
+		for _, metric := range updated {
+			s.written[metric.ID] = metric.UpdatedAt
+		}
+	}
+	if len(conflicted) > 0 {
+		return fmt.Errorf("%w: %s", domain.ErrDerivedMetricConflict, strings.Join(conflicted, ", "))
+	}
+	return nil
+}
+
+func derivedMetricConflicts(
+	rules []*domain.DerivedMetricRule, metrics []*domain.Metric, written map[string]time.Time,
+) map[string]bool {
+	names := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		names[rule.Name] = true
+	}
+	conflicts := make(map[string]bool)
+	for _, metric := range metrics {
+		if !names[metric.ID] {
+			continue
+		}
+		if metric.Type != domain.Gauge || metric.Labels != "" {
+			conflicts[metric.ID] = true
+			continue
+		}
+		if last, ok := written[metric.ID]; ok && !metric.UpdatedAt.Equal(last) {
+			conflicts[metric.ID] = true
+		}
+	}
+	return conflicts
+}
+
+func derivedMetricSources(metrics []*domain.Metric) map[string]*domain.Metric {
+	sources := make(map[string]*domain.Metric)
+	for _, metric := range metrics {
+		if metric.Labels != "" {
+			continue
+		}
+		switch {
+		case metric.Type == domain.Gauge && metric.Value != nil:
+			sources[metric.ID] = metric
+		case metric.Type == domain.Counter && metric.Delta != nil:
+			if _, exists := sources[metric.ID]; !exists {
+				sources[metric.ID] = metric
+			}
+		}
+	}
+	return sources
+}
+
+type derivedMetricEnv struct {
+	ctx     context.Context
+	h       DerivedMetricHistoryService
+	now     time.Time
+	sources map[string]*domain.Metric
+	err     error
+}
+
+func (env *derivedMetricEnv) Value(id string) (float64, bool) {
+	metric, ok := env.sources[id]
+	if !ok {
+		return 0, false
+	}
+	if metric.Type == domain.Counter {
+		return float64(*metric.Delta), true
+	}
+	return *metric.Value, true
+}
+
+func (env *derivedMetricEnv) Rate(id string, window time.Duration) (float64, bool) {
+	metric, ok := env.sources[id]
+	if !ok {
+		return 0, false
+	}
+	samples, err := env.h.History(env.ctx, &metric.MetricID, env.now.Add(-window), env.now)
+	if err != nil {
+		if env.err == nil {
+			env.err = err
+		}
+		return 0, false
+	}
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].Timestamp.Before(samples[j].Timestamp)
+	})
+	return domain.DerivedRate(samples, metric.Type == domain.Counter)
+}
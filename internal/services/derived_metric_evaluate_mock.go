@@ -0,0 +1,166 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/derived_metric_evaluate.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockDerivedMetricRuleRepository is a mock of DerivedMetricRuleRepository interface.
+type MockDerivedMetricRuleRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockDerivedMetricRuleRepositoryMockRecorder
+}
+
+// MockDerivedMetricRuleRepositoryMockRecorder is the mock recorder for MockDerivedMetricRuleRepository.
+type MockDerivedMetricRuleRepositoryMockRecorder struct {
+	mock *MockDerivedMetricRuleRepository
+}
+
+// NewMockDerivedMetricRuleRepository creates a new mock instance.
+func NewMockDerivedMetricRuleRepository(ctrl *gomock.Controller) *MockDerivedMetricRuleRepository {
+	mock := &MockDerivedMetricRuleRepository{ctrl: ctrl}
+	mock.recorder = &MockDerivedMetricRuleRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDerivedMetricRuleRepository) EXPECT() *MockDerivedMetricRuleRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockDerivedMetricRuleRepository) Find(ctx context.Context) ([]*domain.DerivedMetricRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx)
+	ret0, _ := ret[0].([]*domain.DerivedMetricRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockDerivedMetricRuleRepositoryMockRecorder) Find(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockDerivedMetricRuleRepository)(nil).Find), ctx)
+}
+
+// MockDerivedMetricListService is a mock of DerivedMetricListService interface.
+type MockDerivedMetricListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDerivedMetricListServiceMockRecorder
+}
+
+// MockDerivedMetricListServiceMockRecorder is the mock recorder for MockDerivedMetricListService.
+type MockDerivedMetricListServiceMockRecorder struct {
+	mock *MockDerivedMetricListService
+}
+
+// NewMockDerivedMetricListService creates a new mock instance.
+func NewMockDerivedMetricListService(ctrl *gomock.Controller) *MockDerivedMetricListService {
+	mock := &MockDerivedMetricListService{ctrl: ctrl}
+	mock.recorder = &MockDerivedMetricListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDerivedMetricListService) EXPECT() *MockDerivedMetricListServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockDerivedMetricListService) List(ctx context.Context) ([]*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDerivedMetricListServiceMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDerivedMetricListService)(nil).List), ctx)
+}
+
+// MockDerivedMetricHistoryService is a mock of DerivedMetricHistoryService interface.
+type MockDerivedMetricHistoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDerivedMetricHistoryServiceMockRecorder
+}
+
+// MockDerivedMetricHistoryServiceMockRecorder is the mock recorder for MockDerivedMetricHistoryService.
+type MockDerivedMetricHistoryServiceMockRecorder struct {
+	mock *MockDerivedMetricHistoryService
+}
+
+// NewMockDerivedMetricHistoryService creates a new mock instance.
+func NewMockDerivedMetricHistoryService(ctrl *gomock.Controller) *MockDerivedMetricHistoryService {
+	mock := &MockDerivedMetricHistoryService{ctrl: ctrl}
+	mock.recorder = &MockDerivedMetricHistoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDerivedMetricHistoryService) EXPECT() *MockDerivedMetricHistoryServiceMockRecorder {
+	return m.recorder
+}
+
+// History mocks base method.
+func (m *MockDerivedMetricHistoryService) History(ctx context.Context, id *domain.MetricID, from, to time.Time) ([]*domain.MetricSample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, id, from, to)
+	ret0, _ := ret[0].([]*domain.MetricSample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockDerivedMetricHistoryServiceMockRecorder) History(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockDerivedMetricHistoryService)(nil).History), ctx, id, from, to)
+}
+
+// MockDerivedMetricUpdateService is a mock of DerivedMetricUpdateService interface.
+type MockDerivedMetricUpdateService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDerivedMetricUpdateServiceMockRecorder
+}
+
+// MockDerivedMetricUpdateServiceMockRecorder is the mock recorder for MockDerivedMetricUpdateService.
+type MockDerivedMetricUpdateServiceMockRecorder struct {
+	mock *MockDerivedMetricUpdateService
+}
+
+// NewMockDerivedMetricUpdateService creates a new mock instance.
+func NewMockDerivedMetricUpdateService(ctrl *gomock.Controller) *MockDerivedMetricUpdateService {
+	mock := &MockDerivedMetricUpdateService{ctrl: ctrl}
+	mock.recorder = &MockDerivedMetricUpdateServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDerivedMetricUpdateService) EXPECT() *MockDerivedMetricUpdateServiceMockRecorder {
+	return m.recorder
+}
+
+// Update mocks base method.
+func (m *MockDerivedMetricUpdateService) Update(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, metrics)
+	ret0, _ := ret[0].([]*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDerivedMetricUpdateServiceMockRecorder) Update(ctx, metrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDerivedMetricUpdateService)(nil).Update), ctx, metrics)
+}
@@ -0,0 +1,176 @@
+package services_test
+
+import (
+	"context"
+	e "errors"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/services"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type derivedMetricMocks struct {
+	rules   *services.MockDerivedMetricRuleRepository
+	metrics *services.MockDerivedMetricListService
+	history *services.MockDerivedMetricHistoryService
+	update  *services.MockDerivedMetricUpdateService
+}
+
+func newDerivedMetricService(t *testing.T, exprs ...string) (*services.DerivedMetricEvaluateService, *derivedMetricMocks) {
+	ctrl := gomock.NewController(t)
+	m := &derivedMetricMocks{
+		rules:   services.NewMockDerivedMetricRuleRepository(ctrl),
+		metrics: services.NewMockDerivedMetricListService(ctrl),
+		history: services.NewMockDerivedMetricHistoryService(ctrl),
+		update:  services.NewMockDerivedMetricUpdateService(ctrl),
+	}
+	rules := make([]*domain.DerivedMetricRule, 0, len(exprs))
+	for _, expr := range exprs {
+		rule, err := domain.NewDerivedMetricRule("", expr)
+		require.NoError(t, err)
+		rules = append(rules, rule)
+	}
+	m.rules.EXPECT().Find(gomock.Any()).Return(rules, nil).AnyTimes()
+	return services.NewDerivedMetricEvaluateService(m.rules, m.metrics, m.history, m.update), m
+}
+
+func derivedGauge(id string, value float64) *domain.Metric {
+	return &domain.Metric{MetricID: domain.MetricID{ID: id, Type: domain.Gauge}, Value: &value}
+}
+
+func derivedCounter(id string, delta int64) *domain.Metric {
+	return &domain.Metric{MetricID: domain.MetricID{ID: id, Type: domain.Counter}, Delta: &delta}
+}
+
+func TestDerivedMetricEvaluateService_Load(t *testing.T) {
+	stored := []*domain.Metric{derivedGauge("HeapAlloc", 1), derivedGauge("HeapSys", 2)}
+
+	service, m := newDerivedMetricService(t, "HeapFree = HeapSys - HeapAlloc", "HeapRatio = HeapFree / HeapSys")
+	m.metrics.EXPECT().List(gomock.Any()).Return(stored, nil)
+	count, err := service.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	service, m = newDerivedMetricService(t, "HeapFree = HeapSys - Missing")
+	m.metrics.EXPECT().List(gomock.Any()).Return(stored, nil)
+	_, err = service.Load(context.Background())
+	require.ErrorIs(t, err, domain.ErrUnknownDerivedMetricSource)
+	assert.Contains(t, err.Error(), `"Missing"`)
+
+	service, m = newDerivedMetricService(t, "labelled = HeapAlloc + Tagged")
+	m.metrics.EXPECT().List(gomock.Any()).Return(append(stored, &domain.Metric{
+		MetricID: domain.MetricID{ID: "Tagged", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"a": "b"})},
+	}), nil)
+	_, err = service.Load(context.Background())
+	assert.ErrorIs(t, err, domain.ErrUnknownDerivedMetricSource)
+
+	service, m = newDerivedMetricService(t, "Tagged = HeapAlloc + 1", "PollCount = HeapAlloc")
+	m.metrics.EXPECT().List(gomock.Any()).Return(append(stored, derivedCounter("PollCount", 1), &domain.Metric{
+		MetricID: domain.MetricID{ID: "Tagged", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"a": "b"})},
+	}), nil)
+	_, err = service.Load(context.Background())
+	require.ErrorIs(t, err, domain.ErrDerivedMetricConflict)
+	assert.Contains(t, err.Error(), "PollCount, Tagged")
+
+	service, _ = newDerivedMetricService(t, "HeapAlloc = HeapAlloc * 2")
+	_, err = service.Load(context.Background())
+	assert.ErrorIs(t, err, domain.ErrDerivedMetricCycle)
+
+	service, _ = newDerivedMetricService(t, "a = b", "b = a")
+	_, err = service.Load(context.Background())
+	assert.ErrorIs(t, err, domain.ErrDerivedMetricCycle)
+
+	service, m = newDerivedMetricService(t, "a = HeapAlloc")
+	m.metrics.EXPECT().List(gomock.Any()).Return(nil, e.New("list error"))
+	_, err = service.Load(context.Background())
+	assert.EqualError(t, err, "list error")
+}
+
+func TestDerivedMetricEvaluateService_Evaluate(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	stored := []*domain.Metric{
+		derivedGauge("HeapAlloc", 25),
+		derivedGauge("HeapSys", 100),
+		derivedCounter("TotalAlloc", 900),
+		derivedGauge("Zero", 0),
+		derivedGauge("HeapFree", 1),
+	}
+	service, m := newDerivedMetricService(t,
+		"HeapRatio = HeapFree / HeapSys",
+		"HeapFree = HeapSys - HeapAlloc",
+		"AllocRate = rate(TotalAlloc[1m])",
+		"Broken = HeapAlloc / Zero",
+		"DependsOnBroken = Broken + 1",
+	)
+	m.metrics.EXPECT().List(gomock.Any()).Return(stored, nil).Times(2)
+	_, err := service.Load(context.Background())
+	require.NoError(t, err)
+
+	m.history.EXPECT().History(gomock.Any(), &domain.MetricID{ID: "TotalAlloc", Type: domain.Counter}, now.Add(-time.Minute), now).
+		Return([]*domain.MetricSample{
+			{Metric: *derivedCounter("TotalAlloc", 900), Timestamp: now},
+			{Metric: *derivedCounter("TotalAlloc", 300), Timestamp: now.Add(-time.Minute)},
+		}, nil)
+	m.update.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+			values := make(map[string]float64, len(metrics))
+			for _, metric := range metrics {
+				assert.Equal(t, domain.Gauge, metric.Type)
+				values[metric.ID] = *metric.Value
+			}
+			assert.Equal(t, map[string]float64{"HeapFree": 75, "HeapRatio": 0.75, "AllocRate": 10}, values)
+			return metrics, nil
+		})
+	require.NoError(t, service.Evaluate(context.Background(), now))
+}
+
+func TestDerivedMetricEvaluateService_EvaluateHistoryError(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	service, m := newDerivedMetricService(t, "AllocRate = rate(TotalAlloc[1m])")
+	m.metrics.EXPECT().List(gomock.Any()).Return([]*domain.Metric{derivedCounter("TotalAlloc", 1)}, nil).Times(2)
+	_, err := service.Load(context.Background())
+	require.NoError(t, err)
+
+	m.history.EXPECT().History(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, e.New("history error"))
+	assert.EqualError(t, service.Evaluate(context.Background(), now), "history error")
+}
+
+func TestDerivedMetricEvaluateService_EvaluateWithoutRules(t *testing.T) {
+	service, _ := newDerivedMetricService(t)
+	assert.NoError(t, service.Evaluate(context.Background(), time.Now()))
+}
+
+func TestDerivedMetricEvaluateService_EvaluateMissingSource(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	service, m := newDerivedMetricService(t, "Ratio = A / B")
+	m.metrics.EXPECT().List(gomock.Any()).Return([]*domain.Metric{derivedGauge("A", 1), derivedGauge("B", 2)}, nil)
+	_, err := service.Load(context.Background())
+	require.NoError(t, err)
+	m.metrics.EXPECT().List(gomock.Any()).Return(nil, nil)
+	assert.NoError(t, service.Evaluate(context.Background(), now))
+}
+
+func TestDerivedMetricEvaluateService_EvaluateDetectsForeignWrites(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	service, m := newDerivedMetricService(t, "Double = Source * 2", "Quad = Double * 2")
+	source := derivedGauge("Source", 1)
+	m.metrics.EXPECT().List(gomock.Any()).Return([]*domain.Metric{source}, nil).Times(2)
+	_, err := service.Load(context.Background())
+	require.NoError(t, err)
+	m.update.EXPECT().Update(gomock.Any(), gomock.Len(2)).DoAndReturn(
+		func(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+			return metrics, nil
+		})
+	require.NoError(t, service.Evaluate(context.Background(), now))
+
+	foreign := derivedGauge("Double", 10)
+	foreign.UpdatedAt = now.Add(time.Second)
+	m.metrics.EXPECT().List(gomock.Any()).Return([]*domain.Metric{source, foreign}, nil)
+	err = service.Evaluate(context.Background(), now.Add(time.Minute))
+	require.ErrorIs(t, err, domain.ErrDerivedMetricConflict)
+	assert.Contains(t, err.Error(), "Double")
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+)
+
+type MetricDeleteFindRepository interface {
+	Find(ctx context.Context, filters []*domain.MetricID) (map[domain.MetricID]*domain.Metric, error)
+}
+
+type MetricDeleteRepository interface {
+	Delete(ctx context.Context, ids []*domain.MetricID) error
+}
+
+type MetricDeleteHistoryRepository interface {
+	Delete(ctx context.Context, ids []*domain.MetricID) error
+}
+
+type MetricDeletePublisher interface {
+	Sequence() uint64
+	Publish(events []*domain.MetricEvent)
+}
+
+type MetricDeleteService struct {
+	f MetricDeleteFindRepository
+	d MetricDeleteRepository
+	h MetricDeleteHistoryRepository
+	u UnitOfWork
+	p MetricDeletePublisher
+}
+
+func NewMetricDeleteService(
+	f MetricDeleteFindRepository,
+	d MetricDeleteRepository,
+	h MetricDeleteHistoryRepository,
+	u UnitOfWork,
+	p MetricDeletePublisher,
+) *MetricDeleteService {
+	return &MetricDeleteService{
+		f: f,
+		d: d,
+		h: h,
+		u: u,
+		p: p,
+	}
+}
+
+func (s *MetricDeleteService) Delete(
+	ctx context.Context, ids []*domain.MetricID,
+) ([]*domain.Metric, error) {
+	if len(ids) == 0 {
+		return []*domain.Metric{}, nil
+	}
+	var deletedMetrics []*domain.Metric
+	var seq uint64
+	err := s.u.Do(ctx, func(ctx context.Context) error {
+		existingMetrics, err := s.f.Find(ctx, ids)
+		if err != nil {
+			return errors.ErrMetricIsNotDeleted
+		}
+		deletedMetrics = make([]*domain.Metric, 0, len(existingMetrics))
+		deletedIDs := make([]*domain.MetricID, 0, len(existingMetrics))
+		for _, id := range ids {
+			metric, exists := existingMetrics[*id]
+			if !exists {
+				continue
+			}
+			delete(existingMetrics, *id)
+			deletedMetrics = append(deletedMetrics, metric)
+			deletedIDs = append(deletedIDs, &metric.MetricID)
+		}
+		if len(deletedIDs) == 0 {
+			return nil
+		}
+		if err := s.d.Delete(ctx, deletedIDs); err != nil {
+			return errors.ErrMetricIsNotDeleted
+		}
+		if err := s.h.Delete(ctx, deletedIDs); err != nil {
+			return errors.ErrMetricIsNotDeleted
+		}
+		if s.p != nil {
+			seq = s.p.Sequence()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if s.p != nil && len(deletedMetrics) > 0 {
+		s.p.Publish(domain.NewMetricEvents(domain.MetricEventDeleted, seq, deletedMetrics))
+	}
+	return deletedMetrics, nil
+}
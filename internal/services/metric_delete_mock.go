@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/metric_delete.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricDeleteFindRepository is a mock of MetricDeleteFindRepository interface.
+type MockMetricDeleteFindRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricDeleteFindRepositoryMockRecorder
+}
+
+// MockMetricDeleteFindRepositoryMockRecorder is the mock recorder for MockMetricDeleteFindRepository.
+type MockMetricDeleteFindRepositoryMockRecorder struct {
+	mock *MockMetricDeleteFindRepository
+}
+
+// NewMockMetricDeleteFindRepository creates a new mock instance.
+func NewMockMetricDeleteFindRepository(ctrl *gomock.Controller) *MockMetricDeleteFindRepository {
+	mock := &MockMetricDeleteFindRepository{ctrl: ctrl}
+	mock.recorder = &MockMetricDeleteFindRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricDeleteFindRepository) EXPECT() *MockMetricDeleteFindRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockMetricDeleteFindRepository) Find(ctx context.Context, filters []*domain.MetricID) (map[domain.MetricID]*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, filters)
+	ret0, _ := ret[0].(map[domain.MetricID]*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockMetricDeleteFindRepositoryMockRecorder) Find(ctx, filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockMetricDeleteFindRepository)(nil).Find), ctx, filters)
+}
+
+// MockMetricDeleteRepository is a mock of MetricDeleteRepository interface.
+type MockMetricDeleteRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricDeleteRepositoryMockRecorder
+}
+
+// MockMetricDeleteRepositoryMockRecorder is the mock recorder for MockMetricDeleteRepository.
+type MockMetricDeleteRepositoryMockRecorder struct {
+	mock *MockMetricDeleteRepository
+}
+
+// NewMockMetricDeleteRepository creates a new mock instance.
+func NewMockMetricDeleteRepository(ctrl *gomock.Controller) *MockMetricDeleteRepository {
+	mock := &MockMetricDeleteRepository{ctrl: ctrl}
+	mock.recorder = &MockMetricDeleteRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricDeleteRepository) EXPECT() *MockMetricDeleteRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockMetricDeleteRepository) Delete(ctx context.Context, ids []*domain.MetricID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMetricDeleteRepositoryMockRecorder) Delete(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMetricDeleteRepository)(nil).Delete), ctx, ids)
+}
+
+// MockMetricDeleteHistoryRepository is a mock of MetricDeleteHistoryRepository interface.
+type MockMetricDeleteHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricDeleteHistoryRepositoryMockRecorder
+}
+
+// MockMetricDeleteHistoryRepositoryMockRecorder is the mock recorder for MockMetricDeleteHistoryRepository.
+type MockMetricDeleteHistoryRepositoryMockRecorder struct {
+	mock *MockMetricDeleteHistoryRepository
+}
+
+// NewMockMetricDeleteHistoryRepository creates a new mock instance.
+func NewMockMetricDeleteHistoryRepository(ctrl *gomock.Controller) *MockMetricDeleteHistoryRepository {
+	mock := &MockMetricDeleteHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockMetricDeleteHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricDeleteHistoryRepository) EXPECT() *MockMetricDeleteHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockMetricDeleteHistoryRepository) Delete(ctx context.Context, ids []*domain.MetricID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMetricDeleteHistoryRepositoryMockRecorder) Delete(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMetricDeleteHistoryRepository)(nil).Delete), ctx, ids)
+}
+
+// MockMetricDeletePublisher is a mock of MetricDeletePublisher interface.
+type MockMetricDeletePublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricDeletePublisherMockRecorder
+}
+
+// MockMetricDeletePublisherMockRecorder is the mock recorder for MockMetricDeletePublisher.
+type MockMetricDeletePublisherMockRecorder struct {
+	mock *MockMetricDeletePublisher
+}
+
+// NewMockMetricDeletePublisher creates a new mock instance.
+func NewMockMetricDeletePublisher(ctrl *gomock.Controller) *MockMetricDeletePublisher {
+	mock := &MockMetricDeletePublisher{ctrl: ctrl}
+	mock.recorder = &MockMetricDeletePublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricDeletePublisher) EXPECT() *MockMetricDeletePublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockMetricDeletePublisher) Publish(events []*domain.MetricEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", events)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockMetricDeletePublisherMockRecorder) Publish(events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockMetricDeletePublisher)(nil).Publish), events)
+}
+
+// Sequence mocks base method.
+func (m *MockMetricDeletePublisher) Sequence() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sequence")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// Sequence indicates an expected call of Sequence.
+func (mr *MockMetricDeletePublisherMockRecorder) Sequence() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sequence", reflect.TypeOf((*MockMetricDeletePublisher)(nil).Sequence))
+}
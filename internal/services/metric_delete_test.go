@@ -0,0 +1,169 @@
+package services_test
+
+import (
+	"context"
+	e "errors"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/repositories"
+	"go-metrics/internal/services"
+	"go-metrics/internal/unitofworks"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelete_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFindRepo := services.NewMockMetricDeleteFindRepository(ctrl)
+	mockDeleteRepo := services.NewMockMetricDeleteRepository(ctrl)
+	mockHistoryRepo := services.NewMockMetricDeleteHistoryRepository(ctrl)
+	mockUnitOfWork := services.NewMockUnitOfWork(ctrl)
+	mockPublisher := services.NewMockMetricDeletePublisher(ctrl)
+	existing := &domain.Metric{MetricID: domain.MetricID{ID: "1", Type: domain.Gauge}, Value: new(float64)}
+	missing := &domain.MetricID{ID: "2", Type: domain.Gauge}
+	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, operation func(ctx context.Context) error) error {
+			return operation(ctx)
+		},
+	)
+	mockFindRepo.EXPECT().Find(gomock.Any(), []*domain.MetricID{&existing.MetricID, missing}).
+		Return(map[domain.MetricID]*domain.Metric{existing.MetricID: existing}, nil)
+	mockDeleteRepo.EXPECT().Delete(gomock.Any(), []*domain.MetricID{&existing.MetricID}).Return(nil)
+	mockHistoryRepo.EXPECT().Delete(gomock.Any(), []*domain.MetricID{&existing.MetricID}).Return(nil)
+	mockPublisher.EXPECT().Sequence().Return(uint64(3))
+	mockPublisher.EXPECT().Publish([]*domain.MetricEvent{{Kind: domain.MetricEventDeleted, Seq: 3, Metric: existing}})
+	service := services.NewMetricDeleteService(mockFindRepo, mockDeleteRepo, mockHistoryRepo, mockUnitOfWork, mockPublisher)
+	deleted, err := service.Delete(context.Background(), []*domain.MetricID{&existing.MetricID, missing})
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.Metric{existing}, deleted)
+}
+
+func TestDelete_NothingFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFindRepo := services.NewMockMetricDeleteFindRepository(ctrl)
+	mockDeleteRepo := services.NewMockMetricDeleteRepository(ctrl)
+	mockHistoryRepo := services.NewMockMetricDeleteHistoryRepository(ctrl)
+	mockUnitOfWork := services.NewMockUnitOfWork(ctrl)
+	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, operation func(ctx context.Context) error) error {
+			return operation(ctx)
+		},
+	)
+	mockFindRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(map[domain.MetricID]*domain.Metric{}, nil)
+	service := services.NewMetricDeleteService(mockFindRepo, mockDeleteRepo, mockHistoryRepo, mockUnitOfWork, nil)
+	deleted, err := service.Delete(context.Background(), []*domain.MetricID{{ID: "1", Type: domain.Gauge}})
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+}
+
+func TestDelete_EmptyRequestSkipsRepositories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := services.NewMetricDeleteService(
+		services.NewMockMetricDeleteFindRepository(ctrl),
+		services.NewMockMetricDeleteRepository(ctrl),
+		services.NewMockMetricDeleteHistoryRepository(ctrl),
+		services.NewMockUnitOfWork(ctrl),
+		nil,
+	)
+	deleted, err := service.Delete(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+}
+
+func TestDelete_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		findErr    error
+		deleteErr  error
+		historyErr error
+	}{
+		{name: "find error", findErr: e.New("find error")},
+		{name: "delete error", deleteErr: e.New("delete error")},
+		{name: "history error", historyErr: e.New("history error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockFindRepo := services.NewMockMetricDeleteFindRepository(ctrl)
+			mockDeleteRepo := services.NewMockMetricDeleteRepository(ctrl)
+			mockHistoryRepo := services.NewMockMetricDeleteHistoryRepository(ctrl)
+			mockUnitOfWork := services.NewMockUnitOfWork(ctrl)
+			id := domain.MetricID{ID: "1", Type: domain.Gauge}
+			mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, operation func(ctx context.Context) error) error {
+					return operation(ctx)
+				},
+			)
+			mockFindRepo.EXPECT().Find(gomock.Any(), gomock.Any()).
+				Return(map[domain.MetricID]*domain.Metric{id: {MetricID: id}}, tt.findErr)
+			if tt.findErr == nil {
+				mockDeleteRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(tt.deleteErr)
+			}
+			if tt.findErr == nil && tt.deleteErr == nil {
+				mockHistoryRepo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(tt.historyErr)
+			}
+			service := services.NewMetricDeleteService(mockFindRepo, mockDeleteRepo, mockHistoryRepo, mockUnitOfWork, nil)
+			deleted, err := service.Delete(context.Background(), []*domain.MetricID{&id})
+			assert.Nil(t, deleted)
+			assert.Equal(t, errors.ErrMetricIsNotDeleted, err)
+		})
+	}
+}
+
+func TestDelete_DoesNotInterleaveWithUpdates(t *testing.T) {
+	data := make(map[domain.MetricID]*domain.Metric)
+	storage := repositories.NewMetricMemoryStorage(data)
+	uow := unitofworks.NewMemoryUnitOfWork()
+	findRepo := repositories.NewMetricMemoryFindRepository(storage)
+	updateService := services.NewMetricUpdateService(
+		repositories.NewMetricMemorySaveRepository(storage),
+		findRepo,
+		repositories.NewMetricHistoryMemoryRepository(0),
+		uow,
+		nil,
+	)
+	deleteService := services.NewMetricDeleteService(
+		findRepo,
+		repositories.NewMetricMemoryDeleteRepository(storage),
+		repositories.NewMetricHistoryMemoryRepository(0),
+		uow,
+		nil,
+	)
+	id := domain.MetricID{ID: "requests", Type: domain.Counter}
+	var wg sync.WaitGroup
+	var deletedTotal int64
+	var mu sync.Mutex
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			delta := int64(1)
+			_, err := updateService.Update(context.Background(), []*domain.Metric{{MetricID: id, Delta: &delta}})
+			assert.NoError(t, err)
+		}()
+		go func() {
+			defer wg.Done()
+			deleted, err := deleteService.Delete(context.Background(), []*domain.MetricID{&id})
+			assert.NoError(t, err)
+			mu.Lock()
+			defer mu.Unlock()
+			for _, metric := range deleted {
+				deletedTotal += *metric.Delta
+			}
+		}()
+	}
+	wg.Wait()
+	var remaining int64
+	if metric, exists := data[id]; exists {
+		remaining = *metric.Delta
+	}
+	assert.Equal(t, int64(50), deletedTotal+remaining)
+}
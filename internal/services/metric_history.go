@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"time"
+)
+
+type MetricHistoryFindRepository interface {
+	Find(ctx context.Context, id *domain.MetricID, from time.Time, to time.Time) ([]*domain.MetricSample, error)
+	FindBatch(
+		ctx context.Context, ids []*domain.MetricID, from time.Time, to time.Time,
+	) (map[domain.MetricID][]*domain.MetricSample, error)
+}
+
+type MetricHistoryService struct {
+	f MetricHistoryFindRepository
+}
+
+func NewMetricHistoryService(
+	f MetricHistoryFindRepository,
+) *MetricHistoryService {
+	return &MetricHistoryService{
+		f: f,
+	}
+}
+
+func (s *MetricHistoryService) History(
+	ctx context.Context, id *domain.MetricID, from time.Time, to time.Time,
+) ([]*domain.MetricSample, error) {
+	if from.After(to) {
+		return nil, errors.ErrInvalidTimeRange
+	}
+	samples, err := s.f.Find(ctx, id, from, to)
+	if err != nil {
+		return nil, errors.ErrMetricHistoryInternal
+	}
+	return samples, nil
+}
+
+func (s *MetricHistoryService) HistoryBatch(
+	ctx context.Context, ids []*domain.MetricID, from time.Time, to time.Time,
+) (map[domain.MetricID][]*domain.MetricSample, error) {
+	if from.After(to) {
+		return nil, errors.ErrInvalidTimeRange
+	}
+	samples, err := s.f.FindBatch(ctx, ids, from, to)
+	if err != nil {
+		return nil, errors.ErrMetricHistoryInternal
+	}
+	return samples, nil
+}
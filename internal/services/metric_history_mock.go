@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/metric_history.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricHistoryFindRepository is a mock of MetricHistoryFindRepository interface.
+type MockMetricHistoryFindRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricHistoryFindRepositoryMockRecorder
+}
+
+// MockMetricHistoryFindRepositoryMockRecorder is the mock recorder for MockMetricHistoryFindRepository.
+type MockMetricHistoryFindRepositoryMockRecorder struct {
+	mock *MockMetricHistoryFindRepository
+}
+
+// NewMockMetricHistoryFindRepository creates a new mock instance.
+func NewMockMetricHistoryFindRepository(ctrl *gomock.Controller) *MockMetricHistoryFindRepository {
+	mock := &MockMetricHistoryFindRepository{ctrl: ctrl}
+	mock.recorder = &MockMetricHistoryFindRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricHistoryFindRepository) EXPECT() *MockMetricHistoryFindRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockMetricHistoryFindRepository) Find(ctx context.Context, id *domain.MetricID, from, to time.Time) ([]*domain.MetricSample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, id, from, to)
+	ret0, _ := ret[0].([]*domain.MetricSample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockMetricHistoryFindRepositoryMockRecorder) Find(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockMetricHistoryFindRepository)(nil).Find), ctx, id, from, to)
+}
+
+// FindBatch mocks base method.
+func (m *MockMetricHistoryFindRepository) FindBatch(ctx context.Context, ids []*domain.MetricID, from, to time.Time) (map[domain.MetricID][]*domain.MetricSample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBatch", ctx, ids, from, to)
+	ret0, _ := ret[0].(map[domain.MetricID][]*domain.MetricSample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBatch indicates an expected call of FindBatch.
+func (mr *MockMetricHistoryFindRepositoryMockRecorder) FindBatch(ctx, ids, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBatch", reflect.TypeOf((*MockMetricHistoryFindRepository)(nil).FindBatch), ctx, ids, from, to)
+}
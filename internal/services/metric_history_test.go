@@ -0,0 +1,53 @@
+package services_test
+
+import (
+	"context"
+	e "errors"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/services"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFindRepo := services.NewMockMetricHistoryFindRepository(ctrl)
+	id := &domain.MetricID{ID: "HeapAlloc", Type: domain.Gauge}
+	from := time.Unix(100, 0)
+	to := time.Unix(200, 0)
+	samples := []*domain.MetricSample{
+		{Metric: domain.Metric{MetricID: *id, Value: new(float64)}, Timestamp: time.Unix(150, 0)},
+	}
+	mockFindRepo.EXPECT().Find(gomock.Any(), id, from, to).Return(samples, nil).Times(1)
+	service := services.NewMetricHistoryService(mockFindRepo)
+	result, err := service.History(context.Background(), id, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, samples, result)
+}
+
+func TestHistory_InvalidRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFindRepo := services.NewMockMetricHistoryFindRepository(ctrl)
+	service := services.NewMetricHistoryService(mockFindRepo)
+	result, err := service.History(context.Background(), &domain.MetricID{ID: "1", Type: domain.Gauge}, time.Unix(200, 0), time.Unix(100, 0))
+	assert.Nil(t, result)
+	assert.Equal(t, errors.ErrInvalidTimeRange, err)
+}
+
+func TestHistory_FindError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFindRepo := services.NewMockMetricHistoryFindRepository(ctrl)
+	mockFindRepo.EXPECT().Find(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, e.New("find error")).Times(1)
+	service := services.NewMetricHistoryService(mockFindRepo)
+	result, err := service.History(context.Background(), &domain.MetricID{ID: "1", Type: domain.Gauge}, time.Unix(100, 0), time.Unix(200, 0))
+	assert.Nil(t, result)
+	assert.Equal(t, errors.ErrMetricHistoryInternal, err)
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+)
+
+type MetricQueryFindRepository interface {
+	FindPage(ctx context.Context, query *domain.MetricQuery) (*domain.MetricPage, error)
+}
+
+type MetricQueryService struct {
+	f MetricQueryFindRepository
+}
+
+func NewMetricQueryService(
+	f MetricQueryFindRepository,
+) *MetricQueryService {
+	return &MetricQueryService{
+		f: f,
+	}
+}
+
+func (s *MetricQueryService) Query(
+	ctx context.Context, query *domain.MetricQuery,
+) (*domain.MetricPage, error) {
+	page, err := s.f.FindPage(ctx, query)
+	if err != nil {
+		return nil, errors.ErrMetricQueryInternal
+	}
+	return page, nil
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/metric_query.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricQueryFindRepository is a mock of MetricQueryFindRepository interface.
+type MockMetricQueryFindRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricQueryFindRepositoryMockRecorder
+}
+
+// MockMetricQueryFindRepositoryMockRecorder is the mock recorder for MockMetricQueryFindRepository.
+type MockMetricQueryFindRepositoryMockRecorder struct {
+	mock *MockMetricQueryFindRepository
+}
+
+// NewMockMetricQueryFindRepository creates a new mock instance.
+func NewMockMetricQueryFindRepository(ctrl *gomock.Controller) *MockMetricQueryFindRepository {
+	mock := &MockMetricQueryFindRepository{ctrl: ctrl}
+	mock.recorder = &MockMetricQueryFindRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricQueryFindRepository) EXPECT() *MockMetricQueryFindRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindPage mocks base method.
+func (m *MockMetricQueryFindRepository) FindPage(ctx context.Context, query *domain.MetricQuery) (*domain.MetricPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPage", ctx, query)
+	ret0, _ := ret[0].(*domain.MetricPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPage indicates an expected call of FindPage.
+func (mr *MockMetricQueryFindRepositoryMockRecorder) FindPage(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPage", reflect.TypeOf((*MockMetricQueryFindRepository)(nil).FindPage), ctx, query)
+}
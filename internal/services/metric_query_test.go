@@ -0,0 +1,38 @@
+package services_test
+
+import (
+	"context"
+	e "errors"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/services"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFindRepo := services.NewMockMetricQueryFindRepository(ctrl)
+	query := &domain.MetricQuery{Type: domain.Gauge, Limit: 10}
+	page := &domain.MetricPage{Metrics: []*domain.Metric{{MetricID: domain.MetricID{ID: "1", Type: domain.Gauge}}}}
+	mockFindRepo.EXPECT().FindPage(gomock.Any(), query).Return(page, nil).Times(1)
+	service := services.NewMetricQueryService(mockFindRepo)
+	result, err := service.Query(context.Background(), query)
+	require.NoError(t, err)
+	assert.Equal(t, page, result)
+}
+
+func TestQuery_FindError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockFindRepo := services.NewMockMetricQueryFindRepository(ctrl)
+	mockFindRepo.EXPECT().FindPage(gomock.Any(), gomock.Any()).Return(nil, e.New("find error")).Times(1)
+	service := services.NewMetricQueryService(mockFindRepo)
+	result, err := service.Query(context.Background(), &domain.MetricQuery{})
+	assert.Nil(t, result)
+	assert.Equal(t, errors.ErrMetricQueryInternal, err)
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"time"
+)
+
+type MetricResetSaveRepository interface {
+	Save(ctx context.Context, metrics []*domain.Metric) error
+}
+
+type MetricResetFindRepository interface {
+	Find(ctx context.Context, filters []*domain.MetricID) (map[domain.MetricID]*domain.Metric, error)
+}
+
+type MetricResetHistoryRepository interface {
+	Save(ctx context.Context, metrics []*domain.Metric) error
+}
+
+type MetricResetPublisher interface {
+	Sequence() uint64
+	Publish(events []*domain.MetricEvent)
+}
+
+type MetricResetService struct {
+	s MetricResetSaveRepository
+	f MetricResetFindRepository
+	h MetricResetHistoryRepository
+	u UnitOfWork
+	p MetricResetPublisher
+}
+
+func NewMetricResetService(
+	s MetricResetSaveRepository,
+	f MetricResetFindRepository,
+	h MetricResetHistoryRepository,
+	u UnitOfWork,
+	p MetricResetPublisher,
+) *MetricResetService {
+	return &MetricResetService{
+		s: s,
+		f: f,
+		h: h,
+		u: u,
+		p: p,
+	}
+}
+
+func (s *MetricResetService) Reset(
+	ctx context.Context, id *domain.MetricID,
+) (*domain.Metric, error) {
+	if id.Type != domain.Counter {
+		return nil, errors.ErrMetricResetNotSupported
+	}
+	var resetMetric *domain.Metric
+	var seq uint64
+	err := s.u.Do(ctx, func(ctx context.Context) error {
+		existingMetrics, err := s.f.Find(ctx, []*domain.MetricID{id})
+		if err != nil {
+			return errors.ErrMetricIsNotUpdated
+		}
+		if _, exists := existingMetrics[*id]; !exists {
+			return errors.ErrMetricNotFound
+		}
+		resetMetric = &domain.Metric{MetricID: *id, Delta: new(int64), UpdatedAt: time.Now()}
+		if err := s.s.Save(ctx, []*domain.Metric{resetMetric}); err != nil {
+			return errors.ErrMetricIsNotUpdated
+		}
+		if err := s.h.Save(ctx, []*domain.Metric{resetMetric}); err != nil {
+			return errors.ErrMetricIsNotUpdated
+		}
+		if s.p != nil {
+			seq = s.p.Sequence()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if s.p != nil {
+		s.p.Publish(domain.NewMetricEvents(domain.MetricEventUpdated, seq, []*domain.Metric{resetMetric}))
+	}
+	return resetMetric, nil
+}
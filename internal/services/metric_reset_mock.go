@@ -0,0 +1,174 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/services/metric_reset.go
+
+// Package services is a generated GoMock package.
+package services
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricResetSaveRepository is a mock of MetricResetSaveRepository interface.
+type MockMetricResetSaveRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricResetSaveRepositoryMockRecorder
+}
+
+// MockMetricResetSaveRepositoryMockRecorder is the mock recorder for MockMetricResetSaveRepository.
+type MockMetricResetSaveRepositoryMockRecorder struct {
+	mock *MockMetricResetSaveRepository
+}
+
+// NewMockMetricResetSaveRepository creates a new mock instance.
+func NewMockMetricResetSaveRepository(ctrl *gomock.Controller) *MockMetricResetSaveRepository {
+	mock := &MockMetricResetSaveRepository{ctrl: ctrl}
+	mock.recorder = &MockMetricResetSaveRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricResetSaveRepository) EXPECT() *MockMetricResetSaveRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Save mocks base method.
+func (m *MockMetricResetSaveRepository) Save(ctx context.Context, metrics []*domain.Metric) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, metrics)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockMetricResetSaveRepositoryMockRecorder) Save(ctx, metrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockMetricResetSaveRepository)(nil).Save), ctx, metrics)
+}
+
+// MockMetricResetFindRepository is a mock of MetricResetFindRepository interface.
+type MockMetricResetFindRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricResetFindRepositoryMockRecorder
+}
+
+// MockMetricResetFindRepositoryMockRecorder is the mock recorder for MockMetricResetFindRepository.
+type MockMetricResetFindRepositoryMockRecorder struct {
+	mock *MockMetricResetFindRepository
+}
+
+// NewMockMetricResetFindRepository creates a new mock instance.
+func NewMockMetricResetFindRepository(ctrl *gomock.Controller) *MockMetricResetFindRepository {
+	mock := &MockMetricResetFindRepository{ctrl: ctrl}
+	mock.recorder = &MockMetricResetFindRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricResetFindRepository) EXPECT() *MockMetricResetFindRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Find mocks base method.
+func (m *MockMetricResetFindRepository) Find(ctx context.Context, filters []*domain.MetricID) (map[domain.MetricID]*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", ctx, filters)
+	ret0, _ := ret[0].(map[domain.MetricID]*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockMetricResetFindRepositoryMockRecorder) Find(ctx, filters interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockMetricResetFindRepository)(nil).Find), ctx, filters)
+}
+
+// MockMetricResetHistoryRepository is a mock of MetricResetHistoryRepository interface.
+type MockMetricResetHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricResetHistoryRepositoryMockRecorder
+}
+
+// MockMetricResetHistoryRepositoryMockRecorder is the mock recorder for MockMetricResetHistoryRepository.
+type MockMetricResetHistoryRepositoryMockRecorder struct {
+	mock *MockMetricResetHistoryRepository
+}
+
+// NewMockMetricResetHistoryRepository creates a new mock instance.
+func NewMockMetricResetHistoryRepository(ctrl *gomock.Controller) *MockMetricResetHistoryRepository {
+	mock := &MockMetricResetHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockMetricResetHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricResetHistoryRepository) EXPECT() *MockMetricResetHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Save mocks base method.
+func (m *MockMetricResetHistoryRepository) Save(ctx context.Context, metrics []*domain.Metric) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, metrics)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockMetricResetHistoryRepositoryMockRecorder) Save(ctx, metrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockMetricResetHistoryRepository)(nil).Save), ctx, metrics)
+}
+
+// MockMetricResetPublisher is a mock of MetricResetPublisher interface.
+type MockMetricResetPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricResetPublisherMockRecorder
+}
+
+// MockMetricResetPublisherMockRecorder is the mock recorder for MockMetricResetPublisher.
+type MockMetricResetPublisherMockRecorder struct {
+	mock *MockMetricResetPublisher
+}
+
+// NewMockMetricResetPublisher creates a new mock instance.
+func NewMockMetricResetPublisher(ctrl *gomock.Controller) *MockMetricResetPublisher {
+	mock := &MockMetricResetPublisher{ctrl: ctrl}
+	mock.recorder = &MockMetricResetPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricResetPublisher) EXPECT() *MockMetricResetPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockMetricResetPublisher) Publish(events []*domain.MetricEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", events)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockMetricResetPublisherMockRecorder) Publish(events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockMetricResetPublisher)(nil).Publish), events)
+}
+
+// Sequence mocks base method.
+func (m *MockMetricResetPublisher) Sequence() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sequence")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// Sequence indicates an expected call of Sequence.
+func (mr *MockMetricResetPublisherMockRecorder) Sequence() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sequence", reflect.TypeOf((*MockMetricResetPublisher)(nil).Sequence))
+}
@@ -0,0 +1,115 @@
+package services_test
+
+import (
+	"context"
+	e "errors"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/services"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReset_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSaveRepo := services.NewMockMetricResetSaveRepository(ctrl)
+	mockFindRepo := services.NewMockMetricResetFindRepository(ctrl)
+	mockHistoryRepo := services.NewMockMetricResetHistoryRepository(ctrl)
+	mockUnitOfWork := services.NewMockUnitOfWork(ctrl)
+	mockPublisher := services.NewMockMetricResetPublisher(ctrl)
+	id := &domain.MetricID{ID: "requests", Type: domain.Counter}
+	delta := int64(42)
+	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, operation func(ctx context.Context) error) error {
+			return operation(ctx)
+		},
+	)
+	mockFindRepo.EXPECT().Find(gomock.Any(), []*domain.MetricID{id}).
+		Return(map[domain.MetricID]*domain.Metric{*id: {MetricID: *id, Delta: &delta}}, nil)
+	mockSaveRepo.EXPECT().Save(gomock.Any(), gomock.Len(1)).Return(nil)
+	mockHistoryRepo.EXPECT().Save(gomock.Any(), gomock.Len(1)).Return(nil)
+	mockPublisher.EXPECT().Sequence().Return(uint64(7))
+	mockPublisher.EXPECT().Publish(gomock.Any()).Do(func(events []*domain.MetricEvent) {
+		require.Len(t, events, 1)
+		assert.Equal(t, domain.MetricEventUpdated, events[0].Kind)
+		assert.Equal(t, uint64(7), events[0].Seq)
+	})
+	service := services.NewMetricResetService(mockSaveRepo, mockFindRepo, mockHistoryRepo, mockUnitOfWork, mockPublisher)
+	metric, err := service.Reset(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, *id, metric.MetricID)
+	assert.Equal(t, int64(0), *metric.Delta)
+	assert.False(t, metric.UpdatedAt.IsZero())
+}
+
+func TestReset_NotCounter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	service := services.NewMetricResetService(
+		services.NewMockMetricResetSaveRepository(ctrl),
+		services.NewMockMetricResetFindRepository(ctrl),
+		services.NewMockMetricResetHistoryRepository(ctrl),
+		services.NewMockUnitOfWork(ctrl),
+		nil,
+	)
+	metric, err := service.Reset(context.Background(), &domain.MetricID{ID: "1", Type: domain.Gauge})
+	assert.Nil(t, metric)
+	assert.Equal(t, errors.ErrMetricResetNotSupported, err)
+}
+
+func TestReset_Errors(t *testing.T) {
+	id := domain.MetricID{ID: "requests", Type: domain.Counter}
+	tests := []struct {
+		name       string
+		found      map[domain.MetricID]*domain.Metric
+		findErr    error
+		saveErr    error
+		historyErr error
+		expected   error
+	}{
+		{name: "find error", findErr: e.New("find error"), expected: errors.ErrMetricIsNotUpdated},
+		{name: "not found", found: map[domain.MetricID]*domain.Metric{}, expected: errors.ErrMetricNotFound},
+		{
+			name:     "save error",
+			found:    map[domain.MetricID]*domain.Metric{id: {MetricID: id}},
+			saveErr:  e.New("save error"),
+			expected: errors.ErrMetricIsNotUpdated,
+		},
+		{
+			name:       "history error",
+			found:      map[domain.MetricID]*domain.Metric{id: {MetricID: id}},
+			historyErr: e.New("history error"),
+			expected:   errors.ErrMetricIsNotUpdated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockSaveRepo := services.NewMockMetricResetSaveRepository(ctrl)
+			mockFindRepo := services.NewMockMetricResetFindRepository(ctrl)
+			mockHistoryRepo := services.NewMockMetricResetHistoryRepository(ctrl)
+			mockUnitOfWork := services.NewMockUnitOfWork(ctrl)
+			mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, operation func(ctx context.Context) error) error {
+					return operation(ctx)
+				},
+			)
+			mockFindRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(tt.found, tt.findErr)
+			if tt.findErr == nil && len(tt.found) > 0 {
+				mockSaveRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(tt.saveErr)
+				if tt.saveErr == nil {
+					mockHistoryRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(tt.historyErr)
+				}
+			}
+			service := services.NewMetricResetService(mockSaveRepo, mockFindRepo, mockHistoryRepo, mockUnitOfWork, nil)
+			metric, err := service.Reset(context.Background(), &id)
+			assert.Nil(t, metric)
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+}
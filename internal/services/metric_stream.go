@@ -0,0 +1,87 @@
+package services
+
+import (
+	"go-metrics/internal/domain"
+	"sync"
+	"sync/atomic"
+)
+
+const MetricStreamSequenceWindow = 10000
+
+type MetricStreamService struct {
+	buffer int
+	subs   map[*domain.MetricSubscription]struct{}
+	last   map[domain.MetricID]uint64
+	pruned uint64
+	seq    atomic.Uint64
+	closed bool
+	mu     sync.Mutex
+}
+
+func NewMetricStreamService(buffer int) *MetricStreamService {
+	return &MetricStreamService{
+		buffer: buffer,
+		subs:   make(map[*domain.MetricSubscription]struct{}),
+		last:   make(map[domain.MetricID]uint64),
+	}
+}
+
+func (s *MetricStreamService) Subscribe(query *domain.MetricQuery) *domain.MetricSubscription {
+	sub := domain.NewMetricSubscription(query, s.buffer)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		sub.Finish()
+		return sub
+	}
+	s.subs[sub] = struct{}{}
+	return sub
+}
+
+func (s *MetricStreamService) Unsubscribe(sub *domain.MetricSubscription) {
+	s.mu.Lock()
+	delete(s.subs, sub)
+	s.mu.Unlock()
+}
+
+func (s *MetricStreamService) Sequence() uint64 {
+	return s.seq.Add(1)
+}
+
+func (s *MetricStreamService) Publish(events []*domain.MetricEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, event := range events {
+		if s.last[event.Metric.MetricID] > event.Seq {
+			continue
+		}
+		s.last[event.Metric.MetricID] = event.Seq
+		for sub := range s.subs {
+			sub.Offer(event)
+		}
+	}
+	s.prune()
+}
+
+func (s *MetricStreamService) prune() {
+	current := s.seq.Load()
+	if current-s.pruned < MetricStreamSequenceWindow {
+		return
+	}
+	s.pruned = current
+	for id, seq := range s.last {
+		if current-seq >= MetricStreamSequenceWindow {
+			delete(s.last, id)
+		}
+	}
+}
+
+func (s *MetricStreamService) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for sub := range s.subs {
+		sub.Finish()
+		delete(s.subs, sub)
+	}
+}
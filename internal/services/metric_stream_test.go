@@ -0,0 +1,99 @@
+package services_test
+
+import (
+	"go-metrics/internal/domain"
+	"go-metrics/internal/services"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func streamGauge(id string, value float64) *domain.Metric {
+	return &domain.Metric{MetricID: domain.MetricID{ID: id, Type: domain.Gauge}, Value: &value}
+}
+
+func publishUpdates(service *services.MetricStreamService, metrics ...*domain.Metric) {
+	service.Publish(domain.NewMetricEvents(domain.MetricEventUpdated, service.Sequence(), metrics))
+}
+
+func TestMetricStreamService_PublishFilters(t *testing.T) {
+	service := services.NewMetricStreamService(10)
+	all := service.Subscribe(&domain.MetricQuery{})
+	cpu := service.Subscribe(&domain.MetricQuery{Type: domain.Gauge, Regexp: regexp.MustCompile(domain.AnchorRegexp("cpu.*"))})
+	counters := service.Subscribe(&domain.MetricQuery{Type: domain.Counter})
+
+	delta := int64(1)
+	publishUpdates(service,
+		streamGauge("cpu_user", 1),
+		streamGauge("mem_free", 2),
+		&domain.Metric{MetricID: domain.MetricID{ID: "cpu_ticks", Type: domain.Counter}, Delta: &delta},
+	)
+
+	assert.Len(t, all.Events(), 3)
+	require.Len(t, cpu.Events(), 1)
+	assert.Equal(t, "cpu_user", (<-cpu.Events()).Metric.ID)
+	require.Len(t, counters.Events(), 1)
+	assert.Equal(t, "cpu_ticks", (<-counters.Events()).Metric.ID)
+
+	service.Unsubscribe(all)
+	publishUpdates(service, streamGauge("cpu_system", 3))
+	assert.Len(t, all.Events(), 3)
+	assert.Len(t, cpu.Events(), 1)
+}
+
+func TestMetricStreamService_DropsOldestWhenFull(t *testing.T) {
+	service := services.NewMetricStreamService(2)
+	sub := service.Subscribe(&domain.MetricQuery{})
+
+	publishUpdates(service, streamGauge("a", 1), streamGauge("b", 2), streamGauge("c", 3))
+	publishUpdates(service, streamGauge("d", 4))
+
+	assert.Equal(t, uint64(2), sub.TakeDropped())
+	assert.Equal(t, uint64(0), sub.TakeDropped())
+	assert.Equal(t, "c", (<-sub.Events()).Metric.ID)
+	assert.Equal(t, "d", (<-sub.Events()).Metric.ID)
+}
+
+func TestMetricStreamService_Close(t *testing.T) {
+	service := services.NewMetricStreamService(1)
+	before := service.Subscribe(&domain.MetricQuery{})
+	service.Close()
+	after := service.Subscribe(&domain.MetricQuery{})
+	publishUpdates(service, streamGauge("a", 1))
+
+	_, ok := <-before.Events()
+	assert.False(t, ok)
+	_, ok = <-after.Events()
+	assert.False(t, ok)
+	service.Unsubscribe(before)
+}
+
+func TestMetricStreamService_SkipsStaleEvents(t *testing.T) {
+	service := services.NewMetricStreamService(10)
+	sub := service.Subscribe(&domain.MetricQuery{})
+	first, second := service.Sequence(), service.Sequence()
+
+	service.Publish(domain.NewMetricEvents(domain.MetricEventDeleted, second, []*domain.Metric{streamGauge("a", 2)}))
+	service.Publish(domain.NewMetricEvents(domain.MetricEventUpdated, first, []*domain.Metric{streamGauge("a", 1), streamGauge("b", 1)}))
+
+	require.Len(t, sub.Events(), 2)
+	event := <-sub.Events()
+	assert.Equal(t, domain.MetricEventDeleted, event.Kind)
+	assert.Equal(t, "a", event.Metric.ID)
+	assert.Equal(t, "b", (<-sub.Events()).Metric.ID)
+}
+
+func TestMetricStreamService_PrunesOldSequences(t *testing.T) {
+	service := services.NewMetricStreamService(1)
+	stale := service.Sequence()
+	service.Publish(domain.NewMetricEvents(domain.MetricEventDeleted, stale, []*domain.Metric{streamGauge("old", 1)}))
+	for i := 0; i < services.MetricStreamSequenceWindow; i++ {
+		publishUpdates(service, streamGauge("live", float64(i)))
+	}
+	sub := service.Subscribe(&domain.MetricQuery{})
+	service.Publish(domain.NewMetricEvents(domain.MetricEventUpdated, stale, []*domain.Metric{streamGauge("old", 2)}))
+	require.Len(t, sub.Events(), 1)
+	assert.Equal(t, "old", (<-sub.Events()).Metric.ID)
+}
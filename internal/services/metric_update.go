@@ -2,9 +2,10 @@ package services
 
 import (
 	"context"
-	"database/sql"
 	"go-metrics/internal/domain"
 	"go-metrics/internal/errors"
+	"sort"
+	"time"
 )
 
 type MetricUpdateSaveRepository interface {
@@ -15,25 +16,40 @@ type MetricUpdateFindRepository interface {
 	Find(ctx context.Context, filters []*domain.MetricID) (map[domain.MetricID]*domain.Metric, error)
 }
 
+type MetricUpdateHistoryRepository interface {
+	Save(ctx context.Context, metrics []*domain.Metric) error
+}
+
+type MetricUpdatePublisher interface {
+	Sequence() uint64
+	Publish(events []*domain.MetricEvent)
+}
+
 type UnitOfWork interface {
-	Do(ctx context.Context, operation func(tx *sql.Tx) error) error
+	Do(ctx context.Context, operation func(ctx context.Context) error) error
 }
 
 type MetricUpdateService struct {
 	s MetricUpdateSaveRepository
 	f MetricUpdateFindRepository
+	h MetricUpdateHistoryRepository
 	u UnitOfWork
+	p MetricUpdatePublisher
 }
 
 func NewMetricUpdateService(
 	s MetricUpdateSaveRepository,
 	f MetricUpdateFindRepository,
+	h MetricUpdateHistoryRepository,
 	u UnitOfWork,
+	p MetricUpdatePublisher,
 ) *MetricUpdateService {
 	return &MetricUpdateService{
 		s: s,
 		f: f,
+		h: h,
 		u: u,
+		p: p,
 	}
 }
 
@@ -41,21 +57,49 @@ func (s *MetricUpdateService) Update(
 	ctx context.Context, metrics []*domain.Metric,
 ) ([]*domain.Metric, error) {
 	var updatedMetrics []*domain.Metric
-	err := s.u.Do(ctx, func(tx *sql.Tx) error {
-		metricMap := make(map[domain.MetricID]*domain.Metric)
+	var seq uint64
+	now := time.Now()
+	err := s.u.Do(ctx, func(ctx context.Context) error {
+		batch := make([]*domain.Metric, 0, len(metrics))
+		stamped := make(map[*domain.Metric]bool, len(metrics))
 		for _, metric := range metrics {
-			metricID := domain.MetricID{
-				ID:   metric.ID,
-				Type: metric.Type,
+			clone := metric.Clone()
+			if clone.UpdatedAt.IsZero() {
+				clone.UpdatedAt = now
+				stamped[clone] = true
 			}
-			if metric.Type == domain.Counter {
-				if existingMetric, exists := metricMap[metricID]; exists {
-					*existingMetric.Delta += *metric.Delta
-				} else {
+			batch = append(batch, clone)
+		}
+		metricMap := make(map[domain.MetricID]*domain.Metric)
+		gaugeSamples := make(map[domain.MetricID][]*domain.Metric)
+		for _, metric := range batch {
+			metricID := metric.MetricID
+			if metric.Type == domain.Gauge {
+				gaugeSamples[metricID] = append(gaugeSamples[metricID], metric)
+			}
+			existingMetric, exists := metricMap[metricID]
+			if !exists {
+				metricMap[metricID] = metric
+				continue
+			}
+			if metric.Type == domain.Gauge {
+				if !metric.UpdatedAt.Before(existingMetric.UpdatedAt) {
 					metricMap[metricID] = metric
 				}
-			} else {
-				metricMap[metricID] = metric
+				continue
+			}
+			if metric.UpdatedAt.After(existingMetric.UpdatedAt) {
+				existingMetric.UpdatedAt = metric.UpdatedAt
+			}
+			switch metric.Type {
+			case domain.Counter:
+				*existingMetric.Delta += *metric.Delta
+			case domain.Histogram:
+				if err := existingMetric.Histogram.Merge(metric.Histogram); err != nil {
+					return errors.ErrHistogramBucketsMismatch
+				}
+			case domain.Summary:
+				existingMetric.Summary.Merge(metric.Summary)
 			}
 		}
 		metricIDs := make([]*domain.MetricID, 0, len(metricMap))
@@ -70,27 +114,59 @@ func (s *MetricUpdateService) Update(
 			existingMetrics = make(map[domain.MetricID]*domain.Metric)
 		}
 		updatedMetrics = make([]*domain.Metric, 0, len(metricMap))
+		historyMetrics := make([]*domain.Metric, 0, len(batch))
 		for _, metric := range metricMap {
-			if metric.Type == domain.Counter {
-				if existingMetric, exists := existingMetrics[domain.MetricID{
-					ID:   metric.ID,
-					Type: metric.Type,
-				}]; exists {
-					*metric.Delta += *existingMetric.Delta
+			existingMetric, exists := existingMetrics[metric.MetricID]
+			switch {
+			case !exists:
+			case metric.Type == domain.Gauge && !stamped[metric] && metric.UpdatedAt.Before(existingMetric.UpdatedAt):
+				continue
+			case metric.Type == domain.Counter && existingMetric.Delta != nil:
+				*metric.Delta += *existingMetric.Delta
+			case metric.Type == domain.Histogram && existingMetric.Histogram != nil:
+				merged := existingMetric.Histogram.Clone()
+				if err := merged.Merge(metric.Histogram); err != nil {
+					return errors.ErrHistogramBucketsMismatch
+				}
+				metric.Histogram = merged
+			case metric.Type == domain.Summary && existingMetric.Summary != nil:
+				merged := existingMetric.Summary.Clone()
+				merged.Merge(metric.Summary)
+				metric.Summary = merged
+			}
+			updatedMetrics = append(updatedMetrics, metric)
+			if metric.Type != domain.Gauge {
+				historyMetrics = append(historyMetrics, metric)
+				continue
+			}
+			for _, sample := range gaugeSamples[metric.MetricID] {
+				if !exists || stamped[sample] || !sample.UpdatedAt.Before(existingMetric.UpdatedAt) {
+					historyMetrics = append(historyMetrics, sample)
 				}
-				updatedMetrics = append(updatedMetrics, metric)
-			} else {
-				updatedMetrics = append(updatedMetrics, metric)
 			}
 		}
+		if len(updatedMetrics) == 0 {
+			return nil
+		}
+		sort.SliceStable(historyMetrics, func(i, j int) bool {
+			return historyMetrics[i].UpdatedAt.Before(historyMetrics[j].UpdatedAt)
+		})
 		if err := s.s.Save(ctx, updatedMetrics); err != nil {
 			return errors.ErrMetricIsNotUpdated
 		}
-
+		if err := s.h.Save(ctx, historyMetrics); err != nil {
+			return errors.ErrMetricIsNotUpdated
+		}
+		if s.p != nil {
+			seq = s.p.Sequence()
+		}
 		return nil
 	})
 	if err != nil {
 		return nil, err
 	}
+	if s.p != nil {
+		s.p.Publish(domain.NewMetricEvents(domain.MetricEventUpdated, seq, updatedMetrics))
+	}
 	return updatedMetrics, nil
 }
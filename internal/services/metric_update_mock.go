@@ -6,7 +6,6 @@ package services
 
 import (
 	context "context"
-	sql "database/sql"
 	domain "go-metrics/internal/domain"
 	reflect "reflect"
 
@@ -88,6 +87,92 @@ func (mr *MockMetricUpdateFindRepositoryMockRecorder) Find(ctx, filters interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockMetricUpdateFindRepository)(nil).Find), ctx, filters)
 }
 
+// MockMetricUpdateHistoryRepository is a mock of MetricUpdateHistoryRepository interface.
+type MockMetricUpdateHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricUpdateHistoryRepositoryMockRecorder
+}
+
+// MockMetricUpdateHistoryRepositoryMockRecorder is the mock recorder for MockMetricUpdateHistoryRepository.
+type MockMetricUpdateHistoryRepositoryMockRecorder struct {
+	mock *MockMetricUpdateHistoryRepository
+}
+
+// NewMockMetricUpdateHistoryRepository creates a new mock instance.
+func NewMockMetricUpdateHistoryRepository(ctrl *gomock.Controller) *MockMetricUpdateHistoryRepository {
+	mock := &MockMetricUpdateHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockMetricUpdateHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricUpdateHistoryRepository) EXPECT() *MockMetricUpdateHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Save mocks base method.
+func (m *MockMetricUpdateHistoryRepository) Save(ctx context.Context, metrics []*domain.Metric) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, metrics)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockMetricUpdateHistoryRepositoryMockRecorder) Save(ctx, metrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockMetricUpdateHistoryRepository)(nil).Save), ctx, metrics)
+}
+
+// MockMetricUpdatePublisher is a mock of MetricUpdatePublisher interface.
+type MockMetricUpdatePublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricUpdatePublisherMockRecorder
+}
+
+// MockMetricUpdatePublisherMockRecorder is the mock recorder for MockMetricUpdatePublisher.
+type MockMetricUpdatePublisherMockRecorder struct {
+	mock *MockMetricUpdatePublisher
+}
+
+// NewMockMetricUpdatePublisher creates a new mock instance.
+func NewMockMetricUpdatePublisher(ctrl *gomock.Controller) *MockMetricUpdatePublisher {
+	mock := &MockMetricUpdatePublisher{ctrl: ctrl}
+	mock.recorder = &MockMetricUpdatePublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricUpdatePublisher) EXPECT() *MockMetricUpdatePublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockMetricUpdatePublisher) Publish(events []*domain.MetricEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", events)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockMetricUpdatePublisherMockRecorder) Publish(events interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockMetricUpdatePublisher)(nil).Publish), events)
+}
+
+// Sequence mocks base method.
+func (m *MockMetricUpdatePublisher) Sequence() uint64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sequence")
+	ret0, _ := ret[0].(uint64)
+	return ret0
+}
+
+// Sequence indicates an expected call of Sequence.
+func (mr *MockMetricUpdatePublisherMockRecorder) Sequence() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sequence", reflect.TypeOf((*MockMetricUpdatePublisher)(nil).Sequence))
+}
+
 // MockUnitOfWork is a mock of UnitOfWork interface.
 type MockUnitOfWork struct {
 	ctrl     *gomock.Controller
@@ -112,7 +197,7 @@ func (m *MockUnitOfWork) EXPECT() *MockUnitOfWorkMockRecorder {
 }
 
 // Do mocks base method.
-func (m *MockUnitOfWork) Do(ctx context.Context, operation func(*sql.Tx) error) error {
+func (m *MockUnitOfWork) Do(ctx context.Context, operation func(context.Context) error) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Do", ctx, operation)
 	ret0, _ := ret[0].(error)
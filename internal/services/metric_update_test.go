@@ -2,12 +2,15 @@ package services_test
 
 import (
 	"context"
-	"database/sql"
 	e "errors"
 	"go-metrics/internal/domain"
 	"go-metrics/internal/errors"
+	"go-metrics/internal/repositories"
 	"go-metrics/internal/services"
+	"go-metrics/internal/unitofworks"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -19,21 +22,24 @@ func TestUpdate_SuccessfulUpdate(t *testing.T) {
 	defer ctrl.Finish()
 	mockSaveRepo := services.NewMockMetricUpdateSaveRepository(ctrl)
 	mockFindRepo := services.NewMockMetricUpdateFindRepository(ctrl)
+	mockHistoryRepo := services.NewMockMetricUpdateHistoryRepository(ctrl)
 	mockUnitOfWork := services.NewMockUnitOfWork(ctrl)
+	updatedAt := time.Unix(100, 0)
 	metrics := []*domain.Metric{
-		{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}, Delta: new(int64)},
+		{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}, Delta: new(int64), UpdatedAt: updatedAt},
 	}
 	expectedMetrics := []*domain.Metric{
-		{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}, Delta: new(int64)},
+		{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}, Delta: new(int64), UpdatedAt: updatedAt},
 	}
-	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, operation func(tx *sql.Tx) error) error {
-		return operation(nil)
+	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, operation func(ctx context.Context) error) error {
+		return operation(ctx)
 	}).Times(1)
 	mockFindRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(map[domain.MetricID]*domain.Metric{
 		{ID: "1", Type: domain.Counter}: {MetricID: domain.MetricID{ID: "1", Type: domain.Counter}, Delta: new(int64)},
 	}, nil).Times(1)
 	mockSaveRepo.EXPECT().Save(gomock.Any(), metrics).Return(nil).Times(1)
-	service := services.NewMetricUpdateService(mockSaveRepo, mockFindRepo, mockUnitOfWork)
+	mockHistoryRepo.EXPECT().Save(gomock.Any(), metrics).Return(nil).Times(1)
+	service := services.NewMetricUpdateService(mockSaveRepo, mockFindRepo, mockHistoryRepo, mockUnitOfWork, nil)
 	result, err := service.Update(context.Background(), metrics)
 	require.NoError(t, err)
 	assert.Equal(t, expectedMetrics, result)
@@ -44,16 +50,18 @@ func TestUpdate_ExistingMetricsNil(t *testing.T) {
 	defer ctrl.Finish()
 	mockSaveRepo := services.NewMockMetricUpdateSaveRepository(ctrl)
 	mockFindRepo := services.NewMockMetricUpdateFindRepository(ctrl)
+	mockHistoryRepo := services.NewMockMetricUpdateHistoryRepository(ctrl)
 	mockUnitOfWork := services.NewMockUnitOfWork(ctrl)
 	metrics := []*domain.Metric{
-		{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}, Delta: new(int64)},
+		{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}, Delta: new(int64), UpdatedAt: time.Unix(100, 0)},
 	}
-	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, operation func(tx *sql.Tx) error) error {
-		return operation(nil)
+	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, operation func(ctx context.Context) error) error {
+		return operation(ctx)
 	}).Times(1)
 	mockFindRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
 	mockSaveRepo.EXPECT().Save(gomock.Any(), metrics).Return(nil).Times(1)
-	service := services.NewMetricUpdateService(mockSaveRepo, mockFindRepo, mockUnitOfWork)
+	mockHistoryRepo.EXPECT().Save(gomock.Any(), metrics).Return(nil).Times(1)
+	service := services.NewMetricUpdateService(mockSaveRepo, mockFindRepo, mockHistoryRepo, mockUnitOfWork, nil)
 	result, err := service.Update(context.Background(), metrics)
 	require.NoError(t, err)
 	assert.NotNil(t, result)
@@ -65,15 +73,16 @@ func TestUpdate_Failure_FindError(t *testing.T) {
 	defer ctrl.Finish()
 	mockSaveRepo := services.NewMockMetricUpdateSaveRepository(ctrl)
 	mockFindRepo := services.NewMockMetricUpdateFindRepository(ctrl)
+	mockHistoryRepo := services.NewMockMetricUpdateHistoryRepository(ctrl)
 	mockUnitOfWork := services.NewMockUnitOfWork(ctrl)
 	metrics := []*domain.Metric{
 		{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}, Delta: new(int64)},
 	}
-	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, operation func(tx *sql.Tx) error) error {
-		return operation(nil)
+	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, operation func(ctx context.Context) error) error {
+		return operation(ctx)
 	}).Times(1)
 	mockFindRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, e.New("find error")).Times(1)
-	service := services.NewMetricUpdateService(mockSaveRepo, mockFindRepo, mockUnitOfWork)
+	service := services.NewMetricUpdateService(mockSaveRepo, mockFindRepo, mockHistoryRepo, mockUnitOfWork, nil)
 	result, err := service.Update(context.Background(), metrics)
 	require.Error(t, err)
 	assert.Nil(t, result)
@@ -85,20 +94,342 @@ func TestUpdate_Failure_SaveError(t *testing.T) {
 	defer ctrl.Finish()
 	mockSaveRepo := services.NewMockMetricUpdateSaveRepository(ctrl)
 	mockFindRepo := services.NewMockMetricUpdateFindRepository(ctrl)
+	mockHistoryRepo := services.NewMockMetricUpdateHistoryRepository(ctrl)
 	mockUnitOfWork := services.NewMockUnitOfWork(ctrl)
 	metrics := []*domain.Metric{
-		{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}, Delta: new(int64)},
+		{MetricID: domain.MetricID{ID: "1", Type: domain.Counter}, Delta: new(int64), UpdatedAt: time.Unix(100, 0)},
 	}
-	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, operation func(tx *sql.Tx) error) error {
-		return operation(nil)
+	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, operation func(ctx context.Context) error) error {
+		return operation(ctx)
 	}).Times(1)
 	mockFindRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(map[domain.MetricID]*domain.Metric{
 		{ID: "1", Type: domain.Counter}: {MetricID: domain.MetricID{ID: "1", Type: domain.Counter}, Delta: new(int64)},
 	}, nil).Times(1)
 	mockSaveRepo.EXPECT().Save(gomock.Any(), metrics).Return(e.New("save error")).Times(1)
-	service := services.NewMetricUpdateService(mockSaveRepo, mockFindRepo, mockUnitOfWork)
+	service := services.NewMetricUpdateService(mockSaveRepo, mockFindRepo, mockHistoryRepo, mockUnitOfWork, nil)
+	result, err := service.Update(context.Background(), metrics)
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.EqualError(t, err, errors.ErrMetricIsNotUpdated.Error())
+}
+
+func TestUpdate_Failure_HistoryError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSaveRepo := services.NewMockMetricUpdateSaveRepository(ctrl)
+	mockFindRepo := services.NewMockMetricUpdateFindRepository(ctrl)
+	mockHistoryRepo := services.NewMockMetricUpdateHistoryRepository(ctrl)
+	mockUnitOfWork := services.NewMockUnitOfWork(ctrl)
+	metrics := []*domain.Metric{
+		{MetricID: domain.MetricID{ID: "1", Type: domain.Gauge}, Value: new(float64), UpdatedAt: time.Unix(100, 0)},
+	}
+	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, operation func(ctx context.Context) error) error {
+		return operation(ctx)
+	}).Times(1)
+	mockFindRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(nil, nil).Times(1)
+	mockSaveRepo.EXPECT().Save(gomock.Any(), metrics).Return(nil).Times(1)
+	mockHistoryRepo.EXPECT().Save(gomock.Any(), metrics).Return(e.New("history error")).Times(1)
+	service := services.NewMetricUpdateService(mockSaveRepo, mockFindRepo, mockHistoryRepo, mockUnitOfWork, nil)
 	result, err := service.Update(context.Background(), metrics)
 	require.Error(t, err)
 	assert.Nil(t, result)
 	assert.EqualError(t, err, errors.ErrMetricIsNotUpdated.Error())
 }
+
+func TestUpdate_MergesHistograms(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSaveRepo := services.NewMockMetricUpdateSaveRepository(ctrl)
+	mockFindRepo := services.NewMockMetricUpdateFindRepository(ctrl)
+	mockHistoryRepo := services.NewMockMetricUpdateHistoryRepository(ctrl)
+	mockUnitOfWork := services.NewMockUnitOfWork(ctrl)
+	id := domain.MetricID{ID: "GCPauseNs", Type: domain.Histogram}
+	stored := domain.NewHistogram([]float64{1, 10})
+	stored.Observe(0.5)
+	first := domain.NewHistogram([]float64{1, 10})
+	first.Observe(5)
+	second := domain.NewHistogram([]float64{1, 10})
+	second.Observe(50)
+	metrics := []*domain.Metric{
+		{MetricID: id, Histogram: first},
+		{MetricID: id, Histogram: second},
+	}
+	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, operation func(ctx context.Context) error) error {
+		return operation(ctx)
+	}).Times(1)
+	mockFindRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(map[domain.MetricID]*domain.Metric{
+		id: {MetricID: id, Histogram: stored},
+	}, nil).Times(1)
+	mockSaveRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	mockHistoryRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	service := services.NewMetricUpdateService(mockSaveRepo, mockFindRepo, mockHistoryRepo, mockUnitOfWork, nil)
+	result, err := service.Update(context.Background(), metrics)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, []int64{1, 1, 1}, result[0].Histogram.Counts)
+	assert.Equal(t, int64(3), result[0].Histogram.Count)
+	assert.Equal(t, []int64{1, 0, 0}, stored.Counts)
+}
+
+func TestUpdate_Failure_HistogramBucketsMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSaveRepo := services.NewMockMetricUpdateSaveRepository(ctrl)
+	mockFindRepo := services.NewMockMetricUpdateFindRepository(ctrl)
+	mockHistoryRepo := services.NewMockMetricUpdateHistoryRepository(ctrl)
+	mockUnitOfWork := services.NewMockUnitOfWork(ctrl)
+	id := domain.MetricID{ID: "GCPauseNs", Type: domain.Histogram}
+	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, operation func(ctx context.Context) error) error {
+		return operation(ctx)
+	}).Times(1)
+	mockFindRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(map[domain.MetricID]*domain.Metric{
+		id: {MetricID: id, Histogram: domain.NewHistogram([]float64{1})},
+	}, nil).Times(1)
+	service := services.NewMetricUpdateService(mockSaveRepo, mockFindRepo, mockHistoryRepo, mockUnitOfWork, nil)
+	result, err := service.Update(context.Background(), []*domain.Metric{
+		{MetricID: id, Histogram: domain.NewHistogram([]float64{1, 10})},
+	})
+	assert.Nil(t, result)
+	assert.Equal(t, errors.ErrHistogramBucketsMismatch, err)
+}
+
+func TestUpdate_MergesSummaries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSaveRepo := services.NewMockMetricUpdateSaveRepository(ctrl)
+	mockFindRepo := services.NewMockMetricUpdateFindRepository(ctrl)
+	mockHistoryRepo := services.NewMockMetricUpdateHistoryRepository(ctrl)
+	mockUnitOfWork := services.NewMockUnitOfWork(ctrl)
+	id := domain.MetricID{ID: "Latency", Type: domain.Summary}
+	stored := domain.NewSummary(nil)
+	stored.Observe(1)
+	mockUnitOfWork.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, operation func(ctx context.Context) error) error {
+		return operation(ctx)
+	}).Times(1)
+	mockFindRepo.EXPECT().Find(gomock.Any(), gomock.Any()).Return(map[domain.MetricID]*domain.Metric{
+		id: {MetricID: id, Summary: stored},
+	}, nil).Times(1)
+	mockSaveRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	mockHistoryRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+	service := services.NewMetricUpdateService(mockSaveRepo, mockFindRepo, mockHistoryRepo, mockUnitOfWork, nil)
+	result, err := service.Update(context.Background(), []*domain.Metric{
+		{MetricID: id, Summary: &domain.SummaryValue{Observations: []float64{2, 3}}},
+	})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, int64(3), result[0].Summary.Count)
+	assert.Equal(t, 2.0, result[0].Summary.Quantile(0.5))
+	assert.Equal(t, int64(1), stored.Count)
+}
+
+func TestUpdate_ConcurrentCounterUpdates(t *testing.T) {
+	data := make(map[domain.MetricID]*domain.Metric)
+	storage := repositories.NewMetricMemoryStorage(data)
+	service := services.NewMetricUpdateService(
+		repositories.NewMetricMemorySaveRepository(storage),
+		repositories.NewMetricMemoryFindRepository(storage),
+		repositories.NewMetricHistoryMemoryRepository(0),
+		unitofworks.NewMemoryUnitOfWork(),
+		nil,
+	)
+	id := domain.MetricID{ID: "PollCount", Type: domain.Counter}
+
+	const updates = 100
+	var wg sync.WaitGroup
+	for i := 0; i < updates; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			delta := int64(1)
+			_, err := service.Update(context.Background(), []*domain.Metric{{MetricID: id, Delta: &delta}})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.Contains(t, data, id)
+	assert.Equal(t, int64(updates), *data[id].Delta)
+}
+
+func TestUpdate_PublishesCommittedMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	data := make(map[domain.MetricID]*domain.Metric)
+	storage := repositories.NewMetricMemoryStorage(data)
+	mockPublisher := services.NewMockMetricUpdatePublisher(ctrl)
+	mockHistoryRepo := services.NewMockMetricUpdateHistoryRepository(ctrl)
+	service := services.NewMetricUpdateService(
+		repositories.NewMetricMemorySaveRepository(storage),
+		repositories.NewMetricMemoryFindRepository(storage),
+		mockHistoryRepo,
+		unitofworks.NewMemoryUnitOfWork(),
+		mockPublisher,
+	)
+	delta := int64(2)
+	metrics := []*domain.Metric{{MetricID: domain.MetricID{ID: "PollCount", Type: domain.Counter}, Delta: &delta}}
+
+	mockHistoryRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(e.New("history error"))
+	_, err := service.Update(context.Background(), metrics)
+	require.ErrorIs(t, err, errors.ErrMetricIsNotUpdated)
+
+	mockHistoryRepo.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	mockPublisher.EXPECT().Sequence().Return(uint64(1))
+	mockPublisher.EXPECT().Publish(gomock.Any()).Do(func(published []*domain.MetricEvent) {
+		require.Len(t, published, 1)
+		assert.Equal(t, uint64(1), published[0].Seq)
+		assert.Equal(t, int64(4), *published[0].Metric.Delta)
+	})
+	_, err = service.Update(context.Background(), metrics)
+	require.NoError(t, err)
+}
+
+func TestUpdate_StampsUpdatedAt(t *testing.T) {
+	storage := repositories.NewMetricMemoryStorage(make(map[domain.MetricID]*domain.Metric))
+	service := services.NewMetricUpdateService(
+		repositories.NewMetricMemorySaveRepository(storage),
+		repositories.NewMetricMemoryFindRepository(storage),
+		repositories.NewMetricHistoryMemoryRepository(0),
+		unitofworks.NewMemoryUnitOfWork(),
+		nil,
+	)
+	before := time.Now()
+	delta := int64(1)
+	reported := time.Unix(100, 0)
+	value := 2.0
+	result, err := service.Update(context.Background(), []*domain.Metric{
+		{MetricID: domain.MetricID{ID: "PollCount", Type: domain.Counter}, Delta: &delta},
+		{MetricID: domain.MetricID{ID: "Temp", Type: domain.Gauge}, Value: &value, UpdatedAt: reported},
+	})
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	for _, metric := range result {
+		if metric.Type == domain.Gauge {
+			assert.Equal(t, reported, metric.UpdatedAt)
+			continue
+		}
+		assert.False(t, metric.UpdatedAt.Before(before))
+	}
+}
+
+func TestUpdate_KeepsLatestGaugeSample(t *testing.T) {
+	storage := repositories.NewMetricMemoryStorage(make(map[domain.MetricID]*domain.Metric))
+	service := services.NewMetricUpdateService(
+		repositories.NewMetricMemorySaveRepository(storage),
+		repositories.NewMetricMemoryFindRepository(storage),
+		repositories.NewMetricHistoryMemoryRepository(0),
+		unitofworks.NewMemoryUnitOfWork(),
+		nil,
+	)
+	id := domain.MetricID{ID: "Temp", Type: domain.Gauge}
+	newer, older := 2.0, 1.0
+	result, err := service.Update(context.Background(), []*domain.Metric{
+		{MetricID: id, Value: &newer, UpdatedAt: time.Unix(200, 0)},
+		{MetricID: id, Value: &older, UpdatedAt: time.Unix(100, 0)},
+	})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, 2.0, *result[0].Value)
+	assert.Equal(t, time.Unix(200, 0), result[0].UpdatedAt)
+}
+
+func TestUpdate_RecordsEveryFreshGaugeSample(t *testing.T) {
+	storage := repositories.NewMetricMemoryStorage(make(map[domain.MetricID]*domain.Metric))
+	history := repositories.NewMetricHistoryMemoryRepository(0)
+	service := services.NewMetricUpdateService(
+		repositories.NewMetricMemorySaveRepository(storage),
+		repositories.NewMetricMemoryFindRepository(storage),
+		history,
+		unitofworks.NewMemoryUnitOfWork(),
+		nil,
+	)
+	id := domain.MetricID{ID: "Temp", Type: domain.Gauge}
+	gauge := func(value float64, seconds int64) *domain.Metric {
+		return &domain.Metric{MetricID: id, Value: &value, UpdatedAt: time.Unix(seconds, 0)}
+	}
+	_, err := service.Update(context.Background(), []*domain.Metric{gauge(3, 300), gauge(1, 100), gauge(2, 200)})
+	require.NoError(t, err)
+	result, err := service.Update(context.Background(), []*domain.Metric{gauge(0, 250), gauge(4, 400)})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, 4.0, *result[0].Value)
+
+	result, err = service.Update(context.Background(), []*domain.Metric{gauge(5, 350)})
+	require.NoError(t, err)
+	assert.Empty(t, result)
+
+	samples, err := history.Find(context.Background(), &id, time.Time{}, time.Unix(1000, 0))
+	require.NoError(t, err)
+	values := make([]float64, 0, len(samples))
+	for _, sample := range samples {
+		values = append(values, *sample.Value)
+	}
+	assert.Equal(t, []float64{1, 2, 3, 4}, values)
+}
+
+func TestUpdate_ServerStampedGaugeAlwaysApplies(t *testing.T) {
+	storage := repositories.NewMetricMemoryStorage(make(map[domain.MetricID]*domain.Metric))
+	service := services.NewMetricUpdateService(
+		repositories.NewMetricMemorySaveRepository(storage),
+		repositories.NewMetricMemoryFindRepository(storage),
+		repositories.NewMetricHistoryMemoryRepository(0),
+		unitofworks.NewMemoryUnitOfWork(),
+		nil,
+	)
+	id := domain.MetricID{ID: "Temp", Type: domain.Gauge}
+	future, current := 1.0, 2.0
+	_, err := service.Update(context.Background(), []*domain.Metric{
+		{MetricID: id, Value: &future, UpdatedAt: time.Now().Add(time.Hour)},
+	})
+	require.NoError(t, err)
+	result, err := service.Update(context.Background(), []*domain.Metric{{MetricID: id, Value: &current}})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, 2.0, *result[0].Value)
+}
+
+type retryingUnitOfWork struct{}
+
+func (retryingUnitOfWork) Do(ctx context.Context, operation func(ctx context.Context) error) error {
+	if err := operation(ctx); err == nil {
+		return nil
+	}
+	return operation(ctx)
+}
+
+type flakyFindRepository struct {
+	services.MetricUpdateFindRepository
+	failed bool
+}
+
+func (repo *flakyFindRepository) Find(
+	ctx context.Context, filters []*domain.MetricID,
+) (map[domain.MetricID]*domain.Metric, error) {
+	if !repo.failed {
+		repo.failed = true
+		return nil, e.New("serialization failure")
+	}
+	return repo.MetricUpdateFindRepository.Find(ctx, filters)
+}
+
+func TestUpdate_RetriedOperationDoesNotDoubleCount(t *testing.T) {
+	data := make(map[domain.MetricID]*domain.Metric)
+	storage := repositories.NewMetricMemoryStorage(data)
+	service := services.NewMetricUpdateService(
+		repositories.NewMetricMemorySaveRepository(storage),
+		&flakyFindRepository{MetricUpdateFindRepository: repositories.NewMetricMemoryFindRepository(storage)},
+		repositories.NewMetricHistoryMemoryRepository(0),
+		retryingUnitOfWork{},
+		nil,
+	)
+	id := domain.MetricID{ID: "PollCount", Type: domain.Counter}
+	stored := int64(10)
+	data[id] = &domain.Metric{MetricID: id, Delta: &stored}
+	first, second := int64(1), int64(2)
+	metrics := []*domain.Metric{{MetricID: id, Delta: &first}, {MetricID: id, Delta: &second}}
+	result, err := service.Update(context.Background(), metrics)
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, int64(13), *result[0].Delta)
+	assert.Equal(t, int64(1), *metrics[0].Delta)
+	assert.Equal(t, int64(2), *metrics[1].Delta)
+}
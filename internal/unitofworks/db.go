@@ -18,7 +18,7 @@ func NewDBUnitOfWork(db *sql.DB) *DBUnitOfWork {
 	return &DBUnitOfWork{db: db}
 }
 
-func (uow *DBUnitOfWork) Do(ctx context.Context, operation func(tx *sql.Tx) error) error {
+func (uow *DBUnitOfWork) Do(ctx context.Context, operation func(ctx context.Context) error) error {
 	var err error
 	var tx *sql.Tx
 	retryIntervals := []time.Duration{1 * time.Second, 3 * time.Second, 5 * time.Second}
@@ -33,7 +33,7 @@ func (uow *DBUnitOfWork) Do(ctx context.Context, operation func(tx *sql.Tx) erro
 			}
 			return fmt.Errorf("failed to begin transaction: %w", err)
 		}
-		err = operation(tx)
+		err = operation(ContextWithTx(ctx, tx))
 		if err != nil {
 			if errors.IsRetriableError(err) && attempts < len(retryIntervals) {
 				tx.Rollback()
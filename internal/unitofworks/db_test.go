@@ -57,8 +57,8 @@ func TestDBUnitOfWork(t *testing.T) {
 	uow := NewDBUnitOfWork(db)
 	_, err = db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS users (id SERIAL PRIMARY KEY, name TEXT)`)
 	require.NoError(t, err)
-	err = uow.Do(ctx, func(tx *sql.Tx) error {
-		_, err := tx.ExecContext(ctx, `INSERT INTO users (name) VALUES ($1)`, "Alice")
+	err = uow.Do(ctx, func(ctx context.Context) error {
+		_, err := TxFromContext(ctx).ExecContext(ctx, `INSERT INTO users (name) VALUES ($1)`, "Alice")
 		return err
 	})
 	require.NoError(t, err)
@@ -66,8 +66,8 @@ func TestDBUnitOfWork(t *testing.T) {
 	err = db.QueryRowContext(ctx, `SELECT name FROM users WHERE name = $1`, "Alice").Scan(&name)
 	require.NoError(t, err)
 	assert.Equal(t, "Alice", name)
-	uow.Do(ctx, func(tx *sql.Tx) error {
-		_, err := tx.ExecContext(ctx, `INSERT INTO users (name) VALUES ($1)`, "Bob")
+	uow.Do(ctx, func(ctx context.Context) error {
+		_, err := TxFromContext(ctx).ExecContext(ctx, `INSERT INTO users (name) VALUES ($1)`, "Bob")
 		if err != nil {
 			return err
 		}
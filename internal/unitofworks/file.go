@@ -2,15 +2,19 @@ package unitofworks
 
 import (
 	"context"
-	"database/sql"
+	"sync"
 )
 
-type FileUnitOfWork struct{}
+type FileUnitOfWork struct {
+	mu sync.Mutex
+}
 
 func NewFileUnitOfWork() *FileUnitOfWork {
 	return &FileUnitOfWork{}
 }
 
-func (f *FileUnitOfWork) Do(ctx context.Context, operation func(tx *sql.Tx) error) error {
-	return operation(nil)
+func (f *FileUnitOfWork) Do(ctx context.Context, operation func(ctx context.Context) error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return operation(ctx)
 }
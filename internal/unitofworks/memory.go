@@ -2,15 +2,19 @@ package unitofworks
 
 import (
 	"context"
-	"database/sql"
+	"sync"
 )
 
-type MemoryUnitOfWork struct{}
+type MemoryUnitOfWork struct {
+	mu sync.Mutex
+}
 
 func NewMemoryUnitOfWork() *MemoryUnitOfWork {
 	return &MemoryUnitOfWork{}
 }
 
-func (m *MemoryUnitOfWork) Do(ctx context.Context, operation func(tx *sql.Tx) error) error {
-	return operation(nil)
+func (m *MemoryUnitOfWork) Do(ctx context.Context, operation func(ctx context.Context) error) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return operation(ctx)
 }
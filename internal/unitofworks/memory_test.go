@@ -2,7 +2,6 @@ package unitofworks
 
 import (
 	"context"
-	"database/sql"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,8 +9,8 @@ import (
 
 func TestMemoryUnitOfWork_Do(t *testing.T) {
 	uow := NewMemoryUnitOfWork()
-	operation := func(tx *sql.Tx) error {
-		assert.Nil(t, tx)
+	operation := func(ctx context.Context) error {
+		assert.Nil(t, TxFromContext(ctx))
 		return nil
 	}
 	err := uow.Do(context.Background(), operation)
@@ -0,0 +1,17 @@
+package unitofworks
+
+import (
+	"context"
+	"database/sql"
+)
+
+type txContextKey struct{}
+
+func ContextWithTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+func TxFromContext(ctx context.Context) *sql.Tx {
+	tx, _ := ctx.Value(txContextKey{}).(*sql.Tx)
+	return tx
+}
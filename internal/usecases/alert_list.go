@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"time"
+)
+
+type AlertListService interface {
+	List(ctx context.Context) ([]*domain.Alert, error)
+}
+
+type AlertListUsecase struct {
+	svc AlertListService
+}
+
+func NewAlertListUsecase(svc AlertListService) *AlertListUsecase {
+	return &AlertListUsecase{svc: svc}
+}
+
+func (uc *AlertListUsecase) Execute(ctx context.Context) (*AlertListResponse, error) {
+	alerts, err := uc.svc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewAlertListResponse(alerts), nil
+}
+
+type AlertResponse struct {
+	Name       string            `json:"name"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Expr       string            `json:"expr"`
+	State      string            `json:"state"`
+	Value      *float64          `json:"value,omitempty"`
+	ActiveAt   *time.Time        `json:"active_at,omitempty"`
+	FiredAt    *time.Time        `json:"fired_at,omitempty"`
+	ResolvedAt *time.Time        `json:"resolved_at,omitempty"`
+}
+
+type AlertListResponse struct {
+	Alerts []*AlertResponse `json:"alerts"`
+}
+
+func NewAlertListResponse(alerts []*domain.Alert) *AlertListResponse {
+	resp := &AlertListResponse{
+		Alerts: make([]*AlertResponse, 0, len(alerts)),
+	}
+	for _, alert := range alerts {
+		resp.Alerts = append(resp.Alerts, &AlertResponse{
+			Name:       alert.Name,
+			Labels:     alert.Labels.Map(),
+			Expr:       alert.Expr,
+			State:      string(alert.State),
+			Value:      alert.Value,
+			ActiveAt:   alert.ActiveAt,
+			FiredAt:    alert.FiredAt,
+			ResolvedAt: alert.ResolvedAt,
+		})
+	}
+	return resp
+}
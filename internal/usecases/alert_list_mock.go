@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecases/alert_list.go
+
+// Package usecases is a generated GoMock package.
+package usecases
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAlertListService is a mock of AlertListService interface.
+type MockAlertListService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertListServiceMockRecorder
+}
+
+// MockAlertListServiceMockRecorder is the mock recorder for MockAlertListService.
+type MockAlertListServiceMockRecorder struct {
+	mock *MockAlertListService
+}
+
+// NewMockAlertListService creates a new mock instance.
+func NewMockAlertListService(ctrl *gomock.Controller) *MockAlertListService {
+	mock := &MockAlertListService{ctrl: ctrl}
+	mock.recorder = &MockAlertListServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertListService) EXPECT() *MockAlertListServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockAlertListService) List(ctx context.Context) ([]*domain.Alert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*domain.Alert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockAlertListServiceMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockAlertListService)(nil).List), ctx)
+}
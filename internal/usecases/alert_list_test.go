@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlertListUsecase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := NewMockAlertListService(ctrl)
+	usecase := NewAlertListUsecase(mockService)
+
+	t.Run("success", func(t *testing.T) {
+		activeAt := time.Unix(100, 0)
+		mockService.EXPECT().List(gomock.Any()).Return([]*domain.Alert{
+			{
+				AlertID:  domain.AlertID{Name: "heap", Labels: domain.NewLabels(map[string]string{"host": "a"})},
+				Expr:     "HeapAlloc > 5e8 for 2m",
+				State:    domain.AlertPending,
+				Value:    ptrFloat64(6e8),
+				ActiveAt: &activeAt,
+			},
+		}, nil)
+		resp, err := usecase.Execute(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, &AlertListResponse{
+			Alerts: []*AlertResponse{{
+				Name:     "heap",
+				Labels:   map[string]string{"host": "a"},
+				Expr:     "HeapAlloc > 5e8 for 2m",
+				State:    "pending",
+				Value:    ptrFloat64(6e8),
+				ActiveAt: &activeAt,
+			}},
+		}, resp)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		mockService.EXPECT().List(gomock.Any()).Return(nil, nil)
+		resp, err := usecase.Execute(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, &AlertListResponse{Alerts: []*AlertResponse{}}, resp)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mockService.EXPECT().List(gomock.Any()).Return(nil, errors.ErrAlertListInternal)
+		resp, err := usecase.Execute(context.Background())
+		assert.Nil(t, resp)
+		assert.Equal(t, errors.ErrAlertListInternal, err)
+	})
+}
@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/validation"
+)
+
+type MetricDeletePathService interface {
+	Delete(ctx context.Context, ids []*domain.MetricID) ([]*domain.Metric, error)
+}
+
+type MetricDeletePathUsecase struct {
+	svc MetricDeletePathService
+}
+
+func NewMetricDeletePathUsecase(svc MetricDeletePathService) *MetricDeletePathUsecase {
+	return &MetricDeletePathUsecase{svc: svc}
+}
+
+func (uc *MetricDeletePathUsecase) Execute(
+	ctx context.Context,
+	req *MetricDeletePathRequest,
+) (*MetricDeletePathResponse, error) {
+	err := ValidateMetricDeletePathRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	metricID, err := ConvertMetricDeletePathRequestToDomain(req)
+	if err != nil {
+		return nil, err
+	}
+	deleted, err := uc.svc.Delete(ctx, []*domain.MetricID{metricID})
+	if err != nil {
+		return nil, err
+	}
+	if len(deleted) == 0 {
+		return nil, errors.ErrMetricNotFound
+	}
+	return NewMetricDeletePathResponse(), nil
+}
+
+type MetricDeletePathRequest struct {
+	Type   string
+	Name   string
+	Labels []string
+}
+
+func ValidateMetricDeletePathRequest(req *MetricDeletePathRequest) error {
+	err := validation.ValidateMetricID(req.Name)
+	if err != nil {
+		return err
+	}
+	err = validation.ValidateMetricType(req.Type)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func ConvertMetricDeletePathRequestToDomain(req *MetricDeletePathRequest) (*domain.MetricID, error) {
+	labels, err := ConvertMetricHistoryLabelsToDomain(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.MetricID{
+		ID:     req.Name,
+		Type:   domain.MetricType(req.Type),
+		Labels: labels,
+	}, nil
+}
+
+type MetricDeletePathResponse string
+
+func NewMetricDeletePathResponse() *MetricDeletePathResponse {
+	s := MetricDeletePathResponse("Metric deleted successfully")
+	return &s
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecases/metric_delete_path.go
+
+// Package usecases is a generated GoMock package.
+package usecases
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricDeletePathService is a mock of MetricDeletePathService interface.
+type MockMetricDeletePathService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricDeletePathServiceMockRecorder
+}
+
+// MockMetricDeletePathServiceMockRecorder is the mock recorder for MockMetricDeletePathService.
+type MockMetricDeletePathServiceMockRecorder struct {
+	mock *MockMetricDeletePathService
+}
+
+// NewMockMetricDeletePathService creates a new mock instance.
+func NewMockMetricDeletePathService(ctrl *gomock.Controller) *MockMetricDeletePathService {
+	mock := &MockMetricDeletePathService{ctrl: ctrl}
+	mock.recorder = &MockMetricDeletePathServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricDeletePathService) EXPECT() *MockMetricDeletePathServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockMetricDeletePathService) Delete(ctx context.Context, ids []*domain.MetricID) ([]*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, ids)
+	ret0, _ := ret[0].([]*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMetricDeletePathServiceMockRecorder) Delete(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMetricDeletePathService)(nil).Delete), ctx, ids)
+}
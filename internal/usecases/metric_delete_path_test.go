@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricDeletePathUsecase_Execute(t *testing.T) {
+	id := &domain.MetricID{ID: "test_gauge", Type: domain.Gauge}
+	tests := []struct {
+		name      string
+		req       *MetricDeletePathRequest
+		mock      func(mockService *MockMetricDeletePathService)
+		expectErr error
+	}{
+		{
+			name: "success",
+			req:  &MetricDeletePathRequest{Type: "gauge", Name: "test_gauge"},
+			mock: func(mockService *MockMetricDeletePathService) {
+				mockService.EXPECT().Delete(gomock.Any(), []*domain.MetricID{id}).
+					Return([]*domain.Metric{{MetricID: *id}}, nil)
+			},
+		},
+		{
+			name: "labelled",
+			req:  &MetricDeletePathRequest{Type: "gauge", Name: "test_gauge", Labels: []string{"host=h1"}},
+			mock: func(mockService *MockMetricDeletePathService) {
+				labelled := &domain.MetricID{
+					ID: "test_gauge", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "h1"}),
+				}
+				mockService.EXPECT().Delete(gomock.Any(), []*domain.MetricID{labelled}).
+					Return([]*domain.Metric{{MetricID: *labelled}}, nil)
+			},
+		},
+		{
+			name:      "invalid labels",
+			req:       &MetricDeletePathRequest{Type: "gauge", Name: "test_gauge", Labels: []string{"host"}},
+			mock:      func(mockService *MockMetricDeletePathService) {},
+			expectErr: errors.ErrInvalidMetricLabels,
+		},
+		{
+			name: "not found",
+			req:  &MetricDeletePathRequest{Type: "gauge", Name: "test_gauge"},
+			mock: func(mockService *MockMetricDeletePathService) {
+				mockService.EXPECT().Delete(gomock.Any(), []*domain.MetricID{id}).Return([]*domain.Metric{}, nil)
+			},
+			expectErr: errors.ErrMetricNotFound,
+		},
+		{
+			name: "service error",
+			req:  &MetricDeletePathRequest{Type: "gauge", Name: "test_gauge"},
+			mock: func(mockService *MockMetricDeletePathService) {
+				mockService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, errors.ErrMetricIsNotDeleted)
+			},
+			expectErr: errors.ErrMetricIsNotDeleted,
+		},
+		{
+			name:      "invalid type",
+			req:       &MetricDeletePathRequest{Type: "unknown", Name: "test_gauge"},
+			mock:      func(mockService *MockMetricDeletePathService) {},
+			expectErr: errors.ErrInvalidMetricType,
+		},
+		{
+			name:      "invalid name",
+			req:       &MetricDeletePathRequest{Type: "gauge", Name: ""},
+			mock:      func(mockService *MockMetricDeletePathService) {},
+			expectErr: errors.ErrInvalidMetricID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockService := NewMockMetricDeletePathService(ctrl)
+			tt.mock(mockService)
+			uc := NewMetricDeletePathUsecase(mockService)
+			resp, err := uc.Execute(context.Background(), tt.req)
+			if tt.expectErr != nil {
+				assert.Nil(t, resp)
+				assert.Equal(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, NewMetricDeletePathResponse(), resp)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/validation"
+)
+
+type MetricDeletesBodyService interface {
+	Delete(ctx context.Context, ids []*domain.MetricID) ([]*domain.Metric, error)
+}
+
+type MetricDeletesBodyUsecase struct {
+	svc MetricDeletesBodyService
+}
+
+func NewMetricDeletesBodyUsecase(svc MetricDeletesBodyService) *MetricDeletesBodyUsecase {
+	return &MetricDeletesBodyUsecase{svc: svc}
+}
+
+func (uc *MetricDeletesBodyUsecase) Execute(
+	ctx context.Context,
+	req []*MetricDeleteBodyRequest,
+) ([]*MetricGetByIDBodyResponse, error) {
+	for _, r := range req {
+		err := ValidateMetricDeleteBodyRequest(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+	metricIDs := make([]*domain.MetricID, 0, len(req))
+	for _, r := range req {
+		metricIDs = append(metricIDs, ConvertMetricDeleteBodyRequestToDomain(r))
+	}
+	metrics, err := uc.svc.Delete(ctx, metricIDs)
+	if err != nil {
+		return nil, err
+	}
+	metricsResponse := make([]*MetricGetByIDBodyResponse, 0, len(metrics))
+	for _, m := range metrics {
+		metricsResponse = append(metricsResponse, NewMetricGetByIDResponse(m))
+	}
+	return metricsResponse, nil
+}
+
+type MetricDeleteBodyRequest struct {
+	ID     string            `json:"id"`
+	Type   string            `json:"type"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+func ValidateMetricDeleteBodyRequest(req *MetricDeleteBodyRequest) error {
+	err := validation.ValidateMetricID(req.ID)
+	if err != nil {
+		return err
+	}
+	err = validation.ValidateMetricType(req.Type)
+	if err != nil {
+		return err
+	}
+	err = validation.ValidateMetricLabels(req.Labels)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func ConvertMetricDeleteBodyRequestToDomain(req *MetricDeleteBodyRequest) *domain.MetricID {
+	return &domain.MetricID{
+		ID:     req.ID,
+		Type:   domain.MetricType(req.Type),
+		Labels: domain.NewLabels(req.Labels),
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecases/metric_deletes_body.go
+
+// Package usecases is a generated GoMock package.
+package usecases
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricDeletesBodyService is a mock of MetricDeletesBodyService interface.
+type MockMetricDeletesBodyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricDeletesBodyServiceMockRecorder
+}
+
+// MockMetricDeletesBodyServiceMockRecorder is the mock recorder for MockMetricDeletesBodyService.
+type MockMetricDeletesBodyServiceMockRecorder struct {
+	mock *MockMetricDeletesBodyService
+}
+
+// NewMockMetricDeletesBodyService creates a new mock instance.
+func NewMockMetricDeletesBodyService(ctrl *gomock.Controller) *MockMetricDeletesBodyService {
+	mock := &MockMetricDeletesBodyService{ctrl: ctrl}
+	mock.recorder = &MockMetricDeletesBodyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricDeletesBodyService) EXPECT() *MockMetricDeletesBodyServiceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockMetricDeletesBodyService) Delete(ctx context.Context, ids []*domain.MetricID) ([]*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, ids)
+	ret0, _ := ret[0].([]*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockMetricDeletesBodyServiceMockRecorder) Delete(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockMetricDeletesBodyService)(nil).Delete), ctx, ids)
+}
@@ -0,0 +1,70 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricDeletesBodyUsecase_Execute(t *testing.T) {
+	labels := map[string]string{"host": "h1"}
+	tests := []struct {
+		name         string
+		req          []*MetricDeleteBodyRequest
+		mock         func(mockService *MockMetricDeletesBodyService)
+		expectErr    error
+		expectedResp []*MetricGetByIDBodyResponse
+	}{
+		{
+			name: "success",
+			req: []*MetricDeleteBodyRequest{
+				{ID: "test_counter", Type: "counter", Labels: labels},
+				{ID: "missing", Type: "gauge"},
+			},
+			mock: func(mockService *MockMetricDeletesBodyService) {
+				ids := []*domain.MetricID{
+					{ID: "test_counter", Type: domain.Counter, Labels: domain.NewLabels(labels)},
+					{ID: "missing", Type: domain.Gauge},
+				}
+				mockService.EXPECT().Delete(gomock.Any(), ids).
+					Return([]*domain.Metric{{MetricID: *ids[0], Delta: int64Ptr(5)}}, nil)
+			},
+			expectedResp: []*MetricGetByIDBodyResponse{
+				{ID: "test_counter", Type: "counter", Labels: labels, Delta: int64Ptr(5)},
+			},
+		},
+		{
+			name:      "invalid labels",
+			req:       []*MetricDeleteBodyRequest{{ID: "test", Type: "gauge", Labels: map[string]string{"1bad": "x"}}},
+			mock:      func(mockService *MockMetricDeletesBodyService) {},
+			expectErr: errors.ErrInvalidMetricLabels,
+		},
+		{
+			name: "service error",
+			req:  []*MetricDeleteBodyRequest{{ID: "test", Type: "gauge"}},
+			mock: func(mockService *MockMetricDeletesBodyService) {
+				mockService.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil, errors.ErrMetricIsNotDeleted)
+			},
+			expectErr: errors.ErrMetricIsNotDeleted,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockService := NewMockMetricDeletesBodyService(ctrl)
+			tt.mock(mockService)
+			uc := NewMetricDeletesBodyUsecase(mockService)
+			resp, err := uc.Execute(context.Background(), tt.req)
+			assert.Equal(t, tt.expectErr, err)
+			if tt.expectErr == nil {
+				assert.Equal(t, tt.expectedResp, resp)
+			}
+		})
+	}
+}
@@ -35,8 +35,9 @@ func (uc *MetricGetByIDBodyUsecase) Execute(
 }
 
 type MetricGetByIDBodyRequest struct {
-	ID   string `json:"id"`
-	Type string `json:"type"`
+	ID     string            `json:"id"`
+	Type   string            `json:"type"`
+	Labels map[string]string `json:"labels,omitempty"`
 }
 
 func ValidateMetricGetByIDBodyRequest(req *MetricGetByIDBodyRequest) error {
@@ -48,28 +49,42 @@ func ValidateMetricGetByIDBodyRequest(req *MetricGetByIDBodyRequest) error {
 	if err != nil {
 		return err
 	}
+	err = validation.ValidateMetricLabels(req.Labels)
+	if err != nil {
+		return err
+	}
 	return nil
 }
 
 func ConvertMetricGetByIDBodyRequestToDomain(req *MetricGetByIDBodyRequest) *domain.MetricID {
 	return &domain.MetricID{
-		ID:   req.ID,
-		Type: domain.MetricType(req.Type),
+		ID:     req.ID,
+		Type:   domain.MetricType(req.Type),
+		Labels: domain.NewLabels(req.Labels),
 	}
 }
 
 type MetricGetByIDBodyResponse struct {
-	ID    string   `json:"id"`
-	Type  string   `json:"type"`
-	Delta *int64   `json:"delta,omitempty"`
-	Value *float64 `json:"value,omitempty"`
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	Labels    map[string]string      `json:"labels,omitempty"`
+	Delta     *int64                 `json:"delta,omitempty"`
+	Value     *float64               `json:"value,omitempty"`
+	Histogram *domain.HistogramValue `json:"histogram,omitempty"`
+	Summary   *domain.SummaryValue   `json:"summary,omitempty"`
 }
 
 func NewMetricGetByIDResponse(metric *domain.Metric) *MetricGetByIDBodyResponse {
-	return &MetricGetByIDBodyResponse{
-		ID:    metric.ID,
-		Type:  string(metric.Type),
-		Delta: metric.Delta,
-		Value: metric.Value,
+	resp := &MetricGetByIDBodyResponse{
+		ID:        metric.ID,
+		Type:      string(metric.Type),
+		Labels:    metric.Labels.Map(),
+		Delta:     metric.Delta,
+		Value:     metric.Value,
+		Histogram: metric.Histogram,
+	}
+	if metric.Summary != nil {
+		resp.Summary = metric.Summary.Report()
 	}
+	return resp
 }
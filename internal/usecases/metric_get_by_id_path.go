@@ -5,6 +5,7 @@ import (
 	"go-metrics/internal/converters"
 	"go-metrics/internal/domain"
 	"go-metrics/internal/validation"
+	"strings"
 )
 
 type MetricGetByIDPathService interface {
@@ -66,9 +67,43 @@ func NewMetricGetByIDPathResponse(metrics *domain.Metric) *MetricGetByIDPathResp
 		v := converters.FormatInt64(*metrics.Delta)
 		s := MetricGetByIDPathResponse(v)
 		return &s
+	} else if metrics.Type == domain.Histogram {
+		s := MetricGetByIDPathResponse(FormatHistogramText(metrics.Histogram))
+		return &s
+	} else if metrics.Type == domain.Summary {
+		s := MetricGetByIDPathResponse(FormatSummaryText(metrics.Summary))
+		return &s
 	} else {
 		v := converters.FormatFloat64(*metrics.Value)
 		s := MetricGetByIDPathResponse(v)
 		return &s
 	}
 }
+
+func FormatHistogramText(h *domain.HistogramValue) string {
+	var sb strings.Builder
+	for i, count := range h.Cumulative() {
+		sb.WriteString("le=" + FormatBucketBound(h.Buckets, i) + " " + converters.FormatInt64(count) + "\n")
+	}
+	sb.WriteString("sum " + converters.FormatFloat64(h.Sum) + "\n")
+	sb.WriteString("count " + converters.FormatInt64(h.Count) + "\n")
+	return sb.String()
+}
+
+func FormatSummaryText(s *domain.SummaryValue) string {
+	var sb strings.Builder
+	report := s.Report()
+	for i, q := range report.Quantiles {
+		sb.WriteString("quantile=" + converters.FormatFloat64(q) + " " + converters.FormatFloat64(report.Values[i]) + "\n")
+	}
+	sb.WriteString("sum " + converters.FormatFloat64(report.Sum) + "\n")
+	sb.WriteString("count " + converters.FormatInt64(report.Count) + "\n")
+	return sb.String()
+}
+
+func FormatBucketBound(buckets []float64, i int) string {
+	if i >= len(buckets) {
+		return "+Inf"
+	}
+	return converters.FormatFloat64(buckets[i])
+}
@@ -57,6 +57,49 @@ func TestMetricGetByIDPathUsecase_Execute(t *testing.T) {
 			expectErr:    false,
 			expectedResp: toMetricGetByIDPathResponse("123.456"),
 		},
+		{
+			name: "success case - histogram",
+			req: &MetricGetByIDPathRequest{
+				Type: string(domain.Histogram),
+				Name: "test_histogram",
+			},
+			mock: func(mockService *MockMetricGetByIDPathService, req *MetricGetByIDPathRequest) {
+				metricID := ConvertMetricGetByIDPathRequestToDomain(req)
+				histogram := domain.NewHistogram([]float64{1, 5})
+				histogram.Observe(0.5)
+				histogram.Observe(3)
+				histogram.Observe(10)
+				mockService.EXPECT().
+					GetByID(gomock.Any(), metricID).
+					Return(&domain.Metric{
+						MetricID:  *metricID,
+						Histogram: histogram,
+					}, nil)
+			},
+			expectErr:    false,
+			expectedResp: toMetricGetByIDPathResponse("le=1 1\nle=5 2\nle=+Inf 3\nsum 13.5\ncount 3\n"),
+		},
+		{
+			name: "success case - summary",
+			req: &MetricGetByIDPathRequest{
+				Type: string(domain.Summary),
+				Name: "test_summary",
+			},
+			mock: func(mockService *MockMetricGetByIDPathService, req *MetricGetByIDPathRequest) {
+				metricID := ConvertMetricGetByIDPathRequestToDomain(req)
+				summary := domain.NewSummary([]float64{0.5})
+				summary.Observe(1)
+				summary.Observe(3)
+				mockService.EXPECT().
+					GetByID(gomock.Any(), metricID).
+					Return(&domain.Metric{
+						MetricID: *metricID,
+						Summary:  summary,
+					}, nil)
+			},
+			expectErr:    false,
+			expectedResp: toMetricGetByIDPathResponse("quantile=0.5 2\nsum 4\ncount 2\n"),
+		},
 		{
 			name: "validation error - empty name",
 			req: &MetricGetByIDPathRequest{
@@ -0,0 +1,149 @@
+package usecases
+
+import (
+	"context"
+	"go-metrics/internal/converters"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/validation"
+	"time"
+)
+
+type MetricHistoryPathService interface {
+	History(ctx context.Context, id *domain.MetricID, from time.Time, to time.Time) ([]*domain.MetricSample, error)
+}
+
+type MetricHistoryPathUsecase struct {
+	svc MetricHistoryPathService
+}
+
+func NewMetricHistoryPathUsecase(svc MetricHistoryPathService) *MetricHistoryPathUsecase {
+	return &MetricHistoryPathUsecase{svc: svc}
+}
+
+func (uc *MetricHistoryPathUsecase) Execute(
+	ctx context.Context,
+	req *MetricHistoryPathRequest,
+) (*MetricHistoryPathResponse, error) {
+	err := ValidateMetricHistoryPathRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	id, from, to, err := ConvertMetricHistoryPathRequestToDomain(req)
+	if err != nil {
+		return nil, err
+	}
+	samples, err := uc.svc.History(ctx, id, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return NewMetricHistoryPathResponse(id, samples), nil
+}
+
+type MetricHistoryPathRequest struct {
+	Type   string
+	Name   string
+	From   string
+	To     string
+	Labels []string
+}
+
+func ValidateMetricHistoryPathRequest(req *MetricHistoryPathRequest) error {
+	err := validation.ValidateMetricID(req.Name)
+	if err != nil {
+		return err
+	}
+	err = validation.ValidateMetricType(req.Type)
+	if err != nil {
+		return err
+	}
+	for _, value := range []string{req.From, req.To} {
+		if value == "" {
+			continue
+		}
+		if _, err := converters.ConvertToTime(value); err != nil {
+			return errors.ErrInvalidTimeRange
+		}
+	}
+	return nil
+}
+
+func ConvertMetricHistoryPathRequestToDomain(
+	req *MetricHistoryPathRequest,
+) (*domain.MetricID, time.Time, time.Time, error) {
+	labels, err := ConvertMetricHistoryLabelsToDomain(req.Labels)
+	if err != nil {
+		return nil, time.Time{}, time.Time{}, err
+	}
+	id := &domain.MetricID{
+		ID:     req.Name,
+		Type:   domain.MetricType(req.Type),
+		Labels: labels,
+	}
+	var from time.Time
+	to := time.Now()
+	if req.From != "" {
+		v, _ := converters.ConvertToTime(req.From)
+		from = *v
+	}
+	if req.To != "" {
+		v, _ := converters.ConvertToTime(req.To)
+		to = *v
+	}
+	return id, from, to, nil
+}
+
+func ConvertMetricHistoryLabelsToDomain(values []string) (domain.Labels, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+	labels := make(map[string]string, len(values))
+	for _, value := range values {
+		matcher, err := domain.ParseLabelMatcher(value)
+		if err != nil || matcher.Operator != domain.LabelMatchEqual {
+			return "", errors.ErrInvalidMetricLabels
+		}
+		if _, exists := labels[matcher.Name]; exists {
+			return "", errors.ErrInvalidMetricLabels
+		}
+		labels[matcher.Name] = matcher.Value
+	}
+	return domain.NewLabels(labels), nil
+}
+
+type MetricHistorySample struct {
+	Timestamp time.Time              `json:"timestamp"`
+	Delta     *int64                 `json:"delta,omitempty"`
+	Value     *float64               `json:"value,omitempty"`
+	Histogram *domain.HistogramValue `json:"histogram,omitempty"`
+	Summary   *domain.SummaryValue   `json:"summary,omitempty"`
+}
+
+type MetricHistoryPathResponse struct {
+	ID      string                 `json:"id"`
+	Type    string                 `json:"type"`
+	Labels  map[string]string      `json:"labels,omitempty"`
+	Samples []*MetricHistorySample `json:"samples"`
+}
+
+func NewMetricHistoryPathResponse(id *domain.MetricID, samples []*domain.MetricSample) *MetricHistoryPathResponse {
+	resp := &MetricHistoryPathResponse{
+		ID:      id.ID,
+		Type:    string(id.Type),
+		Labels:  id.Labels.Map(),
+		Samples: make([]*MetricHistorySample, 0, len(samples)),
+	}
+	for _, sample := range samples {
+		item := &MetricHistorySample{
+			Timestamp: sample.Timestamp,
+			Delta:     sample.Delta,
+			Value:     sample.Value,
+			Histogram: sample.Histogram,
+		}
+		if sample.Summary != nil {
+			item.Summary = sample.Summary.Report()
+		}
+		resp.Samples = append(resp.Samples, item)
+	}
+	return resp
+}
@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecases/metric_history_path.go
+
+// Package usecases is a generated GoMock package.
+package usecases
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricHistoryPathService is a mock of MetricHistoryPathService interface.
+type MockMetricHistoryPathService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricHistoryPathServiceMockRecorder
+}
+
+// MockMetricHistoryPathServiceMockRecorder is the mock recorder for MockMetricHistoryPathService.
+type MockMetricHistoryPathServiceMockRecorder struct {
+	mock *MockMetricHistoryPathService
+}
+
+// NewMockMetricHistoryPathService creates a new mock instance.
+func NewMockMetricHistoryPathService(ctrl *gomock.Controller) *MockMetricHistoryPathService {
+	mock := &MockMetricHistoryPathService{ctrl: ctrl}
+	mock.recorder = &MockMetricHistoryPathServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricHistoryPathService) EXPECT() *MockMetricHistoryPathServiceMockRecorder {
+	return m.recorder
+}
+
+// History mocks base method.
+func (m *MockMetricHistoryPathService) History(ctx context.Context, id *domain.MetricID, from, to time.Time) ([]*domain.MetricSample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, id, from, to)
+	ret0, _ := ret[0].([]*domain.MetricSample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockMetricHistoryPathServiceMockRecorder) History(ctx, id, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockMetricHistoryPathService)(nil).History), ctx, id, from, to)
+}
@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricHistoryPathUsecase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := NewMockMetricHistoryPathService(ctrl)
+	usecase := NewMetricHistoryPathUsecase(mockService)
+	id := &domain.MetricID{ID: "HeapAlloc", Type: domain.Gauge}
+
+	t.Run("success", func(t *testing.T) {
+		ts := time.Unix(150, 0)
+		mockService.EXPECT().
+			History(gomock.Any(), id, time.Unix(100, 0), time.Unix(200, 0)).
+			Return([]*domain.MetricSample{
+				{Metric: domain.Metric{MetricID: *id, Value: ptrFloat64(42)}, Timestamp: ts},
+			}, nil)
+		resp, err := usecase.Execute(context.Background(), &MetricHistoryPathRequest{
+			Type: "gauge", Name: "HeapAlloc", From: "100", To: "200",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, &MetricHistoryPathResponse{
+			ID:      "HeapAlloc",
+			Type:    "gauge",
+			Samples: []*MetricHistorySample{{Timestamp: ts, Value: ptrFloat64(42)}},
+		}, resp)
+	})
+
+	t.Run("labels and histogram", func(t *testing.T) {
+		labelled := &domain.MetricID{
+			ID: "Latency", Type: domain.Histogram, Labels: domain.NewLabels(map[string]string{"host": "h1", "dc": "eu"}),
+		}
+		ts := time.Unix(150, 0)
+		histogram := &domain.HistogramValue{Buckets: []float64{1}, Counts: []int64{1, 0}, Sum: 0.5, Count: 1}
+		mockService.EXPECT().
+			History(gomock.Any(), labelled, time.Unix(100, 0), time.Unix(200, 0)).
+			Return([]*domain.MetricSample{
+				{Metric: domain.Metric{MetricID: *labelled, Histogram: histogram}, Timestamp: ts},
+			}, nil)
+		resp, err := usecase.Execute(context.Background(), &MetricHistoryPathRequest{
+			Type: "histogram", Name: "Latency", From: "100", To: "200", Labels: []string{"host=h1", `dc="eu"`},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"host": "h1", "dc": "eu"}, resp.Labels)
+		assert.Equal(t, []*MetricHistorySample{{Timestamp: ts, Histogram: histogram}}, resp.Samples)
+	})
+
+	t.Run("invalid labels", func(t *testing.T) {
+		for _, labels := range [][]string{{"host"}, {"host=~h.*"}, {"host=h1", "host=h2"}} {
+			resp, err := usecase.Execute(context.Background(), &MetricHistoryPathRequest{
+				Type: "gauge", Name: "HeapAlloc", Labels: labels,
+			})
+			assert.Nil(t, resp)
+			assert.Equal(t, errors.ErrInvalidMetricLabels, err)
+		}
+	})
+
+	t.Run("invalid from", func(t *testing.T) {
+		resp, err := usecase.Execute(context.Background(), &MetricHistoryPathRequest{
+			Type: "gauge", Name: "HeapAlloc", From: "yesterday",
+		})
+		assert.Nil(t, resp)
+		assert.Equal(t, errors.ErrInvalidTimeRange, err)
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		resp, err := usecase.Execute(context.Background(), &MetricHistoryPathRequest{
+			Type: "unknown", Name: "HeapAlloc",
+		})
+		assert.Nil(t, resp)
+		assert.Equal(t, errors.ErrInvalidMetricType, err)
+	})
+}
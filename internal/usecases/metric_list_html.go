@@ -1,59 +1,241 @@
 package usecases
 
 import (
+	"bytes"
 	"context"
 	"go-metrics/internal/converters"
 	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/validation"
+	"go-metrics/internal/web"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
+const (
+	DefaultMetricListHTMLRefresh = 10
+	MaxMetricListHTMLRefresh     = 3600
+	MetricListHTMLSparklineSpan  = time.Hour
+	metricSparklinePoints        = 60
+	metricSparklineWidth         = 120
+	metricSparklineHeight        = 24
+)
+
+var metricListHTMLTypes = []domain.MetricType{domain.Counter, domain.Gauge, domain.Histogram, domain.Summary}
+
 type MetricListHTMLService interface {
 	List(ctx context.Context) ([]*domain.Metric, error)
 }
 
+type MetricListHTMLHistoryService interface {
+	HistoryBatch(
+		ctx context.Context, ids []*domain.MetricID, from time.Time, to time.Time,
+	) (map[domain.MetricID][]*domain.MetricSample, error)
+}
+
 type MetricListHTMLUsecase struct {
 	svc MetricListHTMLService
+	h   MetricListHTMLHistoryService
+}
+
+func NewMetricListHTMLUsecase(svc MetricListHTMLService, h MetricListHTMLHistoryService) *MetricListHTMLUsecase {
+	return &MetricListHTMLUsecase{svc: svc, h: h}
 }
 
-func NewMetricListHTMLUsecase(svc MetricListHTMLService) *MetricListHTMLUsecase {
-	return &MetricListHTMLUsecase{svc: svc}
+type MetricListHTMLRequest struct {
+	Type    string
+	Name    string
+	Refresh string
 }
 
 func (uc *MetricListHTMLUsecase) Execute(
 	ctx context.Context,
+	req *MetricListHTMLRequest,
 ) (*MetricListHTMLResponse, error) {
+	view, err := NewMetricListHTMLView(req)
+	if err != nil {
+		return nil, err
+	}
 	metrics, err := uc.svc.List(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return NewMetricListHTMLResponse(metrics), nil
+	view.Total = len(metrics)
+	matched := make([]*domain.Metric, 0, len(metrics))
+	ids := make([]*domain.MetricID, 0, len(metrics))
+	for _, metric := range metrics {
+		if view.Matches(metric) {
+			matched = append(matched, metric)
+			ids = append(ids, &metric.MetricID)
+		}
+	}
+	to := time.Now()
+	samples, err := uc.h.HistoryBatch(ctx, ids, to.Add(-MetricListHTMLSparklineSpan), to)
+	if err != nil {
+		return nil, err
+	}
+	for _, metric := range matched {
+		view.Rows = append(view.Rows, NewMetricListHTMLRow(metric, samples[metric.MetricID]))
+	}
+	return NewMetricListHTMLResponse(view)
 }
 
-type MetricListHTMLResponse struct {
-	HTML string
+type MetricListHTMLView struct {
+	Type       string
+	Name       string
+	Refresh    int
+	MaxRefresh int
+	Types      []domain.MetricType
+	Total      int
+	Rows       []*MetricListHTMLRow
 }
 
-func NewMetricListHTMLResponse(metrics []*domain.Metric) *MetricListHTMLResponse {
-	var sb strings.Builder
-	sb.WriteString("<html><head><title>Metrics</title></head><body>")
-	sb.WriteString("<h1>Metrics List</h1>")
-	sb.WriteString("<table border='1' style='border-collapse: collapse; width: 50%;'>")
-	sb.WriteString("<tr><th>ID</th><th>Value</th></tr>")
-	for _, metric := range metrics {
-		sb.WriteString("<tr>")
-		sb.WriteString("<td>" + metric.ID + "</td>")
-		sb.WriteString("<td>")
+func NewMetricListHTMLView(req *MetricListHTMLRequest) (*MetricListHTMLView, error) {
+	view := &MetricListHTMLView{
+		Type:       req.Type,
+		Name:       req.Name,
+		Refresh:    DefaultMetricListHTMLRefresh,
+		MaxRefresh: MaxMetricListHTMLRefresh,
+		Types:      metricListHTMLTypes,
+	}
+	if req.Type != "" {
+		if err := validation.ValidateMetricType(req.Type); err != nil {
+			return nil, err
+		}
+	}
+	if req.Refresh != "" {
+		refresh, err := strconv.Atoi(req.Refresh)
+		if err != nil || refresh < 0 || refresh > MaxMetricListHTMLRefresh {
+			return nil, errors.ErrInvalidMetricQuery
+		}
+		view.Refresh = refresh
+	}
+	return view, nil
+}
+
+func (v *MetricListHTMLView) Matches(metric *domain.Metric) bool {
+	if v.Type != "" && string(metric.Type) != v.Type {
+		return false
+	}
+	return strings.Contains(strings.ToLower(metric.ID), strings.ToLower(v.Name))
+}
 
-		if metric.Type == domain.Counter && metric.Delta != nil {
-			sb.WriteString(converters.FormatInt64(*metric.Delta))
-		} else if metric.Type == domain.Gauge && metric.Value != nil {
-			sb.WriteString(converters.FormatFloat64(*metric.Value))
-		} else {
-			sb.WriteString("N/A")
+type MetricListHTMLRow struct {
+	ID        string
+	Labels    string
+	Type      domain.MetricType
+	Value     string
+	Updated   string
+	Sparkline *MetricSparkline
+}
+
+func NewMetricListHTMLRow(metric *domain.Metric, samples []*domain.MetricSample) *MetricListHTMLRow {
+	row := &MetricListHTMLRow{
+		ID:     metric.ID,
+		Labels: metric.Labels.String(),
+		Type:   metric.Type,
+		Value:  FormatMetricListHTMLValue(metric),
+	}
+	sort.SliceStable(samples, func(i, j int) bool {
+		return samples[i].Timestamp.Before(samples[j].Timestamp)
+	})
+	values := make([]float64, 0, len(samples))
+	for _, sample := range samples {
+		if value, ok := metricSampleValue(&sample.Metric); ok {
+			values = append(values, value)
+		}
+	}
+	if !metric.UpdatedAt.IsZero() {
+		row.Updated = metric.UpdatedAt.Format(time.DateTime)
+	}
+	row.Sparkline = NewMetricSparkline(values)
+	return row
+}
+
+func FormatMetricListHTMLValue(metric *domain.Metric) string {
+	switch {
+	case metric.Type == domain.Counter && metric.Delta != nil:
+		return converters.FormatInt64(*metric.Delta)
+	case metric.Type == domain.Gauge && metric.Value != nil:
+		return converters.FormatFloat64(*metric.Value)
+	case metric.Type == domain.Histogram && metric.Histogram != nil:
+		return "count=" + converters.FormatInt64(metric.Histogram.Count) +
+			" sum=" + converters.FormatFloat64(metric.Histogram.Sum)
+	case metric.Type == domain.Summary && metric.Summary != nil:
+		var sb strings.Builder
+		report := metric.Summary.Report()
+		for i, q := range report.Quantiles {
+			sb.WriteString("q" + converters.FormatFloat64(q) + "=" + converters.FormatFloat64(report.Values[i]) + " ")
 		}
+		sb.WriteString("count=" + converters.FormatInt64(report.Count))
+		return sb.String()
+	default:
+		return "N/A"
+	}
+}
+
+func metricSampleValue(metric *domain.Metric) (float64, bool) {
+	switch {
+	case metric.Type == domain.Counter && metric.Delta != nil:
+		return float64(*metric.Delta), true
+	case metric.Type == domain.Gauge && metric.Value != nil:
+		return *metric.Value, true
+	case metric.Type == domain.Histogram && metric.Histogram != nil:
+		return float64(metric.Histogram.Count), true
+	case metric.Type == domain.Summary && metric.Summary != nil:
+		return float64(metric.Summary.Report().Count), true
+	default:
+		return 0, false
+	}
+}
+
+type MetricSparkline struct {
+	Width  int
+	Height int
+	Points string
+}
+
+func NewMetricSparkline(values []float64) *MetricSparkline {
+	if len(values) < 2 {
+		return nil
+	}
+	if len(values) > metricSparklinePoints {
+		sampled := make([]float64, metricSparklinePoints)
+		for i := range sampled {
+			sampled[i] = values[i*(len(values)-1)/(metricSparklinePoints-1)]
+		}
+		values = sampled
+	}
+	low, high := values[0], values[0]
+	for _, value := range values {
+		low = min(low, value)
+		high = max(high, value)
+	}
+	var sb strings.Builder
+	for i, value := range values {
+		x := float64(i) * metricSparklineWidth / float64(len(values)-1)
+		y := float64(metricSparklineHeight) / 2
+		if high > low {
+			y = metricSparklineHeight - (value-low)/(high-low)*metricSparklineHeight
+		}
+		if i > 0 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(strconv.FormatFloat(x, 'f', 1, 64) + "," + strconv.FormatFloat(y, 'f', 1, 64))
+	}
+	return &MetricSparkline{Width: metricSparklineWidth, Height: metricSparklineHeight, Points: sb.String()}
+}
+
+type MetricListHTMLResponse struct {
+	HTML string
+}
 
-		sb.WriteString("</td></tr>")
+func NewMetricListHTMLResponse(view *MetricListHTMLView) (*MetricListHTMLResponse, error) {
+	var buf bytes.Buffer
+	if err := web.Templates.ExecuteTemplate(&buf, "metric_list.html", view); err != nil {
+		return nil, errors.ErrMetricListInternal
 	}
-	sb.WriteString("</table></body></html>")
-	return &MetricListHTMLResponse{HTML: sb.String()}
+	return &MetricListHTMLResponse{HTML: buf.String()}, nil
 }
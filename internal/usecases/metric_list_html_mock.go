@@ -8,6 +8,7 @@ import (
 	context "context"
 	domain "go-metrics/internal/domain"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -49,3 +50,41 @@ func (mr *MockMetricListHTMLServiceMockRecorder) List(ctx interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMetricListHTMLService)(nil).List), ctx)
 }
+
+// MockMetricListHTMLHistoryService is a mock of MetricListHTMLHistoryService interface.
+type MockMetricListHTMLHistoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricListHTMLHistoryServiceMockRecorder
+}
+
+// MockMetricListHTMLHistoryServiceMockRecorder is the mock recorder for MockMetricListHTMLHistoryService.
+type MockMetricListHTMLHistoryServiceMockRecorder struct {
+	mock *MockMetricListHTMLHistoryService
+}
+
+// NewMockMetricListHTMLHistoryService creates a new mock instance.
+func NewMockMetricListHTMLHistoryService(ctrl *gomock.Controller) *MockMetricListHTMLHistoryService {
+	mock := &MockMetricListHTMLHistoryService{ctrl: ctrl}
+	mock.recorder = &MockMetricListHTMLHistoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricListHTMLHistoryService) EXPECT() *MockMetricListHTMLHistoryServiceMockRecorder {
+	return m.recorder
+}
+
+// HistoryBatch mocks base method.
+func (m *MockMetricListHTMLHistoryService) HistoryBatch(ctx context.Context, ids []*domain.MetricID, from, to time.Time) (map[domain.MetricID][]*domain.MetricSample, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HistoryBatch", ctx, ids, from, to)
+	ret0, _ := ret[0].(map[domain.MetricID][]*domain.MetricSample)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HistoryBatch indicates an expected call of HistoryBatch.
+func (mr *MockMetricListHTMLHistoryServiceMockRecorder) HistoryBatch(ctx, ids, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HistoryBatch", reflect.TypeOf((*MockMetricListHTMLHistoryService)(nil).HistoryBatch), ctx, ids, from, to)
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"errors"
 	"go-metrics/internal/domain"
+	e "go-metrics/internal/errors"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestMetricListHTMLUsecase_Execute(t *testing.T) {
@@ -15,7 +19,9 @@ func TestMetricListHTMLUsecase_Execute(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockService := NewMockMetricListHTMLService(ctrl)
-	usecase := &MetricListHTMLUsecase{svc: mockService}
+	mockHistory := NewMockMetricListHTMLHistoryService(ctrl)
+	mockHistory.EXPECT().HistoryBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	usecase := &MetricListHTMLUsecase{svc: mockService, h: mockHistory}
 
 	tests := []struct {
 		name        string
@@ -34,7 +40,7 @@ func TestMetricListHTMLUsecase_Execute(t *testing.T) {
 			expectedErr: nil,
 			checkHTML: func(html string) {
 				assert.Contains(t, html, "<table")
-				assert.Contains(t, html, "<tr><th>ID</th><th>Value</th></tr>")
+				assert.Contains(t, html, "<tr><th>ID</th><th>Type</th><th>Value</th><th>Last update</th><th>Recent</th></tr>")
 				assert.Contains(t, html, "metric1")
 				assert.Contains(t, html, "100")
 				assert.Contains(t, html, "metric2")
@@ -71,7 +77,7 @@ func TestMetricListHTMLUsecase_Execute(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockSetup()
 
-			resp, err := usecase.Execute(context.Background())
+			resp, err := usecase.Execute(context.Background(), &MetricListHTMLRequest{})
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -88,6 +94,60 @@ func TestMetricListHTMLUsecase_Execute(t *testing.T) {
 	}
 }
 
+func TestMetricListHTMLUsecase_Dashboard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := NewMockMetricListHTMLService(ctrl)
+	mockHistory := NewMockMetricListHTMLHistoryService(ctrl)
+	usecase := NewMetricListHTMLUsecase(mockService, mockHistory)
+
+	updated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.Local)
+	cpu := &domain.Metric{
+		MetricID: domain.MetricID{ID: "CPU_<script>", Type: domain.Gauge}, Value: ptrFloat64(3), UpdatedAt: updated,
+	}
+	hits := &domain.Metric{MetricID: domain.MetricID{ID: "cpu_hits", Type: domain.Counter}, Delta: ptrInt64(7)}
+	mockService.EXPECT().List(gomock.Any()).Return([]*domain.Metric{cpu, hits, {
+		MetricID: domain.MetricID{ID: "mem", Type: domain.Gauge}, Value: ptrFloat64(1),
+	}}, nil)
+	mockHistory.EXPECT().HistoryBatch(gomock.Any(), []*domain.MetricID{&cpu.MetricID}, gomock.Any(), gomock.Any()).
+		Return(map[domain.MetricID][]*domain.MetricSample{cpu.MetricID: {
+			{Metric: domain.Metric{MetricID: cpu.MetricID, Value: ptrFloat64(3)}, Timestamp: updated.Add(-time.Minute)},
+			{Metric: domain.Metric{MetricID: cpu.MetricID, Value: ptrFloat64(1)}, Timestamp: updated.Add(-2 * time.Minute)},
+		}}, nil)
+
+	resp, err := usecase.Execute(context.Background(), &MetricListHTMLRequest{Type: "gauge", Name: "cpu", Refresh: "0"})
+	require.NoError(t, err)
+	assert.Contains(t, resp.HTML, "CPU_&lt;script&gt;")
+	assert.NotContains(t, resp.HTML, "<script>")
+	assert.NotContains(t, resp.HTML, "cpu_hits")
+	assert.NotContains(t, resp.HTML, "http-equiv=\"refresh\"")
+	assert.Contains(t, resp.HTML, "Showing 1 of 3 metrics")
+	assert.Contains(t, resp.HTML, updated.Format(time.DateTime))
+	assert.Contains(t, resp.HTML, `<polyline points="0.0,24.0 120.0,0.0"/>`)
+	assert.Contains(t, resp.HTML, `<option value="gauge" selected>gauge</option>`)
+
+	_, err = usecase.Execute(context.Background(), &MetricListHTMLRequest{Type: "bogus"})
+	assert.Equal(t, e.ErrInvalidMetricType, err)
+	_, err = usecase.Execute(context.Background(), &MetricListHTMLRequest{Refresh: "-1"})
+	assert.Equal(t, e.ErrInvalidMetricQuery, err)
+}
+
+func TestNewMetricSparkline(t *testing.T) {
+	assert.Nil(t, NewMetricSparkline(nil))
+	assert.Nil(t, NewMetricSparkline([]float64{1}))
+	assert.Equal(t, "0.0,12.0 60.0,12.0 120.0,12.0", NewMetricSparkline([]float64{5, 5, 5}).Points)
+
+	values := make([]float64, 500)
+	for i := range values {
+		values[i] = float64(i)
+	}
+	sparkline := NewMetricSparkline(values)
+	points := strings.Fields(sparkline.Points)
+	require.Len(t, points, metricSparklinePoints)
+	assert.Equal(t, "0.0,24.0", points[0])
+	assert.Equal(t, "120.0,0.0", points[len(points)-1])
+}
+
 func ptrInt64(v int64) *int64 {
 	return &v
 }
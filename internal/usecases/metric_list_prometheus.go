@@ -0,0 +1,164 @@
+package usecases
+
+import (
+	"context"
+	"go-metrics/internal/converters"
+	"go-metrics/internal/domain"
+	"go-metrics/pkg/log"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type MetricListPrometheusService interface {
+	List(ctx context.Context) ([]*domain.Metric, error)
+}
+
+type MetricListPrometheusUsecase struct {
+	svc MetricListPrometheusService
+}
+
+func NewMetricListPrometheusUsecase(svc MetricListPrometheusService) *MetricListPrometheusUsecase {
+	return &MetricListPrometheusUsecase{svc: svc}
+}
+
+func (uc *MetricListPrometheusUsecase) Execute(
+	ctx context.Context,
+) (*MetricListPrometheusResponse, error) {
+	metrics, err := uc.svc.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewMetricListPrometheusResponse(metrics), nil
+}
+
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type MetricListPrometheusResponse struct {
+	Text string
+}
+
+var prometheusInvalidNameChars = regexp.MustCompile("[^a-zA-Z0-9_:]")
+
+type prometheusSeries struct {
+	name   string
+	metric *domain.Metric
+}
+
+type prometheusSeriesKey struct {
+	name   string
+	labels domain.Labels
+}
+
+func NewMetricListPrometheusResponse(metrics []*domain.Metric) *MetricListPrometheusResponse {
+	sorted := make([]*domain.Metric, 0, len(metrics))
+	for _, metric := range metrics {
+		if FormatPrometheusValue(metric) != "" || metric.Histogram != nil || metric.Summary != nil {
+			sorted = append(sorted, metric)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := FormatPrometheusName(sorted[i].ID), FormatPrometheusName(sorted[j].ID)
+		if a != b {
+			return a < b
+		}
+		if sorted[i].Type != sorted[j].Type {
+			return sorted[i].Type < sorted[j].Type
+		}
+		if sorted[i].Labels != sorted[j].Labels {
+			return sorted[i].Labels < sorted[j].Labels
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	owners := make(map[string]domain.MetricType)
+	for _, metric := range sorted {
+		name := FormatPrometheusName(metric.ID)
+		if _, ok := owners[name]; !ok {
+			owners[name] = metric.Type
+		}
+	}
+	series := make([]*prometheusSeries, 0, len(sorted))
+	seen := make(map[prometheusSeriesKey]bool, len(sorted))
+	for _, metric := range sorted {
+		name := FormatPrometheusName(metric.ID)
+		if owners[name] != metric.Type {
+			name = name + "_" + string(metric.Type)
+			if _, taken := owners[name]; taken {
+				log.Error("Dropped Prometheus series with a colliding name", "id", metric.ID, "type", metric.Type, "name", name)
+				continue
+			}
+		}
+		key := prometheusSeriesKey{name: name, labels: metric.Labels}
+		if seen[key] {
+			log.Error("Dropped duplicate Prometheus series", "id", metric.ID, "type", metric.Type, "name", name)
+			continue
+		}
+		seen[key] = true
+		series = append(series, &prometheusSeries{name: name, metric: metric})
+	}
+	sort.SliceStable(series, func(i, j int) bool {
+		if series[i].name != series[j].name {
+			return series[i].name < series[j].name
+		}
+		return series[i].metric.Labels < series[j].metric.Labels
+	})
+	var sb strings.Builder
+	family := ""
+	for _, s := range series {
+		if s.name != family {
+			family = s.name
+			sb.WriteString("# HELP " + s.name + " " + string(s.metric.Type) + " metric " + s.metric.ID + "\n")
+			sb.WriteString("# TYPE " + s.name + " " + string(s.metric.Type) + "\n")
+		}
+		writePrometheusSamples(&sb, s.name, s.metric)
+	}
+	return &MetricListPrometheusResponse{Text: sb.String()}
+}
+
+func writePrometheusSamples(sb *strings.Builder, name string, metric *domain.Metric) {
+	switch {
+	case metric.Type == domain.Histogram && metric.Histogram != nil:
+		for i, count := range metric.Histogram.Cumulative() {
+			le := `le="` + FormatBucketBound(metric.Histogram.Buckets, i) + `"`
+			sb.WriteString(name + "_bucket" + prometheusLabels(metric.Labels, le) + " " + converters.FormatInt64(count) + "\n")
+		}
+		sb.WriteString(name + "_sum" + metric.Labels.String() + " " + converters.FormatFloat64(metric.Histogram.Sum) + "\n")
+		sb.WriteString(name + "_count" + metric.Labels.String() + " " + converters.FormatInt64(metric.Histogram.Count) + "\n")
+	case metric.Type == domain.Summary && metric.Summary != nil:
+		report := metric.Summary.Report()
+		for i, q := range report.Quantiles {
+			quantile := `quantile="` + converters.FormatFloat64(q) + `"`
+			sb.WriteString(name + prometheusLabels(metric.Labels, quantile) + " " + converters.FormatFloat64(report.Values[i]) + "\n")
+		}
+		sb.WriteString(name + "_sum" + metric.Labels.String() + " " + converters.FormatFloat64(report.Sum) + "\n")
+		sb.WriteString(name + "_count" + metric.Labels.String() + " " + converters.FormatInt64(report.Count) + "\n")
+	default:
+		sb.WriteString(name + metric.Labels.String() + " " + FormatPrometheusValue(metric) + "\n")
+	}
+}
+
+func prometheusLabels(labels domain.Labels, extra string) string {
+	if labels == "" {
+		return "{" + extra + "}"
+	}
+	return "{" + string(labels) + "," + extra + "}"
+}
+
+func FormatPrometheusName(id string) string {
+	name := prometheusInvalidNameChars.ReplaceAllString(id, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	return name
+}
+
+func FormatPrometheusValue(metric *domain.Metric) string {
+	switch {
+	case metric.Type == domain.Counter && metric.Delta != nil:
+		return converters.FormatInt64(*metric.Delta)
+	case metric.Type == domain.Gauge && metric.Value != nil:
+		return converters.FormatFloat64(*metric.Value)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecases/metric_list_prometheus.go
+
+// Package usecases is a generated GoMock package.
+package usecases
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricListPrometheusService is a mock of MetricListPrometheusService interface.
+type MockMetricListPrometheusService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricListPrometheusServiceMockRecorder
+}
+
+// MockMetricListPrometheusServiceMockRecorder is the mock recorder for MockMetricListPrometheusService.
+type MockMetricListPrometheusServiceMockRecorder struct {
+	mock *MockMetricListPrometheusService
+}
+
+// NewMockMetricListPrometheusService creates a new mock instance.
+func NewMockMetricListPrometheusService(ctrl *gomock.Controller) *MockMetricListPrometheusService {
+	mock := &MockMetricListPrometheusService{ctrl: ctrl}
+	mock.recorder = &MockMetricListPrometheusServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricListPrometheusService) EXPECT() *MockMetricListPrometheusServiceMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockMetricListPrometheusService) List(ctx context.Context) ([]*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockMetricListPrometheusServiceMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockMetricListPrometheusService)(nil).List), ctx)
+}
@@ -0,0 +1,161 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"go-metrics/internal/domain"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricListPrometheusUsecase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := NewMockMetricListPrometheusService(ctrl)
+	usecase := NewMetricListPrometheusUsecase(mockService)
+
+	tests := []struct {
+		name         string
+		mockSetup    func()
+		expectedErr  error
+		expectedText string
+	}{
+		{
+			name: "success - counters and gauges",
+			mockSetup: func() {
+				mockService.EXPECT().List(gomock.Any()).Return([]*domain.Metric{
+					{MetricID: domain.MetricID{ID: "HeapAlloc", Type: domain.Gauge}, Value: ptrFloat64(123.5)},
+					{MetricID: domain.MetricID{ID: "PollCount", Type: domain.Counter}, Delta: ptrInt64(7)},
+				}, nil)
+			},
+			expectedText: "# HELP HeapAlloc gauge metric HeapAlloc\n" +
+				"# TYPE HeapAlloc gauge\n" +
+				"HeapAlloc 123.5\n" +
+				"# HELP PollCount counter metric PollCount\n" +
+				"# TYPE PollCount counter\n" +
+				"PollCount 7\n",
+		},
+		{
+			name: "name collision and invalid names",
+			mockSetup: func() {
+				mockService.EXPECT().List(gomock.Any()).Return([]*domain.Metric{
+					{MetricID: domain.MetricID{ID: "1m", Type: domain.Gauge}, Value: ptrFloat64(1)},
+					{MetricID: domain.MetricID{ID: "1m", Type: domain.Counter}, Delta: ptrInt64(2)},
+				}, nil)
+			},
+			expectedText: "# HELP _1m counter metric 1m\n" +
+				"# TYPE _1m counter\n" +
+				"_1m 2\n" +
+				"# HELP _1m_gauge gauge metric 1m\n" +
+				"# TYPE _1m_gauge gauge\n" +
+				"_1m_gauge 1\n",
+		},
+		{
+			name: "sanitized names sort and group together",
+			mockSetup: func() {
+				mockService.EXPECT().List(gomock.Any()).Return([]*domain.Metric{
+					{MetricID: domain.MetricID{ID: "a.b", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "b"})}, Value: ptrFloat64(2)},
+					{MetricID: domain.MetricID{ID: "a_a", Type: domain.Gauge}, Value: ptrFloat64(3)},
+					{MetricID: domain.MetricID{ID: "a_b", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "a"})}, Value: ptrFloat64(1)},
+					{MetricID: domain.MetricID{ID: "a_b", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "b"})}, Value: ptrFloat64(4)},
+				}, nil)
+			},
+			expectedText: "# HELP a_a gauge metric a_a\n" +
+				"# TYPE a_a gauge\n" +
+				"a_a 3\n" +
+				"# HELP a_b gauge metric a_b\n" +
+				"# TYPE a_b gauge\n" +
+				"a_b{host=\"a\"} 1\n" +
+				"a_b{host=\"b\"} 2\n",
+		},
+		{
+			name: "renamed family collides with an existing name",
+			mockSetup: func() {
+				mockService.EXPECT().List(gomock.Any()).Return([]*domain.Metric{
+					{MetricID: domain.MetricID{ID: "x", Type: domain.Counter}, Delta: ptrInt64(1)},
+					{MetricID: domain.MetricID{ID: "x", Type: domain.Gauge}, Value: ptrFloat64(2)},
+					{MetricID: domain.MetricID{ID: "x_gauge", Type: domain.Gauge}, Value: ptrFloat64(3)},
+				}, nil)
+			},
+			expectedText: "# HELP x counter metric x\n" +
+				"# TYPE x counter\n" +
+				"x 1\n" +
+				"# HELP x_gauge gauge metric x_gauge\n" +
+				"# TYPE x_gauge gauge\n" +
+				"x_gauge 3\n",
+		},
+		{
+			name: "labeled series share one family",
+			mockSetup: func() {
+				mockService.EXPECT().List(gomock.Any()).Return([]*domain.Metric{
+					{MetricID: domain.MetricID{ID: "CPU", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "b"})}, Value: ptrFloat64(2)},
+					{MetricID: domain.MetricID{ID: "CPU", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "a"})}, Value: ptrFloat64(1)},
+				}, nil)
+			},
+			expectedText: "# HELP CPU gauge metric CPU\n" +
+				"# TYPE CPU gauge\n" +
+				"CPU{host=\"a\"} 1\n" +
+				"CPU{host=\"b\"} 2\n",
+		},
+		{
+			name: "histogram and summary",
+			mockSetup: func() {
+				histogram := domain.NewHistogram([]float64{1})
+				histogram.Observe(0.5)
+				histogram.Observe(2)
+				summary := domain.NewSummary([]float64{0.5})
+				summary.Observe(4)
+				mockService.EXPECT().List(gomock.Any()).Return([]*domain.Metric{
+					{MetricID: domain.MetricID{ID: "GCPause", Type: domain.Histogram, Labels: domain.NewLabels(map[string]string{"host": "a"})}, Histogram: histogram},
+					{MetricID: domain.MetricID{ID: "Latency", Type: domain.Summary}, Summary: summary},
+				}, nil)
+			},
+			expectedText: "# HELP GCPause histogram metric GCPause\n" +
+				"# TYPE GCPause histogram\n" +
+				"GCPause_bucket{host=\"a\",le=\"1\"} 1\n" +
+				"GCPause_bucket{host=\"a\",le=\"+Inf\"} 2\n" +
+				"GCPause_sum{host=\"a\"} 2.5\n" +
+				"GCPause_count{host=\"a\"} 2\n" +
+				"# HELP Latency summary metric Latency\n" +
+				"# TYPE Latency summary\n" +
+				"Latency{quantile=\"0.5\"} 4\n" +
+				"Latency_sum 4\n" +
+				"Latency_count 1\n",
+		},
+		{
+			name: "metrics without values are skipped",
+			mockSetup: func() {
+				mockService.EXPECT().List(gomock.Any()).Return([]*domain.Metric{
+					{MetricID: domain.MetricID{ID: "metric", Type: domain.Counter}},
+				}, nil)
+			},
+			expectedText: "",
+		},
+		{
+			name: "error - service failure",
+			mockSetup: func() {
+				mockService.EXPECT().List(gomock.Any()).Return(nil, errors.New("service error"))
+			},
+			expectedErr: errors.New("service error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockSetup()
+
+			resp, err := usecase.Execute(context.Background())
+
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Nil(t, resp)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedText, resp.Text)
+			}
+		})
+	}
+}
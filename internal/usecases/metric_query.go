@@ -0,0 +1,141 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/validation"
+	"strconv"
+	"strings"
+)
+
+const (
+	DefaultMetricQueryLimit = 100
+	MaxMetricQueryLimit     = 1000
+)
+
+type MetricQueryService interface {
+	Query(ctx context.Context, query *domain.MetricQuery) (*domain.MetricPage, error)
+}
+
+type MetricQueryUsecase struct {
+	svc MetricQueryService
+}
+
+func NewMetricQueryUsecase(svc MetricQueryService) *MetricQueryUsecase {
+	return &MetricQueryUsecase{svc: svc}
+}
+
+func (uc *MetricQueryUsecase) Execute(
+	ctx context.Context,
+	req *MetricQueryRequest,
+) (*MetricQueryResponse, error) {
+	query, err := ConvertMetricQueryRequestToDomain(req)
+	if err != nil {
+		return nil, err
+	}
+	page, err := uc.svc.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return NewMetricQueryResponse(page), nil
+}
+
+type MetricQueryRequest struct {
+	Type   string
+	Prefix string
+	Regex  string
+	Labels []string
+	Sort   string
+	Limit  string
+	Cursor string
+}
+
+func ConvertMetricQueryRequestToDomain(req *MetricQueryRequest) (*domain.MetricQuery, error) {
+	query := &domain.MetricQuery{
+		Prefix: req.Prefix,
+		SortBy: domain.MetricSortByID,
+		Limit:  DefaultMetricQueryLimit,
+	}
+	if req.Type != "" {
+		if err := validation.ValidateMetricType(req.Type); err != nil {
+			return nil, err
+		}
+		query.Type = domain.MetricType(req.Type)
+	}
+	if req.Regex != "" {
+		re, err := domain.CompileAnchoredRegexp(req.Regex)
+		if err != nil {
+			return nil, errors.ErrInvalidMetricQuery
+		}
+		query.Regexp = re
+	}
+	for _, label := range req.Labels {
+		matcher, err := domain.ParseLabelMatcher(label)
+		if err != nil {
+			return nil, errors.ErrInvalidMetricQuery
+		}
+		query.Labels = append(query.Labels, matcher)
+	}
+	if req.Sort != "" {
+		query.Desc = strings.HasPrefix(req.Sort, "-")
+		switch field := domain.MetricSortField(strings.TrimPrefix(req.Sort, "-")); field {
+		case domain.MetricSortByID, domain.MetricSortByType:
+			query.SortBy = field
+		default:
+			return nil, errors.ErrInvalidMetricQuery
+		}
+	}
+	if req.Limit != "" {
+		limit, err := strconv.Atoi(req.Limit)
+		if err != nil || limit <= 0 || limit > MaxMetricQueryLimit {
+			return nil, errors.ErrInvalidMetricQuery
+		}
+		query.Limit = limit
+	}
+	if req.Cursor != "" {
+		after, err := DecodeMetricCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		query.After = after
+	}
+	return query, nil
+}
+
+func EncodeMetricCursor(id *domain.MetricID) string {
+	data, _ := json.Marshal(id)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeMetricCursor(cursor string) (*domain.MetricID, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.ErrInvalidMetricCursor
+	}
+	var id domain.MetricID
+	if err := json.Unmarshal(data, &id); err != nil || id.ID == "" {
+		return nil, errors.ErrInvalidMetricCursor
+	}
+	return &id, nil
+}
+
+type MetricQueryResponse struct {
+	Metrics    []*MetricGetByIDBodyResponse `json:"metrics"`
+	NextCursor string                       `json:"next_cursor,omitempty"`
+}
+
+func NewMetricQueryResponse(page *domain.MetricPage) *MetricQueryResponse {
+	resp := &MetricQueryResponse{
+		Metrics: make([]*MetricGetByIDBodyResponse, 0, len(page.Metrics)),
+	}
+	for _, metric := range page.Metrics {
+		resp.Metrics = append(resp.Metrics, NewMetricGetByIDResponse(metric))
+	}
+	if page.Next != nil {
+		resp.NextCursor = EncodeMetricCursor(page.Next)
+	}
+	return resp
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecases/metric_query.go
+
+// Package usecases is a generated GoMock package.
+package usecases
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricQueryService is a mock of MetricQueryService interface.
+type MockMetricQueryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricQueryServiceMockRecorder
+}
+
+// MockMetricQueryServiceMockRecorder is the mock recorder for MockMetricQueryService.
+type MockMetricQueryServiceMockRecorder struct {
+	mock *MockMetricQueryService
+}
+
+// NewMockMetricQueryService creates a new mock instance.
+func NewMockMetricQueryService(ctrl *gomock.Controller) *MockMetricQueryService {
+	mock := &MockMetricQueryService{ctrl: ctrl}
+	mock.recorder = &MockMetricQueryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricQueryService) EXPECT() *MockMetricQueryServiceMockRecorder {
+	return m.recorder
+}
+
+// Query mocks base method.
+func (m *MockMetricQueryService) Query(ctx context.Context, query *domain.MetricQuery) (*domain.MetricPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", ctx, query)
+	ret0, _ := ret[0].(*domain.MetricPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockMetricQueryServiceMockRecorder) Query(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockMetricQueryService)(nil).Query), ctx, query)
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertMetricQueryRequestToDomain(t *testing.T) {
+	cursor := EncodeMetricCursor(&domain.MetricID{
+		ID: "CPU", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "h1"}),
+	})
+	query, err := ConvertMetricQueryRequestToDomain(&MetricQueryRequest{
+		Type:   "gauge",
+		Prefix: "CP",
+		Regex:  "CPU.*",
+		Labels: []string{"host=h1", "dc!~eu-.*"},
+		Sort:   "-type",
+		Limit:  "5",
+		Cursor: cursor,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, domain.Gauge, query.Type)
+	assert.Equal(t, "CP", query.Prefix)
+	assert.Equal(t, "^(?:CPU.*)$", query.Regexp.String())
+	require.Len(t, query.Labels, 2)
+	assert.Equal(t, domain.LabelMatchNotRegexp, query.Labels[1].Operator)
+	assert.Equal(t, domain.MetricSortByType, query.SortBy)
+	assert.True(t, query.Desc)
+	assert.Equal(t, 5, query.Limit)
+	assert.Equal(t, &domain.MetricID{
+		ID: "CPU", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{"host": "h1"}),
+	}, query.After)
+}
+
+func TestConvertMetricQueryRequestToDomain_Defaults(t *testing.T) {
+	query, err := ConvertMetricQueryRequestToDomain(&MetricQueryRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, &domain.MetricQuery{SortBy: domain.MetricSortByID, Limit: DefaultMetricQueryLimit}, query)
+}
+
+func TestConvertMetricQueryRequestToDomain_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *MetricQueryRequest
+		err  error
+	}{
+		{name: "type", req: &MetricQueryRequest{Type: "unknown"}, err: errors.ErrInvalidMetricType},
+		{name: "regex", req: &MetricQueryRequest{Regex: "("}, err: errors.ErrInvalidMetricQuery},
+		{name: "label", req: &MetricQueryRequest{Labels: []string{"host"}}, err: errors.ErrInvalidMetricQuery},
+		{name: "sort", req: &MetricQueryRequest{Sort: "value"}, err: errors.ErrInvalidMetricQuery},
+		{name: "limit", req: &MetricQueryRequest{Limit: "0"}, err: errors.ErrInvalidMetricQuery},
+		{name: "limit too large", req: &MetricQueryRequest{Limit: "1001"}, err: errors.ErrInvalidMetricQuery},
+		{name: "cursor", req: &MetricQueryRequest{Cursor: "!!"}, err: errors.ErrInvalidMetricCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := ConvertMetricQueryRequestToDomain(tt.req)
+			assert.Nil(t, query)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestMetricQueryUsecase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := NewMockMetricQueryService(ctrl)
+	usecase := NewMetricQueryUsecase(mockService)
+	next := &domain.MetricID{ID: "A", Type: domain.Gauge}
+	mockService.EXPECT().
+		Query(gomock.Any(), &domain.MetricQuery{Type: domain.Gauge, SortBy: domain.MetricSortByID, Limit: 1}).
+		Return(&domain.MetricPage{
+			Metrics: []*domain.Metric{{MetricID: *next, Value: ptrFloat64(1)}},
+			Next:    next,
+		}, nil)
+	resp, err := usecase.Execute(context.Background(), &MetricQueryRequest{Type: "gauge", Limit: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, []*MetricGetByIDBodyResponse{{ID: "A", Type: "gauge", Value: ptrFloat64(1)}}, resp.Metrics)
+	after, err := DecodeMetricCursor(resp.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, next, after)
+}
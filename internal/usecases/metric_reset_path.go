@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/validation"
+)
+
+type MetricResetPathService interface {
+	Reset(ctx context.Context, id *domain.MetricID) (*domain.Metric, error)
+}
+
+type MetricResetPathUsecase struct {
+	svc MetricResetPathService
+}
+
+func NewMetricResetPathUsecase(svc MetricResetPathService) *MetricResetPathUsecase {
+	return &MetricResetPathUsecase{svc: svc}
+}
+
+func (uc *MetricResetPathUsecase) Execute(
+	ctx context.Context,
+	req *MetricResetPathRequest,
+) (*MetricResetPathResponse, error) {
+	err := ValidateMetricResetPathRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	metricID, err := ConvertMetricResetPathRequestToDomain(req)
+	if err != nil {
+		return nil, err
+	}
+	_, err = uc.svc.Reset(ctx, metricID)
+	if err != nil {
+		return nil, err
+	}
+	return NewMetricResetPathResponse(), nil
+}
+
+type MetricResetPathRequest struct {
+	Type   string
+	Name   string
+	Labels []string
+}
+
+func ValidateMetricResetPathRequest(req *MetricResetPathRequest) error {
+	err := validation.ValidateMetricID(req.Name)
+	if err != nil {
+		return err
+	}
+	err = validation.ValidateMetricType(req.Type)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func ConvertMetricResetPathRequestToDomain(req *MetricResetPathRequest) (*domain.MetricID, error) {
+	labels, err := ConvertMetricHistoryLabelsToDomain(req.Labels)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.MetricID{
+		ID:     req.Name,
+		Type:   domain.MetricType(req.Type),
+		Labels: labels,
+	}, nil
+}
+
+type MetricResetPathResponse string
+
+func NewMetricResetPathResponse() *MetricResetPathResponse {
+	s := MetricResetPathResponse("Metric reset successfully")
+	return &s
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecases/metric_reset_path.go
+
+// Package usecases is a generated GoMock package.
+package usecases
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricResetPathService is a mock of MetricResetPathService interface.
+type MockMetricResetPathService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricResetPathServiceMockRecorder
+}
+
+// MockMetricResetPathServiceMockRecorder is the mock recorder for MockMetricResetPathService.
+type MockMetricResetPathServiceMockRecorder struct {
+	mock *MockMetricResetPathService
+}
+
+// NewMockMetricResetPathService creates a new mock instance.
+func NewMockMetricResetPathService(ctrl *gomock.Controller) *MockMetricResetPathService {
+	mock := &MockMetricResetPathService{ctrl: ctrl}
+	mock.recorder = &MockMetricResetPathServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricResetPathService) EXPECT() *MockMetricResetPathServiceMockRecorder {
+	return m.recorder
+}
+
+// Reset mocks base method.
+func (m *MockMetricResetPathService) Reset(ctx context.Context, id *domain.MetricID) (*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx, id)
+	ret0, _ := ret[0].(*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockMetricResetPathServiceMockRecorder) Reset(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockMetricResetPathService)(nil).Reset), ctx, id)
+}
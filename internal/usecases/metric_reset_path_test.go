@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricResetPathUsecase_Execute(t *testing.T) {
+	id := &domain.MetricID{ID: "test_counter", Type: domain.Counter}
+	tests := []struct {
+		name      string
+		req       *MetricResetPathRequest
+		mock      func(mockService *MockMetricResetPathService)
+		expectErr error
+	}{
+		{
+			name: "success",
+			req:  &MetricResetPathRequest{Type: "counter", Name: "test_counter"},
+			mock: func(mockService *MockMetricResetPathService) {
+				mockService.EXPECT().Reset(gomock.Any(), id).
+					Return(&domain.Metric{MetricID: *id, Delta: int64Ptr(0)}, nil)
+			},
+		},
+		{
+			name: "labelled",
+			req:  &MetricResetPathRequest{Type: "counter", Name: "test_counter", Labels: []string{"host=h1"}},
+			mock: func(mockService *MockMetricResetPathService) {
+				labelled := &domain.MetricID{
+					ID: "test_counter", Type: domain.Counter, Labels: domain.NewLabels(map[string]string{"host": "h1"}),
+				}
+				mockService.EXPECT().Reset(gomock.Any(), labelled).
+					Return(&domain.Metric{MetricID: *labelled, Delta: int64Ptr(0)}, nil)
+			},
+		},
+		{
+			name:      "invalid labels",
+			req:       &MetricResetPathRequest{Type: "counter", Name: "test_counter", Labels: []string{"host=~h.*"}},
+			mock:      func(mockService *MockMetricResetPathService) {},
+			expectErr: errors.ErrInvalidMetricLabels,
+		},
+		{
+			name: "not supported",
+			req:  &MetricResetPathRequest{Type: "gauge", Name: "test_counter"},
+			mock: func(mockService *MockMetricResetPathService) {
+				mockService.EXPECT().Reset(gomock.Any(), gomock.Any()).Return(nil, errors.ErrMetricResetNotSupported)
+			},
+			expectErr: errors.ErrMetricResetNotSupported,
+		},
+		{
+			name:      "invalid type",
+			req:       &MetricResetPathRequest{Type: "unknown", Name: "test_counter"},
+			mock:      func(mockService *MockMetricResetPathService) {},
+			expectErr: errors.ErrInvalidMetricType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockService := NewMockMetricResetPathService(ctrl)
+			tt.mock(mockService)
+			uc := NewMetricResetPathUsecase(mockService)
+			resp, err := uc.Execute(context.Background(), tt.req)
+			if tt.expectErr != nil {
+				assert.Nil(t, resp)
+				assert.Equal(t, tt.expectErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, NewMetricResetPathResponse(), resp)
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+)
+
+type MetricStreamService interface {
+	Subscribe(query *domain.MetricQuery) *domain.MetricSubscription
+	Unsubscribe(sub *domain.MetricSubscription)
+}
+
+type MetricStreamUsecase struct {
+	svc MetricStreamService
+}
+
+func NewMetricStreamUsecase(svc MetricStreamService) *MetricStreamUsecase {
+	return &MetricStreamUsecase{svc: svc}
+}
+
+type MetricStreamRequest struct {
+	Type   string
+	Prefix string
+	Regex  string
+	Labels []string
+}
+
+type MetricStream struct {
+	*domain.MetricSubscription
+	svc MetricStreamService
+}
+
+func (s *MetricStream) Close() {
+	s.svc.Unsubscribe(s.MetricSubscription)
+}
+
+func (uc *MetricStreamUsecase) Execute(ctx context.Context, req *MetricStreamRequest) (*MetricStream, error) {
+	query, err := ConvertMetricQueryRequestToDomain(&MetricQueryRequest{
+		Type:   req.Type,
+		Prefix: req.Prefix,
+		Regex:  req.Regex,
+		Labels: req.Labels,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &MetricStream{MetricSubscription: uc.svc.Subscribe(query), svc: uc.svc}, nil
+}
@@ -0,0 +1,61 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecases/metric_stream.go
+
+// Package usecases is a generated GoMock package.
+package usecases
+
+import (
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricStreamService is a mock of MetricStreamService interface.
+type MockMetricStreamService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricStreamServiceMockRecorder
+}
+
+// MockMetricStreamServiceMockRecorder is the mock recorder for MockMetricStreamService.
+type MockMetricStreamServiceMockRecorder struct {
+	mock *MockMetricStreamService
+}
+
+// NewMockMetricStreamService creates a new mock instance.
+func NewMockMetricStreamService(ctrl *gomock.Controller) *MockMetricStreamService {
+	mock := &MockMetricStreamService{ctrl: ctrl}
+	mock.recorder = &MockMetricStreamServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricStreamService) EXPECT() *MockMetricStreamServiceMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockMetricStreamService) Subscribe(query *domain.MetricQuery) *domain.MetricSubscription {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", query)
+	ret0, _ := ret[0].(*domain.MetricSubscription)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockMetricStreamServiceMockRecorder) Subscribe(query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockMetricStreamService)(nil).Subscribe), query)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockMetricStreamService) Unsubscribe(sub *domain.MetricSubscription) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Unsubscribe", sub)
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockMetricStreamServiceMockRecorder) Unsubscribe(sub interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockMetricStreamService)(nil).Unsubscribe), sub)
+}
@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricStreamUsecase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := NewMockMetricStreamService(ctrl)
+	uc := NewMetricStreamUsecase(mockService)
+
+	_, err := uc.Execute(context.Background(), &MetricStreamRequest{Type: "unknown"})
+	assert.Equal(t, errors.ErrInvalidMetricType, err)
+	_, err = uc.Execute(context.Background(), &MetricStreamRequest{Labels: []string{"no-operator"}})
+	assert.Equal(t, errors.ErrInvalidMetricQuery, err)
+
+	var subscribed *domain.MetricSubscription
+	mockService.EXPECT().Subscribe(gomock.Any()).DoAndReturn(func(query *domain.MetricQuery) *domain.MetricSubscription {
+		assert.Equal(t, domain.Counter, query.Type)
+		assert.Equal(t, "http_", query.Prefix)
+		require.Len(t, query.Labels, 1)
+		subscribed = domain.NewMetricSubscription(query, 1)
+		return subscribed
+	})
+	stream, err := uc.Execute(context.Background(), &MetricStreamRequest{
+		Type:   "counter",
+		Prefix: "http_",
+		Labels: []string{`host="a"`},
+	})
+	require.NoError(t, err)
+	assert.Same(t, subscribed, stream.MetricSubscription)
+
+	mockService.EXPECT().Unsubscribe(subscribed)
+	stream.Close()
+}
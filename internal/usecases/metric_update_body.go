@@ -35,10 +35,13 @@ func (uc *MetricUpdateBodyUsecase) Execute(
 }
 
 type MetricUpdateBodyRequest struct {
-	ID    string   `json:"id"`
-	Type  string   `json:"type"`
-	Delta *int64   `json:"delta,omitempty"`
-	Value *float64 `json:"value,omitempty"`
+	ID        string                 `json:"id"`
+	Type      string                 `json:"type"`
+	Labels    map[string]string      `json:"labels,omitempty"`
+	Delta     *int64                 `json:"delta,omitempty"`
+	Value     *float64               `json:"value,omitempty"`
+	Histogram *domain.HistogramValue `json:"histogram,omitempty"`
+	Summary   *domain.SummaryValue   `json:"summary,omitempty"`
 }
 
 func ValidateMetricUpdateBodyRequest(req *MetricUpdateBodyRequest) error {
@@ -50,16 +53,22 @@ func ValidateMetricUpdateBodyRequest(req *MetricUpdateBodyRequest) error {
 	if err != nil {
 		return err
 	}
-	if req.Type == string(domain.Counter) {
+	err = validation.ValidateMetricLabels(req.Labels)
+	if err != nil {
+		return err
+	}
+	switch domain.MetricType(req.Type) {
+	case domain.Counter:
 		err = validation.ValidateCounterPtrValue(req.Delta)
-		if err != nil {
-			return err
-		}
-	} else {
+	case domain.Histogram:
+		err = validation.ValidateHistogramPtrValue(req.Histogram)
+	case domain.Summary:
+		err = validation.ValidateSummaryPtrValue(req.Summary)
+	default:
 		err = validation.ValidateGaugePtrValue(req.Value)
-		if err != nil {
-			return err
-		}
+	}
+	if err != nil {
+		return err
 	}
 	return nil
 }
@@ -67,13 +76,19 @@ func ValidateMetricUpdateBodyRequest(req *MetricUpdateBodyRequest) error {
 func ConvertMetricUpdateBodyRequestToDomain(req *MetricUpdateBodyRequest) *domain.Metric {
 	m := domain.Metric{
 		MetricID: domain.MetricID{
-			ID:   req.ID,
-			Type: domain.MetricType(req.Type),
+			ID:     req.ID,
+			Type:   domain.MetricType(req.Type),
+			Labels: domain.NewLabels(req.Labels),
 		},
 	}
-	if req.Type == string(domain.Counter) {
+	switch domain.MetricType(req.Type) {
+	case domain.Counter:
 		m.Delta = req.Delta
-	} else {
+	case domain.Histogram:
+		m.Histogram = req.Histogram.Clone()
+	case domain.Summary:
+		m.Summary = req.Summary.Clone()
+	default:
 		m.Value = req.Value
 	}
 	return &m
@@ -82,10 +97,16 @@ func ConvertMetricUpdateBodyRequestToDomain(req *MetricUpdateBodyRequest) *domai
 type MetricUpdateBodyResponse MetricUpdateBodyRequest
 
 func NewMetricUpdateBodyResponse(metrics []*domain.Metric) *MetricUpdateBodyResponse {
-	return &MetricUpdateBodyResponse{
-		ID:    metrics[0].ID,
-		Type:  string(metrics[0].Type),
-		Delta: metrics[0].Delta,
-		Value: metrics[0].Value,
+	resp := &MetricUpdateBodyResponse{
+		ID:        metrics[0].ID,
+		Type:      string(metrics[0].Type),
+		Labels:    metrics[0].Labels.Map(),
+		Delta:     metrics[0].Delta,
+		Value:     metrics[0].Value,
+		Histogram: metrics[0].Histogram,
+	}
+	if metrics[0].Summary != nil {
+		resp.Summary = metrics[0].Summary.Report()
 	}
+	return resp
 }
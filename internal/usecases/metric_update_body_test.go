@@ -33,6 +33,36 @@ func TestMetricUpdateBodyUsecase_Execute(t *testing.T) {
 			expectErr:    nil,
 			expectedResp: &MetricUpdateBodyResponse{ID: "test_counter", Type: "counter", Delta: int64Ptr(123)},
 		},
+		{
+			name: "success case - gauge with labels",
+			req: &MetricUpdateBodyRequest{
+				ID:     "CPUutilization",
+				Type:   string(domain.Gauge),
+				Labels: map[string]string{"host": "h42"},
+				Value:  float64Ptr(12.5),
+			},
+			mock: func(mockService *MockMetricUpdateBodyService, req *MetricUpdateBodyRequest) {
+				metric := ConvertMetricUpdateBodyRequestToDomain(req)
+				assert.Equal(t, domain.NewLabels(map[string]string{"host": "h42"}), metric.Labels)
+				mockService.EXPECT().Update(gomock.Any(), []*domain.Metric{metric}).Return([]*domain.Metric{metric}, nil)
+			},
+			expectErr: nil,
+			expectedResp: &MetricUpdateBodyResponse{
+				ID: "CPUutilization", Type: "gauge", Labels: map[string]string{"host": "h42"}, Value: float64Ptr(12.5),
+			},
+		},
+		{
+			name: "validation error - invalid label name",
+			req: &MetricUpdateBodyRequest{
+				ID:     "CPUutilization",
+				Type:   string(domain.Gauge),
+				Labels: map[string]string{"host-name": "h42"},
+				Value:  float64Ptr(12.5),
+			},
+			mock:         nil,
+			expectErr:    errors.ErrInvalidMetricLabels,
+			expectedResp: nil,
+		},
 		{
 			name: "validation error - empty id",
 			req: &MetricUpdateBodyRequest{
@@ -55,6 +55,11 @@ func ValidateMetricUpdatePathRequest(req *MetricUpdatePathRequest) error {
 		if err != nil {
 			return err
 		}
+	} else if req.Type == string(domain.Histogram) || req.Type == string(domain.Summary) {
+		err = validation.ValidateGaugeValue(req.Value)
+		if err != nil {
+			return err
+		}
 	} else {
 		err = validation.ValidateGaugeValue(req.Value)
 		if err != nil {
@@ -74,6 +79,14 @@ func ConvertMetricUpdatePathRequestToDomain(req *MetricUpdatePathRequest) *domai
 	if req.Type == string(domain.Counter) {
 		v, _ := converters.ConvertToInt64(req.Value)
 		m.Delta = v
+	} else if req.Type == string(domain.Histogram) {
+		v, _ := converters.ConvertToFloat64(req.Value)
+		m.Histogram = domain.NewHistogram(domain.DefaultHistogramBuckets)
+		m.Histogram.Observe(*v)
+	} else if req.Type == string(domain.Summary) {
+		v, _ := converters.ConvertToFloat64(req.Value)
+		m.Summary = domain.NewSummary(nil)
+		m.Summary.Observe(*v)
 	} else {
 		v, _ := converters.ConvertToFloat64(req.Value)
 		m.Value = v
@@ -33,6 +33,33 @@ func TestMetricUpdatePathUsecase_Execute(t *testing.T) {
 			expectErr:    nil,
 			expectedResp: NewMetricUpdatePathResponse(),
 		},
+		{
+			name: "success case - histogram observation",
+			req: &MetricUpdatePathRequest{
+				Type:  string(domain.Histogram),
+				Name:  "test_histogram",
+				Value: "0.2",
+			},
+			mock: func(mockService *MockMetricUpdatePathService, req *MetricUpdatePathRequest) {
+				metric := ConvertMetricUpdatePathRequestToDomain(req)
+				assert.Equal(t, domain.DefaultHistogramBuckets, metric.Histogram.Buckets)
+				assert.Equal(t, int64(1), metric.Histogram.Count)
+				mockService.EXPECT().Update(gomock.Any(), []*domain.Metric{metric}).Return([]*domain.Metric{metric}, nil)
+			},
+			expectErr:    nil,
+			expectedResp: NewMetricUpdatePathResponse(),
+		},
+		{
+			name: "invalid histogram observation",
+			req: &MetricUpdatePathRequest{
+				Type:  string(domain.Summary),
+				Name:  "test_summary",
+				Value: "fast",
+			},
+			mock:         nil,
+			expectErr:    errors.ErrInvalidGaugeMetricValue,
+			expectedResp: nil,
+		},
 		{
 			name: "validation error - empty name",
 			req: &MetricUpdatePathRequest{
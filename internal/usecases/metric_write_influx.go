@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/parsers"
+)
+
+type MetricWriteInfluxService interface {
+	Update(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error)
+}
+
+type MetricWriteInfluxUsecase struct {
+	svc MetricWriteInfluxService
+}
+
+func NewMetricWriteInfluxUsecase(svc MetricWriteInfluxService) *MetricWriteInfluxUsecase {
+	return &MetricWriteInfluxUsecase{svc: svc}
+}
+
+func (uc *MetricWriteInfluxUsecase) Execute(
+	ctx context.Context,
+	req *MetricWriteInfluxRequest,
+) error {
+	precision, err := parsers.ParseInfluxPrecision(req.Precision)
+	if err != nil {
+		return errors.ErrInvalidLineProtocol
+	}
+	points, err := parsers.ParseInfluxLines(req.Body, precision)
+	if err != nil {
+		return errors.ErrInvalidLineProtocol
+	}
+	metrics := ConvertInfluxPointsToDomain(points)
+	if len(metrics) == 0 {
+		return nil
+	}
+	_, err = uc.svc.Update(ctx, metrics)
+	return err
+}
+
+type MetricWriteInfluxRequest struct {
+	Body      string
+	Precision string
+}
+
+func ConvertInfluxPointsToDomain(points []*parsers.InfluxPoint) []*domain.Metric {
+	var metrics []*domain.Metric
+	for _, point := range points {
+		labels := make(map[string]string, len(point.Tags))
+		for name, value := range point.Tags {
+			labels[parsers.SanitizeLabelName(name)] = value
+		}
+		for _, field := range point.Fields {
+			metricID := domain.MetricID{
+				ID:     parsers.SanitizeMetricID(point.Measurement + "_" + field.Key),
+				Labels: domain.NewLabels(labels),
+			}
+			var metric *domain.Metric
+			switch field.Type {
+			case parsers.InfluxInteger, parsers.InfluxUnsigned:
+				metricID.Type = domain.Counter
+				delta := field.Int
+				metric = &domain.Metric{MetricID: metricID, Delta: &delta}
+			case parsers.InfluxFloat:
+				metricID.Type = domain.Gauge
+				value := field.Float
+				metric = &domain.Metric{MetricID: metricID, Value: &value}
+			case parsers.InfluxBoolean:
+				metricID.Type = domain.Gauge
+				value := 0.0
+				if field.Bool {
+					value = 1
+				}
+				metric = &domain.Metric{MetricID: metricID, Value: &value}
+			default:
+				continue
+			}
+			metric.UpdatedAt = point.Timestamp
+			metrics = append(metrics, metric)
+		}
+	}
+	return metrics
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecases/metric_write_influx.go
+
+// Package usecases is a generated GoMock package.
+package usecases
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricWriteInfluxService is a mock of MetricWriteInfluxService interface.
+type MockMetricWriteInfluxService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricWriteInfluxServiceMockRecorder
+}
+
+// MockMetricWriteInfluxServiceMockRecorder is the mock recorder for MockMetricWriteInfluxService.
+type MockMetricWriteInfluxServiceMockRecorder struct {
+	mock *MockMetricWriteInfluxService
+}
+
+// NewMockMetricWriteInfluxService creates a new mock instance.
+func NewMockMetricWriteInfluxService(ctrl *gomock.Controller) *MockMetricWriteInfluxService {
+	mock := &MockMetricWriteInfluxService{ctrl: ctrl}
+	mock.recorder = &MockMetricWriteInfluxServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricWriteInfluxService) EXPECT() *MockMetricWriteInfluxServiceMockRecorder {
+	return m.recorder
+}
+
+// Update mocks base method.
+func (m *MockMetricWriteInfluxService) Update(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, metrics)
+	ret0, _ := ret[0].([]*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMetricWriteInfluxServiceMockRecorder) Update(ctx, metrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMetricWriteInfluxService)(nil).Update), ctx, metrics)
+}
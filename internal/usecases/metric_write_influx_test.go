@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricWriteInfluxUsecase_Execute(t *testing.T) {
+	labels := domain.NewLabels(map[string]string{"host": "h1", "host_name": "a"})
+	timestamp := time.UnixMilli(1700000000000)
+	tests := []struct {
+		name      string
+		req       *MetricWriteInfluxRequest
+		mock      func(mockService *MockMetricWriteInfluxService)
+		expectErr error
+	}{
+		{
+			name: "success",
+			req: &MetricWriteInfluxRequest{
+				Body:      "http.requests,host=h1,host.name=a count=3i,latency=0.25,up=true,note=\"x\" 1700000000000\n",
+				Precision: "ms",
+			},
+			mock: func(mockService *MockMetricWriteInfluxService) {
+				mockService.EXPECT().Update(gomock.Any(), []*domain.Metric{
+					{
+						MetricID:  domain.MetricID{ID: "http_requests_count", Type: domain.Counter, Labels: labels},
+						Delta:     int64Ptr(3),
+						UpdatedAt: timestamp,
+					},
+					{
+						MetricID:  domain.MetricID{ID: "http_requests_latency", Type: domain.Gauge, Labels: labels},
+						Value:     float64Ptr(0.25),
+						UpdatedAt: timestamp,
+					},
+					{
+						MetricID:  domain.MetricID{ID: "http_requests_up", Type: domain.Gauge, Labels: labels},
+						Value:     float64Ptr(1),
+						UpdatedAt: timestamp,
+					},
+				}).Return(nil, nil)
+			},
+		},
+		{
+			name: "only string fields",
+			req:  &MetricWriteInfluxRequest{Body: "events message=\"deployed\""},
+			mock: func(mockService *MockMetricWriteInfluxService) {},
+		},
+		{
+			name:      "invalid line",
+			req:       &MetricWriteInfluxRequest{Body: "cpu value"},
+			mock:      func(mockService *MockMetricWriteInfluxService) {},
+			expectErr: errors.ErrInvalidLineProtocol,
+		},
+		{
+			name:      "invalid precision",
+			req:       &MetricWriteInfluxRequest{Body: "cpu value=1", Precision: "weeks"},
+			mock:      func(mockService *MockMetricWriteInfluxService) {},
+			expectErr: errors.ErrInvalidLineProtocol,
+		},
+		{
+			name: "service error",
+			req:  &MetricWriteInfluxRequest{Body: "cpu value=1"},
+			mock: func(mockService *MockMetricWriteInfluxService) {
+				mockService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, errors.ErrMetricIsNotUpdated)
+			},
+			expectErr: errors.ErrMetricIsNotUpdated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockService := NewMockMetricWriteInfluxService(ctrl)
+			tt.mock(mockService)
+			uc := NewMetricWriteInfluxUsecase(mockService)
+			err := uc.Execute(context.Background(), tt.req)
+			assert.Equal(t, tt.expectErr, err)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/parsers"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+)
+
+const otlpRejectedMessage = "only gauge and sum metrics with valid names and values are supported"
+
+type MetricWriteOTLPService interface {
+	Update(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error)
+}
+
+type MetricWriteOTLPUsecase struct {
+	svc     MetricWriteOTLPService
+	tracker *OTLPSumTracker
+	mu      sync.Mutex
+}
+
+func NewMetricWriteOTLPUsecase(svc MetricWriteOTLPService) *MetricWriteOTLPUsecase {
+	return &MetricWriteOTLPUsecase{
+		svc:     svc,
+		tracker: NewOTLPSumTracker(time.Now()),
+	}
+}
+
+func (uc *MetricWriteOTLPUsecase) Execute(
+	ctx context.Context,
+	req *colmetricspb.ExportMetricsServiceRequest,
+) (*colmetricspb.ExportMetricsServiceResponse, error) {
+	uc.mu.Lock()
+	batch := uc.tracker.Begin()
+	metrics, rejected := ConvertOTLPRequestToDomain(req, batch)
+	batch.Reserve(time.Now())
+	uc.mu.Unlock()
+	if len(metrics) > 0 {
+		if _, err := uc.svc.Update(ctx, metrics); err != nil {
+			batch.Rollback()
+			return nil, err
+		}
+	}
+	batch.Commit(time.Now())
+	resp := &colmetricspb.ExportMetricsServiceResponse{}
+	if rejected > 0 {
+		resp.PartialSuccess = &colmetricspb.ExportMetricsPartialSuccess{
+			RejectedDataPoints: rejected,
+			ErrorMessage:       otlpRejectedMessage,
+		}
+	}
+	return resp, nil
+}
+
+func ConvertOTLPRequestToDomain(
+	req *colmetricspb.ExportMetricsServiceRequest,
+	batch *OTLPSumBatch,
+) ([]*domain.Metric, int64) {
+	var metrics []*domain.Metric
+	var rejected int64
+	for _, rm := range req.GetResourceMetrics() {
+		resourceLabels := otlpAttributesToLabels(nil, rm.GetResource().GetAttributes())
+		for _, sm := range rm.GetScopeMetrics() {
+			for _, m := range sm.GetMetrics() {
+				name := parsers.SanitizeMetricID(m.GetName())
+				switch data := m.GetData().(type) {
+				case *metricspb.Metric_Gauge:
+					for _, dp := range data.Gauge.GetDataPoints() {
+						value, ok := otlpNumberValue(dp)
+						if name == "" || !ok {
+							rejected++
+							continue
+						}
+						metrics = append(metrics, &domain.Metric{
+							MetricID:  otlpMetricID(name, domain.Gauge, resourceLabels, dp),
+							Value:     &value,
+							UpdatedAt: otlpTimestamp(dp),
+						})
+					}
+				case *metricspb.Metric_Sum:
+					temporality := data.Sum.GetAggregationTemporality()
+					for _, dp := range data.Sum.GetDataPoints() {
+						value, ok := otlpNumberValue(dp)
+						if name == "" || !ok || temporality == metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED {
+							rejected++
+							continue
+						}
+						cumulative := temporality == metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+						if cumulative && !data.Sum.GetIsMonotonic() {
+							metrics = append(metrics, &domain.Metric{
+								MetricID:  otlpMetricID(name, domain.Gauge, resourceLabels, dp),
+								Value:     &value,
+								UpdatedAt: otlpTimestamp(dp),
+							})
+							continue
+						}
+						id := otlpMetricID(name, domain.Counter, resourceLabels, dp)
+						var delta int64
+						if cumulative {
+							delta = batch.Cumulative(id, dp.GetStartTimeUnixNano(), value)
+						} else {
+							delta = batch.Delta(id, value)
+						}
+						metrics = append(metrics, &domain.Metric{MetricID: id, Delta: &delta, UpdatedAt: otlpTimestamp(dp)})
+					}
+				default:
+					rejected += otlpDataPointCount(m)
+				}
+			}
+		}
+	}
+	return metrics, rejected
+}
+
+func otlpMetricID(
+	name string, metricType domain.MetricType, resourceLabels map[string]string, dp *metricspb.NumberDataPoint,
+) domain.MetricID {
+	return domain.MetricID{
+		ID:     name,
+		Type:   metricType,
+		Labels: domain.NewLabels(otlpAttributesToLabels(resourceLabels, dp.GetAttributes())),
+	}
+}
+
+func otlpTimestamp(dp *metricspb.NumberDataPoint) time.Time {
+	if dp.GetTimeUnixNano() == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(dp.GetTimeUnixNano()))
+}
+
+func otlpNumberValue(dp *metricspb.NumberDataPoint) (float64, bool) {
+	switch value := dp.GetValue().(type) {
+	case *metricspb.NumberDataPoint_AsInt:
+		return float64(value.AsInt), true
+	case *metricspb.NumberDataPoint_AsDouble:
+		if math.IsNaN(value.AsDouble) || math.IsInf(value.AsDouble, 0) {
+			return 0, false
+		}
+		return value.AsDouble, true
+	default:
+		return 0, false
+	}
+}
+
+func otlpAttributesToLabels(base map[string]string, attributes []*commonpb.KeyValue) map[string]string {
+	labels := make(map[string]string, len(base)+len(attributes))
+	for name, value := range base {
+		labels[name] = value
+	}
+	for _, attribute := range attributes {
+		name := parsers.SanitizeLabelName(attribute.GetKey())
+		if name == "" {
+			continue
+		}
+		switch value := attribute.GetValue().GetValue().(type) {
+		case *commonpb.AnyValue_StringValue:
+			labels[name] = value.StringValue
+		case *commonpb.AnyValue_IntValue:
+			labels[name] = strconv.FormatInt(value.IntValue, 10)
+		case *commonpb.AnyValue_DoubleValue:
+			labels[name] = strconv.FormatFloat(value.DoubleValue, 'f', -1, 64)
+		case *commonpb.AnyValue_BoolValue:
+			labels[name] = strconv.FormatBool(value.BoolValue)
+		}
+	}
+	return labels
+}
+
+func otlpDataPointCount(m *metricspb.Metric) int64 {
+	switch data := m.GetData().(type) {
+	case *metricspb.Metric_Histogram:
+		return int64(len(data.Histogram.GetDataPoints()))
+	case *metricspb.Metric_ExponentialHistogram:
+		return int64(len(data.ExponentialHistogram.GetDataPoints()))
+	case *metricspb.Metric_Summary:
+		return int64(len(data.Summary.GetDataPoints()))
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecases/metric_write_otlp.go
+
+// Package usecases is a generated GoMock package.
+package usecases
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricWriteOTLPService is a mock of MetricWriteOTLPService interface.
+type MockMetricWriteOTLPService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricWriteOTLPServiceMockRecorder
+}
+
+// MockMetricWriteOTLPServiceMockRecorder is the mock recorder for MockMetricWriteOTLPService.
+type MockMetricWriteOTLPServiceMockRecorder struct {
+	mock *MockMetricWriteOTLPService
+}
+
+// NewMockMetricWriteOTLPService creates a new mock instance.
+func NewMockMetricWriteOTLPService(ctrl *gomock.Controller) *MockMetricWriteOTLPService {
+	mock := &MockMetricWriteOTLPService{ctrl: ctrl}
+	mock.recorder = &MockMetricWriteOTLPServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricWriteOTLPService) EXPECT() *MockMetricWriteOTLPServiceMockRecorder {
+	return m.recorder
+}
+
+// Update mocks base method.
+func (m *MockMetricWriteOTLPService) Update(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, metrics)
+	ret0, _ := ret[0].([]*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMetricWriteOTLPServiceMockRecorder) Update(ctx, metrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMetricWriteOTLPService)(nil).Update), ctx, metrics)
+}
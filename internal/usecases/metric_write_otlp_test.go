@@ -0,0 +1,324 @@
+package usecases
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+func otlpStringAttribute(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{Key: key, Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}}}
+}
+
+func otlpRequest(metrics ...*metricspb.Metric) *colmetricspb.ExportMetricsServiceRequest {
+	return &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{{
+			Resource: &resourcepb.Resource{Attributes: []*commonpb.KeyValue{
+				otlpStringAttribute("service.name", "api"),
+				otlpStringAttribute("host", "r1"),
+			}},
+			ScopeMetrics: []*metricspb.ScopeMetrics{{Metrics: metrics}},
+		}},
+	}
+}
+
+func otlpSum(name string, temporality metricspb.AggregationTemporality, start uint64, value int64) *metricspb.Metric {
+	return &metricspb.Metric{
+		Name: name,
+		Data: &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+			AggregationTemporality: temporality,
+			IsMonotonic:            true,
+			DataPoints: []*metricspb.NumberDataPoint{{
+				StartTimeUnixNano: start,
+				Value:             &metricspb.NumberDataPoint_AsInt{AsInt: value},
+			}},
+		}},
+	}
+}
+
+func TestMetricWriteOTLPUsecase_Execute(t *testing.T) {
+	labels := domain.NewLabels(map[string]string{"service_name": "api", "host": "h1", "enabled": "true"})
+	gauge := &metricspb.Metric{
+		Name: "process.cpu",
+		Data: &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{DataPoints: []*metricspb.NumberDataPoint{
+			{
+				Attributes: []*commonpb.KeyValue{
+					otlpStringAttribute("host", "h1"),
+					{Key: "enabled", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: true}}},
+				},
+				Value: &metricspb.NumberDataPoint_AsDouble{AsDouble: 0.5},
+			},
+			{Value: &metricspb.NumberDataPoint_AsDouble{AsDouble: math.NaN()}},
+		}}},
+	}
+	histogram := &metricspb.Metric{
+		Name: "latency",
+		Data: &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+			DataPoints: []*metricspb.HistogramDataPoint{{Count: 1}, {Count: 2}},
+		}},
+	}
+	delta := otlpSum("requests", metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA, 0, 4)
+	delta.GetSum().DataPoints[0].Attributes = []*commonpb.KeyValue{otlpStringAttribute("host", "h1")}
+
+	tests := []struct {
+		name       string
+		req        *colmetricspb.ExportMetricsServiceRequest
+		mock       func(mockService *MockMetricWriteOTLPService)
+		expectResp *colmetricspb.ExportMetricsServiceResponse
+		expectErr  error
+	}{
+		{
+			name: "gauge and delta sum",
+			req:  otlpRequest(gauge, delta),
+			mock: func(mockService *MockMetricWriteOTLPService) {
+				mockService.EXPECT().Update(gomock.Any(), []*domain.Metric{
+					{
+						MetricID: domain.MetricID{ID: "process_cpu", Type: domain.Gauge, Labels: labels},
+						Value:    float64Ptr(0.5),
+					},
+					{
+						MetricID: domain.MetricID{
+							ID:     "requests",
+							Type:   domain.Counter,
+							Labels: domain.NewLabels(map[string]string{"service_name": "api", "host": "h1"}),
+						},
+						Delta: int64Ptr(4),
+					},
+				}).Return(nil, nil)
+			},
+			expectResp: &colmetricspb.ExportMetricsServiceResponse{
+				PartialSuccess: &colmetricspb.ExportMetricsPartialSuccess{
+					RejectedDataPoints: 1,
+					ErrorMessage:       otlpRejectedMessage,
+				},
+			},
+		},
+		{
+			name: "unsupported types only",
+			req: otlpRequest(
+				histogram,
+				otlpSum("requests", metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_UNSPECIFIED, 0, 1),
+			),
+			mock: func(mockService *MockMetricWriteOTLPService) {},
+			expectResp: &colmetricspb.ExportMetricsServiceResponse{
+				PartialSuccess: &colmetricspb.ExportMetricsPartialSuccess{
+					RejectedDataPoints: 3,
+					ErrorMessage:       otlpRejectedMessage,
+				},
+			},
+		},
+		{
+			name:       "empty request",
+			req:        &colmetricspb.ExportMetricsServiceRequest{},
+			mock:       func(mockService *MockMetricWriteOTLPService) {},
+			expectResp: &colmetricspb.ExportMetricsServiceResponse{},
+		},
+		{
+			name: "service error",
+			req:  otlpRequest(delta),
+			mock: func(mockService *MockMetricWriteOTLPService) {
+				mockService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, errors.ErrMetricIsNotUpdated)
+			},
+			expectErr: errors.ErrMetricIsNotUpdated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockService := NewMockMetricWriteOTLPService(ctrl)
+			tt.mock(mockService)
+			uc := NewMetricWriteOTLPUsecase(mockService)
+			resp, err := uc.Execute(context.Background(), tt.req)
+			assert.Equal(t, tt.expectErr, err)
+			if tt.expectErr == nil {
+				assert.Equal(t, tt.expectResp.GetPartialSuccess().GetRejectedDataPoints(), resp.GetPartialSuccess().GetRejectedDataPoints())
+				assert.Equal(t, tt.expectResp.GetPartialSuccess().GetErrorMessage(), resp.GetPartialSuccess().GetErrorMessage())
+			}
+		})
+	}
+}
+
+func TestConvertOTLPRequestToDomain_Cumulative(t *testing.T) {
+	started := time.Unix(1000, 0)
+	cumulative := metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+	before := uint64(started.Add(-time.Minute).UnixNano())
+	after := uint64(started.Add(time.Minute).UnixNano())
+	tracker := NewOTLPSumTracker(started)
+	deltas := func(req *colmetricspb.ExportMetricsServiceRequest) []int64 {
+		batch := tracker.Begin()
+		metrics, rejected := ConvertOTLPRequestToDomain(req, batch)
+		require.Zero(t, rejected)
+		batch.Reserve(started)
+		batch.Commit(started)
+		result := make([]int64, 0, len(metrics))
+		for _, metric := range metrics {
+			result = append(result, *metric.Delta)
+		}
+		return result
+	}
+
+	assert.Equal(t, []int64{0, 5}, deltas(otlpRequest(
+		otlpSum("old", cumulative, before, 100),
+		otlpSum("new", cumulative, after, 5),
+	)))
+	assert.Equal(t, []int64{20, 3}, deltas(otlpRequest(
+		otlpSum("old", cumulative, before, 120),
+		otlpSum("new", cumulative, after, 8),
+	)))
+	assert.Equal(t, []int64{7, 2}, deltas(otlpRequest(
+		otlpSum("old", cumulative, after, 7),
+		otlpSum("new", cumulative, after, 2),
+	)))
+}
+
+func otlpDoubleSum(name string, temporality metricspb.AggregationTemporality, start uint64, value float64) *metricspb.Metric {
+	metric := otlpSum(name, temporality, start, 0)
+	metric.GetSum().DataPoints[0].Value = &metricspb.NumberDataPoint_AsDouble{AsDouble: value}
+	return metric
+}
+
+func TestConvertOTLPRequestToDomain_DeltaRemainder(t *testing.T) {
+	tracker := NewOTLPSumTracker(time.Unix(1000, 0))
+	temporality := metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA
+	var total int64
+	for i := 0; i < 5; i++ {
+		batch := tracker.Begin()
+		metrics, _ := ConvertOTLPRequestToDomain(otlpRequest(otlpDoubleSum("bytes", temporality, 0, 0.4)), batch)
+		batch.Reserve(time.Unix(1000, 0))
+		batch.Commit(time.Unix(1000, 0))
+		total += *metrics[0].Delta
+	}
+	assert.Equal(t, int64(2), total)
+}
+
+func TestOTLPSumTracker_EvictsIdleSeries(t *testing.T) {
+	started := time.Unix(1000, 0)
+	tracker := NewOTLPSumTracker(started)
+	cumulative := metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+	start := uint64(started.Add(time.Minute).UnixNano())
+	batch := tracker.Begin()
+	ConvertOTLPRequestToDomain(otlpRequest(otlpSum("old", cumulative, start, 5)), batch)
+	batch.Reserve(started.Add(time.Minute))
+	batch.Commit(started.Add(time.Minute))
+
+	tracker.Begin().Commit(started.Add(2 * OTLPSeriesTTL))
+	assert.Empty(t, tracker.series)
+
+	metrics, _ := ConvertOTLPRequestToDomain(otlpRequest(otlpSum("old", cumulative, start, 9)), tracker.Begin())
+	assert.Equal(t, int64(0), *metrics[0].Delta)
+}
+
+func TestMetricWriteOTLPUsecase_Execute_RollsBackTrackerOnFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := NewMockMetricWriteOTLPService(ctrl)
+	uc := NewMetricWriteOTLPUsecase(mockService)
+	start := uint64(time.Now().Add(time.Minute).UnixNano())
+	req := otlpRequest(otlpSum("requests", metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, start, 5))
+
+	mockService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, errors.ErrMetricIsNotUpdated)
+	_, err := uc.Execute(context.Background(), req)
+	require.Equal(t, errors.ErrMetricIsNotUpdated, err)
+
+	mockService.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+			require.Len(t, metrics, 1)
+			assert.Equal(t, int64(5), *metrics[0].Delta)
+			return metrics, nil
+		},
+	)
+	_, err = uc.Execute(context.Background(), req)
+	require.NoError(t, err)
+}
+
+func TestMetricWriteOTLPUsecase_Execute_DeltaRetryAfterFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := NewMockMetricWriteOTLPService(ctrl)
+	uc := NewMetricWriteOTLPUsecase(mockService)
+	req := otlpRequest(otlpDoubleSum("bytes", metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA, 0, 4.5))
+
+	mockService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, errors.ErrMetricIsNotUpdated)
+	_, err := uc.Execute(context.Background(), req)
+	require.Equal(t, errors.ErrMetricIsNotUpdated, err)
+
+	var total int64
+	mockService.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+			total += *metrics[0].Delta
+			return metrics, nil
+		},
+	).Times(2)
+	_, err = uc.Execute(context.Background(), req)
+	require.NoError(t, err)
+	_, err = uc.Execute(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), total)
+}
+
+func TestMetricWriteOTLPUsecase_Execute_DoesNotHoldLockDuringUpdate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := NewMockMetricWriteOTLPService(ctrl)
+	uc := NewMetricWriteOTLPUsecase(mockService)
+	cumulative := metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE
+	start := uint64(time.Now().Add(time.Minute).UnixNano())
+
+	blocked := make(chan struct{})
+	release := make(chan struct{})
+	deltas := make(chan int64, 2)
+	mockService.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+			deltas <- *metrics[0].Delta
+			close(blocked)
+			<-release
+			return metrics, nil
+		},
+	)
+	mockService.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+			deltas <- *metrics[0].Delta
+			return metrics, nil
+		},
+	)
+	done := make(chan error, 1)
+	go func() {
+		_, err := uc.Execute(context.Background(), otlpRequest(otlpSum("requests", cumulative, start, 5)))
+		done <- err
+	}()
+	<-blocked
+	_, err := uc.Execute(context.Background(), otlpRequest(otlpSum("requests", cumulative, start, 8)))
+	require.NoError(t, err)
+	close(release)
+	require.NoError(t, <-done)
+	assert.Equal(t, int64(5), <-deltas)
+	assert.Equal(t, int64(3), <-deltas)
+}
+
+func TestConvertOTLPRequestToDomain_NonMonotonicCumulativeSumIsGauge(t *testing.T) {
+	sum := otlpSum("queue.depth", metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, 1, 3)
+	sum.GetSum().IsMonotonic = false
+	metrics, rejected := ConvertOTLPRequestToDomain(otlpRequest(sum), NewOTLPSumTracker(time.Unix(0, 0)).Begin())
+	require.Zero(t, rejected)
+	require.Len(t, metrics, 1)
+	assert.Equal(t, domain.MetricID{
+		ID:     "queue_depth",
+		Type:   domain.Gauge,
+		Labels: domain.NewLabels(map[string]string{"service_name": "api", "host": "r1"}),
+	}, metrics[0].MetricID)
+	assert.Equal(t, 3.0, *metrics[0].Value)
+	assert.Nil(t, metrics[0].Delta)
+}
@@ -0,0 +1,153 @@
+package usecases
+
+import (
+	"go-metrics/internal/domain"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	OTLPSeriesTTL       = time.Hour
+	otlpSeriesSweepTime = time.Minute
+)
+
+type otlpSumSeries struct {
+	start uint64
+	value float64
+	carry float64
+	seen  time.Time
+}
+
+type OTLPSumTracker struct {
+	started uint64
+	swept   time.Time
+	series  map[domain.MetricID]*otlpSumSeries
+	mu      sync.Mutex
+}
+
+func NewOTLPSumTracker(started time.Time) *OTLPSumTracker {
+	return &OTLPSumTracker{
+		started: uint64(started.UnixNano()),
+		swept:   started,
+		series:  make(map[domain.MetricID]*otlpSumSeries),
+	}
+}
+
+func (t *OTLPSumTracker) Begin() *OTLPSumBatch {
+	return &OTLPSumBatch{
+		tracker: t,
+		pending: make(map[domain.MetricID]*otlpSumSeries),
+		undo:    make(map[domain.MetricID]float64),
+	}
+}
+
+func (t *OTLPSumTracker) lookup(id domain.MetricID) (*otlpSumSeries, uint64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	series, exists := t.series[id]
+	return series, t.started, exists
+}
+
+func (t *OTLPSumTracker) reserve(pending map[domain.MetricID]*otlpSumSeries, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, series := range pending {
+		series.seen = now
+		t.series[id] = series
+	}
+}
+
+func (t *OTLPSumTracker) rollback(undo map[domain.MetricID]float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, carry := range undo {
+		if series, exists := t.series[id]; exists {
+			series.carry += carry
+		}
+	}
+}
+
+func (t *OTLPSumTracker) commit(pending map[domain.MetricID]*otlpSumSeries, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id := range pending {
+		if series, exists := t.series[id]; exists {
+			series.seen = now
+		}
+	}
+	if now.Sub(t.swept) < otlpSeriesSweepTime {
+		return
+	}
+	t.swept = now
+	cutoff := now.Add(-OTLPSeriesTTL)
+	for id, series := range t.series {
+		if series.seen.Before(cutoff) {
+			delete(t.series, id)
+		}
+	}
+	if horizon := uint64(cutoff.UnixNano()); horizon > t.started {
+		t.started = horizon
+	}
+}
+
+type OTLPSumBatch struct {
+	tracker *OTLPSumTracker
+	pending map[domain.MetricID]*otlpSumSeries
+	undo    map[domain.MetricID]float64
+}
+
+func (b *OTLPSumBatch) Cumulative(id domain.MetricID, start uint64, value float64) int64 {
+	prev, started, exists := b.lookup(id)
+	var increase float64
+	switch {
+	case !exists:
+		if start != 0 && start >= started {
+			increase = value
+		}
+	case start != prev.start || value < prev.value:
+		increase = value + prev.carry
+	default:
+		increase = value - prev.value + prev.carry
+	}
+	delta := b.emit(id, &otlpSumSeries{start: start, value: value}, increase)
+	b.undo[id] += float64(delta)
+	return delta
+}
+
+func (b *OTLPSumBatch) Delta(id domain.MetricID, value float64) int64 {
+	var carry float64
+	if prev, _, exists := b.lookup(id); exists {
+		carry = prev.carry
+	}
+	delta := b.emit(id, &otlpSumSeries{}, value+carry)
+	b.undo[id] += float64(delta) - value
+	return delta
+}
+
+func (b *OTLPSumBatch) Reserve(now time.Time) {
+	b.tracker.reserve(b.pending, now)
+}
+
+func (b *OTLPSumBatch) Commit(now time.Time) {
+	b.tracker.commit(b.pending, now)
+}
+
+func (b *OTLPSumBatch) Rollback() {
+	b.tracker.rollback(b.undo)
+}
+
+func (b *OTLPSumBatch) lookup(id domain.MetricID) (*otlpSumSeries, uint64, bool) {
+	series, started, exists := b.tracker.lookup(id)
+	if pending, ok := b.pending[id]; ok {
+		return pending, started, true
+	}
+	return series, started, exists
+}
+
+func (b *OTLPSumBatch) emit(id domain.MetricID, series *otlpSumSeries, increase float64) int64 {
+	whole := math.Trunc(increase)
+	series.carry = increase - whole
+	b.pending[id] = series
+	return int64(whole)
+}
@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/parsers"
+	"math"
+	"sort"
+	"time"
+)
+
+const remoteWriteNameLabel = "__name__"
+
+type MetricWriteRemoteService interface {
+	Update(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error)
+}
+
+type MetricWriteRemoteUsecase struct {
+	svc MetricWriteRemoteService
+}
+
+func NewMetricWriteRemoteUsecase(svc MetricWriteRemoteService) *MetricWriteRemoteUsecase {
+	return &MetricWriteRemoteUsecase{svc: svc}
+}
+
+type MetricWriteRemoteRequest struct {
+	Body []byte
+}
+
+func (uc *MetricWriteRemoteUsecase) Execute(ctx context.Context, req *MetricWriteRemoteRequest) error {
+	series, err := parsers.ParseRemoteWriteRequest(req.Body)
+	if err != nil {
+		return errors.ErrInvalidRemoteWrite
+	}
+	metrics := ConvertRemoteWriteSeriesToDomain(series)
+	if len(metrics) == 0 {
+		return nil
+	}
+	_, err = uc.svc.Update(ctx, metrics)
+	return err
+}
+
+func ConvertRemoteWriteSeriesToDomain(series []*parsers.RemoteWriteSeries) []*domain.Metric {
+	var metrics []*domain.Metric
+	for _, s := range series {
+		name := parsers.SanitizeMetricID(s.Labels[remoteWriteNameLabel])
+		if name == "" {
+			continue
+		}
+		labels := make(map[string]string, len(s.Labels))
+		for key, value := range s.Labels {
+			if key == remoteWriteNameLabel {
+				continue
+			}
+			if key = parsers.SanitizeLabelName(key); key != "" {
+				labels[key] = value
+			}
+		}
+		id := domain.MetricID{ID: name, Type: domain.Gauge, Labels: domain.NewLabels(labels)}
+		samples := append([]*parsers.RemoteWriteSample(nil), s.Samples...)
+		sort.SliceStable(samples, func(i, j int) bool {
+			return samples[i].Timestamp < samples[j].Timestamp
+		})
+		for i, sample := range samples {
+			if math.IsNaN(sample.Value) || math.IsInf(sample.Value, 0) {
+				continue
+			}
+			if i > 0 && sample.Timestamp == samples[i-1].Timestamp {
+				continue
+			}
+			value := sample.Value
+			metrics = append(metrics, &domain.Metric{
+				MetricID:  id,
+				Value:     &value,
+				UpdatedAt: time.UnixMilli(sample.Timestamp),
+			})
+		}
+	}
+	return metrics
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/usecases/metric_write_remote.go
+
+// Package usecases is a generated GoMock package.
+package usecases
+
+import (
+	context "context"
+	domain "go-metrics/internal/domain"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMetricWriteRemoteService is a mock of MetricWriteRemoteService interface.
+type MockMetricWriteRemoteService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetricWriteRemoteServiceMockRecorder
+}
+
+// MockMetricWriteRemoteServiceMockRecorder is the mock recorder for MockMetricWriteRemoteService.
+type MockMetricWriteRemoteServiceMockRecorder struct {
+	mock *MockMetricWriteRemoteService
+}
+
+// NewMockMetricWriteRemoteService creates a new mock instance.
+func NewMockMetricWriteRemoteService(ctrl *gomock.Controller) *MockMetricWriteRemoteService {
+	mock := &MockMetricWriteRemoteService{ctrl: ctrl}
+	mock.recorder = &MockMetricWriteRemoteServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetricWriteRemoteService) EXPECT() *MockMetricWriteRemoteServiceMockRecorder {
+	return m.recorder
+}
+
+// Update mocks base method.
+func (m *MockMetricWriteRemoteService) Update(ctx context.Context, metrics []*domain.Metric) ([]*domain.Metric, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, metrics)
+	ret0, _ := ret[0].([]*domain.Metric)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockMetricWriteRemoteServiceMockRecorder) Update(ctx, metrics interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockMetricWriteRemoteService)(nil).Update), ctx, metrics)
+}
@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"go-metrics/internal/domain"
+	"go-metrics/internal/errors"
+	"go-metrics/internal/parsers"
+
+	"github.com/golang/mock/gomock"
+	"github.com/klauspost/compress/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func remoteWriteBody(name string, value float64, timestamp int64) []byte {
+	var label, sample, series, req []byte
+	label = protowire.AppendTag(label, 1, protowire.BytesType)
+	label = protowire.AppendString(label, "__name__")
+	label = protowire.AppendTag(label, 2, protowire.BytesType)
+	label = protowire.AppendString(label, name)
+	sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+	sample = protowire.AppendFixed64(sample, math.Float64bits(value))
+	sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+	sample = protowire.AppendVarint(sample, uint64(timestamp))
+	series = protowire.AppendTag(series, 1, protowire.BytesType)
+	series = protowire.AppendBytes(series, label)
+	series = protowire.AppendTag(series, 2, protowire.BytesType)
+	series = protowire.AppendBytes(series, sample)
+	req = protowire.AppendTag(req, 1, protowire.BytesType)
+	req = protowire.AppendBytes(req, series)
+	return snappy.Encode(nil, req)
+}
+
+func TestMetricWriteRemoteUsecase_Execute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockService := NewMockMetricWriteRemoteService(ctrl)
+	uc := NewMetricWriteRemoteUsecase(mockService)
+	id := domain.MetricID{ID: "up", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{})}
+	ctx := context.Background()
+
+	err := uc.Execute(ctx, &MetricWriteRemoteRequest{Body: []byte("garbage")})
+	assert.Equal(t, errors.ErrInvalidRemoteWrite, err)
+
+	mockService.EXPECT().Update(gomock.Any(), gomock.Any()).Return(nil, errors.ErrMetricIsNotUpdated)
+	err = uc.Execute(ctx, &MetricWriteRemoteRequest{Body: remoteWriteBody("up", 1, 2000)})
+	assert.Equal(t, errors.ErrMetricIsNotUpdated, err)
+
+	mockService.EXPECT().Update(gomock.Any(), []*domain.Metric{
+		{MetricID: id, Value: float64Ptr(1), UpdatedAt: time.UnixMilli(2000)},
+	}).Return(nil, nil)
+	err = uc.Execute(ctx, &MetricWriteRemoteRequest{Body: remoteWriteBody("up", 1, 2000)})
+	require.NoError(t, err)
+}
+
+func TestConvertRemoteWriteSeriesToDomain(t *testing.T) {
+	id := domain.MetricID{
+		ID:     "node_load1",
+		Type:   domain.Gauge,
+		Labels: domain.NewLabels(map[string]string{"instance": "a:9100", "job_name": "node"}),
+	}
+	other := domain.MetricID{ID: "up", Type: domain.Gauge, Labels: domain.NewLabels(map[string]string{})}
+	series := []*parsers.RemoteWriteSeries{
+		{
+			Labels: map[string]string{"__name__": "node_load1", "instance": "a:9100", "job.name": "node"},
+			Samples: []*parsers.RemoteWriteSample{
+				{Value: 3, Timestamp: 300},
+				{Value: 1, Timestamp: 100},
+				{Value: 4, Timestamp: 300},
+				{Value: math.NaN(), Timestamp: 400},
+			},
+		},
+		{Labels: map[string]string{"job": "missing name"}, Samples: []*parsers.RemoteWriteSample{{Value: 1, Timestamp: 1}}},
+		{Labels: map[string]string{"__name__": "up"}, Samples: []*parsers.RemoteWriteSample{{Value: 1, Timestamp: 50}}},
+	}
+
+	assert.Equal(t, []*domain.Metric{
+		{MetricID: id, Value: float64Ptr(1), UpdatedAt: time.UnixMilli(100)},
+		{MetricID: id, Value: float64Ptr(3), UpdatedAt: time.UnixMilli(300)},
+		{MetricID: other, Value: float64Ptr(1), UpdatedAt: time.UnixMilli(50)},
+	}, ConvertRemoteWriteSeriesToDomain(series))
+}
@@ -0,0 +1,17 @@
+package validation
+
+import (
+	"go-metrics/internal/errors"
+	"regexp"
+)
+
+var labelNameRegexp = regexp.MustCompile("^[A-Za-z_][A-Za-z0-9_]*$")
+
+func ValidateMetricLabels(labels map[string]string) error {
+	for name := range labels {
+		if !labelNameRegexp.MatchString(name) {
+			return errors.ErrInvalidMetricLabels
+		}
+	}
+	return nil
+}
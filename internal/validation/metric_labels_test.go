@@ -0,0 +1,29 @@
+package validation
+
+import (
+	"go-metrics/internal/errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMetricLabels(t *testing.T) {
+	tests := []struct {
+		name      string
+		labels    map[string]string
+		expectErr error
+	}{
+		{"nil", nil, nil},
+		{"valid", map[string]string{"host": "h-42.example.com", "_dc": "eu"}, nil},
+		{"empty value", map[string]string{"host": ""}, nil},
+		{"empty name", map[string]string{"": "v"}, errors.ErrInvalidMetricLabels},
+		{"leading digit", map[string]string{"1host": "v"}, errors.ErrInvalidMetricLabels},
+		{"dash", map[string]string{"host-name": "v"}, errors.ErrInvalidMetricLabels},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectErr, ValidateMetricLabels(tt.labels))
+		})
+	}
+}
@@ -7,8 +7,10 @@ import (
 
 func ValidateMetricType(metricType string) error {
 	mtype := domain.MetricType(metricType)
-	if mtype != domain.Gauge && mtype != domain.Counter {
+	switch mtype {
+	case domain.Gauge, domain.Counter, domain.Histogram, domain.Summary:
+		return nil
+	default:
 		return errors.ErrInvalidMetricType
 	}
-	return nil
 }
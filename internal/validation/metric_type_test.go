@@ -14,6 +14,9 @@ func TestValidateMetricType(t *testing.T) {
 	}{
 		{"gauge", nil},
 		{"counter", nil},
+		{"histogram", nil},
+		{"summary", nil},
+		{"Histogram", errors.ErrInvalidMetricType},
 		{"gauge123", errors.ErrInvalidMetricType},
 		{"invalidType", errors.ErrInvalidMetricType},
 		{"", errors.ErrInvalidMetricType},
@@ -2,7 +2,9 @@ package validation
 
 import (
 	"go-metrics/internal/converters"
+	"go-metrics/internal/domain"
 	"go-metrics/internal/errors"
+	"math"
 )
 
 func ValidateCounterValue(value string) error {
@@ -34,3 +36,42 @@ func ValidateGaugePtrValue(value *float64) error {
 	}
 	return nil
 }
+
+func ValidateHistogramPtrValue(value *domain.HistogramValue) error {
+	if value == nil || len(value.Counts) != len(value.Buckets)+1 {
+		return errors.ErrInvalidHistogramMetricValue
+	}
+	for i, bound := range value.Buckets {
+		if math.IsNaN(bound) || (i > 0 && bound <= value.Buckets[i-1]) {
+			return errors.ErrInvalidHistogramMetricValue
+		}
+	}
+	var total int64
+	for _, count := range value.Counts {
+		if count < 0 {
+			return errors.ErrInvalidHistogramMetricValue
+		}
+		total += count
+	}
+	if total != value.Count {
+		return errors.ErrInvalidHistogramMetricValue
+	}
+	return nil
+}
+
+func ValidateSummaryPtrValue(value *domain.SummaryValue) error {
+	if value == nil || value.Count < 0 {
+		return errors.ErrInvalidSummaryMetricValue
+	}
+	for _, q := range value.Quantiles {
+		if math.IsNaN(q) || q < 0 || q > 1 {
+			return errors.ErrInvalidSummaryMetricValue
+		}
+	}
+	for _, observation := range value.Observations {
+		if math.IsNaN(observation) {
+			return errors.ErrInvalidSummaryMetricValue
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"go-metrics/internal/domain"
 	"go-metrics/internal/errors"
 	"testing"
 
@@ -79,3 +80,44 @@ func TestValidateGaugePtrValue(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateHistogramPtrValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     *domain.HistogramValue
+		expectErr error
+	}{
+		{"nil", nil, errors.ErrInvalidHistogramMetricValue},
+		{"valid", &domain.HistogramValue{Buckets: []float64{1, 2}, Counts: []int64{1, 0, 2}, Count: 3, Sum: 9}, nil},
+		{"no buckets", &domain.HistogramValue{Counts: []int64{4}, Count: 4}, nil},
+		{"counts length", &domain.HistogramValue{Buckets: []float64{1, 2}, Counts: []int64{1, 0}, Count: 1}, errors.ErrInvalidHistogramMetricValue},
+		{"unsorted buckets", &domain.HistogramValue{Buckets: []float64{2, 1}, Counts: []int64{0, 0, 0}}, errors.ErrInvalidHistogramMetricValue},
+		{"negative count", &domain.HistogramValue{Buckets: []float64{1}, Counts: []int64{-1, 1}}, errors.ErrInvalidHistogramMetricValue},
+		{"count mismatch", &domain.HistogramValue{Buckets: []float64{1}, Counts: []int64{1, 1}, Count: 5}, errors.ErrInvalidHistogramMetricValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectErr, ValidateHistogramPtrValue(tt.value))
+		})
+	}
+}
+
+func TestValidateSummaryPtrValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     *domain.SummaryValue
+		expectErr error
+	}{
+		{"nil", nil, errors.ErrInvalidSummaryMetricValue},
+		{"valid", &domain.SummaryValue{Quantiles: []float64{0.5, 0.99}, Observations: []float64{1, 2}}, nil},
+		{"quantile out of range", &domain.SummaryValue{Quantiles: []float64{1.5}}, errors.ErrInvalidSummaryMetricValue},
+		{"negative count", &domain.SummaryValue{Count: -1}, errors.ErrInvalidSummaryMetricValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectErr, ValidateSummaryPtrValue(tt.value))
+		})
+	}
+}